@@ -36,6 +36,7 @@ The commands are:
     export               exports raw data from a shard to line protocol
     buildtsi.            generates tsi1 indexes from tsm1 data
     help                 display this help message
+    purgetombstones      purges data deleted by tombstones from tsm1 files
     report               displays a shard level report
     verify               verifies integrity of TSM files
 
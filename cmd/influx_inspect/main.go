@@ -13,6 +13,7 @@ import (
 	"github.com/influxdata/influxdb/cmd/influx_inspect/dumptsm"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/export"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/help"
+	"github.com/influxdata/influxdb/cmd/influx_inspect/purgetombstones"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/report"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/verify"
 	_ "github.com/influxdata/influxdb/tsdb/engine"
@@ -78,6 +79,11 @@ func (m *Main) Run(args ...string) error {
 		if err := name.Run(args...); err != nil {
 			return fmt.Errorf("buildtsi: %s", err)
 		}
+	case "purgetombstones":
+		name := purgetombstones.NewCommand()
+		if err := name.Run(args...); err != nil {
+			return fmt.Errorf("purgetombstones: %s", err)
+		}
 	case "report":
 		name := report.NewCommand()
 		if err := name.Run(args...); err != nil {
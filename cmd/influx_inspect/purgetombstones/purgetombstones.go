@@ -0,0 +1,125 @@
+// Package purgetombstones purges the data deleted by tombstones from TSM files.
+package purgetombstones
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+// Command represents the program execution for "influx_inspect purgetombstones".
+type Command struct {
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	var (
+		path      string
+		threshold int64
+		dryRun    bool
+	)
+
+	fs := flag.NewFlagSet("purgetombstones", flag.ExitOnError)
+	fs.StringVar(&path, "shard", "", "Path to the shard directory")
+	fs.Int64Var(&threshold, "threshold", 0, "Only purge files whose tombstones are at least this many bytes")
+	fs.BoolVar(&dryRun, "dry-run", false, "Only list the files that would be purged")
+
+	fs.SetOutput(cmd.Stdout)
+	fs.Usage = cmd.printUsage
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if path == "" {
+		return errors.New("shard path required")
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, fmt.Sprintf("*.%s", tsm1.TSMFileExtension)))
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	tw := tabwriter.NewWriter(cmd.Stdout, 16, 8, 0, '\t', 0)
+	defer tw.Flush()
+
+	var purged int
+	for _, f := range files {
+		size, err := tombstoneSize(f)
+		if err != nil {
+			return err
+		} else if size == 0 || size < threshold {
+			continue
+		}
+
+		if dryRun {
+			fmt.Fprintf(tw, "%s: %d bytes of tombstones\n", f, size)
+			continue
+		}
+
+		n, err := tsm1.PurgeTombstones(f)
+		if err != nil {
+			return fmt.Errorf("%s: %s", f, err)
+		}
+		fmt.Fprintf(tw, "%s: %d bytes of tombstones purged, %d blocks rewritten\n", f, size, n)
+		purged++
+	}
+
+	fmt.Fprintf(tw, "Purged files: %d / %d, in %vs\n", purged, len(files), time.Since(start).Seconds())
+	return nil
+}
+
+// tombstoneSize returns the size of the tombstones of the TSM file at path.
+func tombstoneSize(path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	defer r.Close()
+
+	return tsm1.TombstoneSize(r), nil
+}
+
+// printUsage prints the usage message to STDERR.
+func (cmd *Command) printUsage() {
+	usage := `Purges the data deleted by tombstones from the TSM files of a shard.
+Only the blocks covered by tombstones are rewritten.  The shard must not be
+in use by a running influxd.
+
+Usage: influx_inspect purgetombstones [flags]
+
+    -shard <path>
+            Path to the shard directory.
+    -threshold <bytes>
+            Only purge files whose tombstones are at least this many bytes.
+            Defaults to 0.
+    -dry-run
+            Only list the files that would be purged.
+`
+
+	fmt.Fprintf(cmd.Stdout, usage)
+}
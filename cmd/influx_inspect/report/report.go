@@ -75,10 +75,11 @@ func (cmd *Command) Run(args ...string) error {
 	start := time.Now()
 
 	tw := tabwriter.NewWriter(cmd.Stdout, 8, 2, 1, ' ', 0)
-	fmt.Fprintln(tw, strings.Join([]string{"DB", "RP", "Shard", "File", "Series", "New" + estTitle, "Min Time", "Max Time", "Load Time"}, "\t"))
+	fmt.Fprintln(tw, strings.Join([]string{"DB", "RP", "Shard", "File", "Tier", "Size", "Series", "New" + estTitle, "Min Time", "Max Time", "Load Time"}, "\t"))
 
 	minTime, maxTime := int64(math.MaxInt64), int64(math.MinInt64)
 	var fileCount int
+	var hotBytes, coldBytes int64
 	if err := cmd.WalkShardDirs(cmd.dir, func(db, rp, id, path string) error {
 		if cmd.pattern != "" && strings.Contains(path, cmd.pattern) {
 			return nil
@@ -99,6 +100,15 @@ func (cmd *Command) Run(args ...string) error {
 		loadTime := time.Since(loadStart)
 		fileCount++
 
+		// Shards moved to the cold directory are symlinked from the data directory.
+		tier, size := "hot", int64(reader.Size())
+		if isColdShardDir(filepath.Dir(path)) {
+			tier = "cold"
+			coldBytes += size
+		} else {
+			hotBytes += size
+		}
+
 		dbCount := dbCardinalities[db]
 		if dbCount == nil {
 			dbCount = newCounterFn()
@@ -154,6 +164,8 @@ func (cmd *Command) Run(args ...string) error {
 		fmt.Fprintln(tw, strings.Join([]string{
 			db, rp, id,
 			filepath.Base(file.Name()),
+			tier,
+			strconv.FormatInt(size, 10),
 			strconv.FormatInt(int64(seriesCount), 10),
 			strconv.FormatInt(int64(dbCount.Count()-oldCount), 10),
 			time.Unix(0, minT).UTC().Format(time.RFC3339Nano),
@@ -178,6 +190,8 @@ func (cmd *Command) Run(args ...string) error {
 		time.Unix(0, maxTime).UTC().Format(time.RFC3339Nano),
 	)
 	fmt.Printf("  Duration: %s \n", time.Unix(0, maxTime).Sub(time.Unix(0, minTime)))
+	fmt.Printf("  Hot Bytes: %d\n", hotBytes)
+	fmt.Printf("  Cold Bytes: %d\n", coldBytes)
 	println()
 
 	fmt.Printf("Statistics\n")
@@ -227,6 +241,13 @@ func (cmd *Command) isShardDir(dir string) error {
 	return nil
 }
 
+// isColdShardDir returns true if the shard directory is a symlink to the cold
+// directory.
+func isColdShardDir(dir string) bool {
+	fi, err := os.Lstat(dir)
+	return err == nil && fi.Mode()&os.ModeSymlink != 0
+}
+
 func (cmd *Command) WalkShardDirs(root string, fn func(db, rp, id, path string) error) error {
 	type location struct {
 		db, rp, id, path string
@@ -242,6 +263,25 @@ func (cmd *Command) WalkShardDirs(root string, fn func(db, rp, id, path string)
 			return nil
 		}
 
+		// Walk does not follow the symlinks of shards moved to the cold
+		// directory, so add their files separately.
+		if info.Mode()&os.ModeSymlink != 0 && cmd.isShardDir(path) == nil {
+			files, err := filepath.Glob(filepath.Join(path, "*."+tsm1.TSMFileExtension))
+			if err != nil {
+				return err
+			}
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			parts := strings.Split(absPath, string(filepath.Separator))
+			db, rp, id := parts[len(parts)-3], parts[len(parts)-2], parts[len(parts)-1]
+			for _, f := range files {
+				dirs = append(dirs, location{db: db, rp: rp, id: id, path: f})
+			}
+			return nil
+		}
+
 		if filepath.Ext(info.Name()) == "."+tsm1.TSMFileExtension {
 			shardDir := filepath.Dir(path)
 
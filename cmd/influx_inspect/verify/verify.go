@@ -31,8 +31,11 @@ func NewCommand() *Command {
 // Run executes the command.
 func (cmd *Command) Run(args ...string) error {
 	var path string
+	var repairPlan, repair bool
 	fs := flag.NewFlagSet("verify", flag.ExitOnError)
 	fs.StringVar(&path, "dir", os.Getenv("HOME")+"/.influxdb", "Root storage path. [$HOME/.influxdb]")
+	fs.BoolVar(&repairPlan, "repair-plan", false, "Print the plan to repair the corrupt and quarantined files.")
+	fs.BoolVar(&repair, "repair", false, "Repair the corrupt and quarantined files.")
 
 	fs.SetOutput(cmd.Stdout)
 	fs.Usage = cmd.printUsage
@@ -67,6 +70,9 @@ func (cmd *Command) Run(args ...string) error {
 
 	tw := tabwriter.NewWriter(cmd.Stdout, 16, 8, 0, '\t', 0)
 
+	// The files to repair and their corrupt blocks
+	var plan []repairStep
+
 	// Verify the checksums of every block in every file
 	for _, f := range files {
 		file, err := os.OpenFile(f, os.O_RDONLY, 0600)
@@ -79,32 +85,97 @@ func (cmd *Command) Run(args ...string) error {
 			return err
 		}
 
+		step := repairStep{path: f}
 		blockItr := reader.BlockIterator()
 		brokenFileBlocks := 0
 		count := 0
 		for blockItr.Next() {
 			totalBlocks++
-			key, _, _, _, checksum, buf, err := blockItr.Read()
+			key, minTime, maxTime, _, checksum, buf, err := blockItr.Read()
 			if err != nil {
 				brokenBlocks++
+				brokenFileBlocks++
 				fmt.Fprintf(tw, "%s: could not get checksum for key %v block %d due to error: %q\n", f, key, count, err)
 			} else if expected := crc32.ChecksumIEEE(buf); checksum != expected {
 				brokenBlocks++
+				brokenFileBlocks++
 				fmt.Fprintf(tw, "%s: got %d but expected %d for key %v, block %d\n", f, checksum, expected, key, count)
+				step.blocks = append(step.blocks, corruptBlock{key: string(key), minTime: minTime, maxTime: maxTime})
 			}
 			count++
 		}
+		step.total = count
 		if brokenFileBlocks == 0 {
 			fmt.Fprintf(tw, "%s: healthy\n", f)
 		}
 		reader.Close()
+
+		if _, err := os.Stat(fmt.Sprintf("%s.%s", f, tsm1.QuarantineFileExtension)); err == nil {
+			step.quarantined = true
+			fmt.Fprintf(tw, "%s: quarantined\n", f)
+		}
+		if len(step.blocks) > 0 || step.quarantined {
+			plan = append(plan, step)
+		}
 	}
 
 	fmt.Fprintf(tw, "Broken Blocks: %d / %d, in %vs\n", brokenBlocks, totalBlocks, time.Since(start).Seconds())
+
+	if repairPlan || repair {
+		cmd.printRepairPlan(tw, plan)
+	}
+	if repair {
+		for _, step := range plan {
+			n, err := tsm1.DropCorruptBlocks(step.path)
+			if err != nil {
+				tw.Flush()
+				return fmt.Errorf("repair %s: %v", step.path, err)
+			}
+			fmt.Fprintf(tw, "%s: repaired, %d blocks dropped\n", step.path, n)
+		}
+	}
+
 	tw.Flush()
 	return nil
 }
 
+// corruptBlock describes a block whose checksum does not match its data.
+type corruptBlock struct {
+	key              string
+	minTime, maxTime int64
+}
+
+// repairStep describes the repair of a corrupt or quarantined TSM file.
+type repairStep struct {
+	path        string
+	blocks      []corruptBlock
+	total       int
+	quarantined bool
+}
+
+// printRepairPlan prints the actions taken by -repair.
+func (cmd *Command) printRepairPlan(w io.Writer, plan []repairStep) {
+	fmt.Fprintf(w, "Repair Plan: %d files\n", len(plan))
+	for _, step := range plan {
+		switch {
+		case len(step.blocks) == 0:
+			fmt.Fprintf(w, "%s: no corrupt blocks, lift quarantine\n", step.path)
+		case len(step.blocks) == step.total:
+			fmt.Fprintf(w, "%s: all %d blocks corrupt, remove file\n", step.path, step.total)
+		default:
+			fmt.Fprintf(w, "%s: drop %d of %d blocks, rewrite file\n", step.path, len(step.blocks), step.total)
+		}
+		for _, b := range step.blocks {
+			fmt.Fprintf(w, "    drop %s\t%s\t%s\n", b.key,
+				time.Unix(0, b.minTime).UTC().Format(time.RFC3339Nano),
+				time.Unix(0, b.maxTime).UTC().Format(time.RFC3339Nano))
+		}
+	}
+	if len(plan) > 0 {
+		fmt.Fprintln(w, "The data of the dropped blocks is lost unless it is restored from a backup.")
+	}
+}
+
 // printUsage prints the usage message to STDERR.
 func (cmd *Command) printUsage() {
 	usage := fmt.Sprintf(`Verifies the integrity of TSM files.
@@ -114,6 +185,11 @@ Usage: influx_inspect verify [flags]
     -dir <path>
             Root storage path
             Defaults to "%[1]s/.influxdb".
+    -repair-plan
+            Print the plan to repair the corrupt and quarantined files.
+    -repair
+            Drop the corrupt blocks and lift the quarantine of the files.
+            influxd must not be running.
  `, os.Getenv("HOME"))
 
 	fmt.Fprintf(cmd.Stdout, usage)
@@ -0,0 +1,73 @@
+// Package rebuildindex is the rebuild-index subcommand for the influxd command,
+// for rebuilding the index of a shard on a running server.
+package rebuildindex
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/influxdata/influxdb/services/snapshotter"
+)
+
+// Command represents the program execution for "influxd rebuild-index".
+type Command struct {
+	// Standard input/output, overridden for testing.
+	Stderr io.Writer
+	Stdout io.Writer
+
+	host    string
+	shardID uint64
+}
+
+// NewCommand returns a new instance of Command with default settings.
+func NewCommand() *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.host, "host", "localhost:8088", "")
+	fs.Uint64Var(&cmd.shardID, "shard", 0, "")
+	fs.SetOutput(cmd.Stderr)
+	fs.Usage = cmd.printUsage
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if cmd.shardID == 0 {
+		cmd.printUsage()
+		return errors.New("-shard is required")
+	}
+
+	client := snapshotter.NewClient(cmd.host)
+	qid, err := client.RebuildIndex(cmd.shardID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Rebuilding index of shard %d in the background as query %d.\n", cmd.shardID, qid)
+	fmt.Fprintln(cmd.Stdout, "Use SHOW QUERIES to follow its progress and KILL QUERY to abort it.")
+	return nil
+}
+
+// printUsage prints the usage message to STDERR.
+func (cmd *Command) printUsage() {
+	fmt.Fprintf(cmd.Stderr, `
+Rebuilds the index of a shard as a tsi1 index from its data while the server
+keeps serving the shard with its current index.  The new index replaces the
+current one once it is complete.
+
+Usage: influxd rebuild-index [flags]
+
+Options:
+    -host <host:port>
+            The host to connect to. Defaults to 'localhost:8088'.
+    -shard <id>
+            Required. The id of the shard to rebuild the index of.
+`)
+}
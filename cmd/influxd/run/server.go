@@ -72,6 +72,7 @@ type Server struct {
 	TSDBStore     *tsdb.Store
 	QueryExecutor *query.Executor
 	PointsWriter  *coordinator.PointsWriter
+	QueryCache    *coordinator.QueryCache
 	Subscriber    *subscriber.Service
 
 	Services []Service
@@ -177,6 +178,13 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 	s.PointsWriter.WriteTimeout = time.Duration(c.Coordinator.WriteTimeout)
 	s.PointsWriter.TSDBStore = s.TSDBStore
 
+	// Initialize the query result cache if it is enabled.
+	if c.Coordinator.QueryCacheMaxMemorySize > 0 {
+		s.QueryCache = coordinator.NewQueryCache(int(c.Coordinator.QueryCacheMaxMemorySize))
+		s.PointsWriter.QueryCache = s.QueryCache
+		s.TSDBStore.OnShardChange = s.QueryCache.Invalidate
+	}
+
 	// Initialize query executor.
 	s.QueryExecutor = query.NewExecutor()
 	s.QueryExecutor.StatementExecutor = &coordinator.StatementExecutor{
@@ -186,16 +194,19 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 		ShardMapper: &coordinator.LocalShardMapper{
 			MetaClient: s.MetaClient,
 			TSDBStore:  coordinator.LocalTSDBStore{Store: s.TSDBStore},
+			QueryCache: s.QueryCache,
 		},
 		Monitor:           s.Monitor,
 		PointsWriter:      s.PointsWriter,
 		MaxSelectPointN:   c.Coordinator.MaxSelectPointN,
 		MaxSelectSeriesN:  c.Coordinator.MaxSelectSeriesN,
 		MaxSelectBucketsN: c.Coordinator.MaxSelectBucketsN,
+		QueryCache:        s.QueryCache,
 	}
 	s.QueryExecutor.TaskManager.QueryTimeout = time.Duration(c.Coordinator.QueryTimeout)
 	s.QueryExecutor.TaskManager.LogQueriesAfter = time.Duration(c.Coordinator.LogQueriesAfter)
 	s.QueryExecutor.TaskManager.MaxConcurrentQueries = c.Coordinator.MaxConcurrentQueries
+	s.QueryExecutor.ObserveQueryDuration = s.TSDBStore.ObserveQueryLatency
 
 	// Initialize the monitor
 	s.Monitor.Version = s.buildInfo.Version
@@ -212,6 +223,9 @@ func (s *Server) Statistics(tags map[string]string) []models.Statistic {
 	statistics = append(statistics, s.QueryExecutor.Statistics(tags)...)
 	statistics = append(statistics, s.TSDBStore.Statistics(tags)...)
 	statistics = append(statistics, s.PointsWriter.Statistics(tags)...)
+	if s.QueryCache != nil {
+		statistics = append(statistics, s.QueryCache.Statistics(tags)...)
+	}
 	statistics = append(statistics, s.Subscriber.Statistics(tags)...)
 	for _, srv := range s.Services {
 		if m, ok := srv.(monitor.Reporter); ok {
@@ -225,6 +239,7 @@ func (s *Server) appendSnapshotterService() {
 	srv := snapshotter.NewService()
 	srv.TSDBStore = s.TSDBStore
 	srv.MetaClient = s.MetaClient
+	srv.TaskManager = s.QueryExecutor.TaskManager
 	s.Services = append(s.Services, srv)
 	s.SnapshotterService = srv
 }
@@ -260,6 +275,7 @@ func (s *Server) appendHTTPDService(c httpd.Config) {
 	srv.Handler.QueryExecutor = s.QueryExecutor
 	srv.Handler.Monitor = s.Monitor
 	srv.Handler.PointsWriter = s.PointsWriter
+	srv.Handler.Compactions = s.TSDBStore
 	srv.Handler.Version = s.buildInfo.Version
 	srv.Handler.BuildType = "OSS"
 
@@ -273,6 +289,8 @@ func (s *Server) appendStorageService(c storage.Config) {
 	srv := storage.NewService(c)
 	srv.MetaClient = s.MetaClient
 	srv.TSDBStore = s.TSDBStore
+	srv.PointsWriter = s.PointsWriter
+	srv.WriteAuthorizer = meta.NewWriteAuthorizer(s.MetaClient)
 
 	s.Services = append(s.Services, srv)
 }
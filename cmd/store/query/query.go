@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -40,8 +41,11 @@ type Command struct {
 	soffset         uint64
 	desc            bool
 	silent          bool
+	explain         bool
 	expr            string
 	agg             string
+	window          time.Duration
+	windowOffset    time.Duration
 	grouping        string
 	keys            []string
 
@@ -90,8 +94,11 @@ func (cmd *Command) Run(args ...string) error {
 	fs.Uint64Var(&cmd.limit, "limit", 0, "Optional: limit number of values per series")
 	fs.BoolVar(&cmd.desc, "desc", false, "Optional: return results in descending order")
 	fs.BoolVar(&cmd.silent, "silent", false, "silence output")
+	fs.BoolVar(&cmd.explain, "explain", false, "Optional: display the estimated cost of the query rather than executing it")
 	fs.StringVar(&cmd.expr, "expr", "", "InfluxQL conditional expression")
-	fs.StringVar(&cmd.agg, "agg", "", "aggregate functions (sum, count)")
+	fs.StringVar(&cmd.agg, "agg", "", "aggregate functions (sum, count, min, max, first, last, mean)")
+	fs.DurationVar(&cmd.window, "window", 0, "Optional: apply the aggregate to windows of the specified duration (requires -agg)")
+	fs.DurationVar(&cmd.windowOffset, "window-offset", 0, "Optional: offset of the window boundaries (requires -window)")
 	fs.StringVar(&cmd.grouping, "grouping", "", "comma-separated list of tags to specify series order")
 
 	fs.SetOutput(cmd.Stdout)
@@ -160,6 +167,15 @@ func (cmd *Command) validate() error {
 	if cmd.startTime != 0 && cmd.endTime != 0 && cmd.endTime < cmd.startTime {
 		return fmt.Errorf("end time before start time")
 	}
+	if cmd.window < 0 {
+		return fmt.Errorf("window must be positive")
+	}
+	if cmd.window > 0 && cmd.aggType == storage.AggregateTypeNone {
+		return fmt.Errorf("window requires an aggregate")
+	}
+	if cmd.windowOffset != 0 && cmd.window == 0 {
+		return fmt.Errorf("window offset requires a window")
+	}
 	return nil
 }
 
@@ -181,6 +197,8 @@ func (cmd *Command) query(c storage.StorageClient) error {
 
 	if cmd.aggType != storage.AggregateTypeNone {
 		req.Aggregate = &storage.Aggregate{Type: cmd.aggType}
+		req.WindowEvery = int64(cmd.window)
+		req.WindowOffset = int64(cmd.windowOffset)
 	}
 
 	if cmd.expr != "" {
@@ -198,6 +216,10 @@ func (cmd *Command) query(c storage.StorageClient) error {
 		req.Predicate = &storage.Predicate{Root: v.nodes[0]}
 	}
 
+	if cmd.explain {
+		return cmd.explainQuery(c, &req)
+	}
+
 	stream, err := c.Read(context.Background(), &req)
 	if err != nil {
 		fmt.Fprintln(cmd.Stdout, err)
@@ -236,6 +258,23 @@ func (cmd *Command) query(c storage.StorageClient) error {
 	return nil
 }
 
+func (cmd *Command) explainQuery(c storage.StorageClient, req *storage.ReadRequest) error {
+	res, err := c.Explain(context.Background(), &storage.ExplainRequest{ReadRequest: req})
+	if err != nil {
+		fmt.Fprintln(cmd.Stdout, err)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(cmd.Stdout, 8, 8, 1, '\t', 0)
+	fmt.Fprintln(tw, "shard\tseries\tcached values\tfiles\tblocks\tblock size")
+	for _, sc := range res.Shards {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%d\n", sc.ShardID, sc.Cost.NumSeries, sc.Cost.CachedValues, sc.Cost.NumFiles, sc.Cost.BlocksRead, sc.Cost.BlockSize)
+	}
+	t := res.Total
+	fmt.Fprintf(tw, "total (%d shards)\t%d\t%d\t%d\t%d\t%d\n", t.NumShards, t.NumSeries, t.CachedValues, t.NumFiles, t.BlocksRead, t.BlockSize)
+	return tw.Flush()
+}
+
 func (cmd *Command) processFramesSilent(frames []storage.ReadResponse_Frame) {
 	for _, frame := range frames {
 		switch f := frame.Data.(type) {
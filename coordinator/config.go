@@ -25,6 +25,10 @@ const (
 	// DefaultMaxSelectSeriesN is the maximum number of series a SELECT can run.
 	// A value of zero will make the maximum series count unlimited.
 	DefaultMaxSelectSeriesN = 0
+
+	// DefaultQueryCacheMaxMemorySize is the maximum size of the query result cache.
+	// A value of zero will disable the cache.
+	DefaultQueryCacheMaxMemorySize = 0
 )
 
 // Config represents the configuration for the coordinator service.
@@ -36,6 +40,8 @@ type Config struct {
 	MaxSelectPointN      int           `toml:"max-select-point"`
 	MaxSelectSeriesN     int           `toml:"max-select-series"`
 	MaxSelectBucketsN    int           `toml:"max-select-buckets"`
+
+	QueryCacheMaxMemorySize toml.Size `toml:"query-cache-max-memory-size"`
 }
 
 // NewConfig returns an instance of Config with defaults.
@@ -46,6 +52,8 @@ func NewConfig() Config {
 		MaxConcurrentQueries: DefaultMaxConcurrentQueries,
 		MaxSelectPointN:      DefaultMaxSelectPointN,
 		MaxSelectSeriesN:     DefaultMaxSelectSeriesN,
+
+		QueryCacheMaxMemorySize: DefaultQueryCacheMaxMemorySize,
 	}
 }
 
@@ -59,5 +67,7 @@ func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 		"max-select-point":       c.MaxSelectPointN,
 		"max-select-series":      c.MaxSelectSeriesN,
 		"max-select-buckets":     c.MaxSelectBucketsN,
+
+		"query-cache-max-memory-size": c.QueryCacheMaxMemorySize,
 	}), nil
 }
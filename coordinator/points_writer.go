@@ -57,8 +57,13 @@ type PointsWriter struct {
 	TSDBStore interface {
 		CreateShard(database, retentionPolicy string, shardID uint64, enabled bool) error
 		WriteToShard(shardID uint64, points []models.Point) error
+		BulkLoadShard(shardID uint64, points []models.Point) error
 	}
 
+	// QueryCache is invalidated for the time range of points written to each
+	// shard. The cache is not used if it is nil.
+	QueryCache *QueryCache
+
 	subPoints []chan<- *WritePointsRequest
 
 	stats *WriteStatistics
@@ -288,6 +293,19 @@ func (w *PointsWriter) WritePoints(database, retentionPolicy string, consistency
 
 // WritePointsPrivileged writes the data to the underlying storage, consitencyLevel is only used for clustered scenarios
 func (w *PointsWriter) WritePointsPrivileged(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+	return w.writePoints(database, retentionPolicy, points, w.TSDBStore.WriteToShard)
+}
+
+// BulkLoadPoints writes the data to the underlying storage like WritePoints,
+// but the points of each shard are sorted and written directly to new TSM
+// files instead of the WAL and the cache.  It is meant for backfilling large
+// amounts of historical or unsorted data.
+func (w *PointsWriter) BulkLoadPoints(database, retentionPolicy string, user meta.User, points []models.Point) error {
+	return w.writePoints(database, retentionPolicy, points, w.TSDBStore.BulkLoadShard)
+}
+
+// writePoints maps points to their shards and writes them with write.
+func (w *PointsWriter) writePoints(database, retentionPolicy string, points []models.Point, write func(shardID uint64, points []models.Point) error) error {
 	atomic.AddInt64(&w.stats.WriteReq, 1)
 	atomic.AddInt64(&w.stats.PointWriteReq, int64(len(points)))
 
@@ -308,7 +326,7 @@ func (w *PointsWriter) WritePointsPrivileged(database, retentionPolicy string, c
 	ch := make(chan error, len(shardMappings.Points))
 	for shardID, points := range shardMappings.Points {
 		go func(shard *meta.ShardInfo, database, retentionPolicy string, points []models.Point) {
-			ch <- w.writeToShard(shard, database, retentionPolicy, points)
+			ch <- w.writeToShard(shard, database, retentionPolicy, points, write)
 		}(shardMappings.Shards[shardID], database, retentionPolicy, points)
 	}
 
@@ -358,11 +376,17 @@ func (w *PointsWriter) WritePointsPrivileged(database, retentionPolicy string, c
 	return err
 }
 
-// writeToShards writes points to a shard.
-func (w *PointsWriter) writeToShard(shard *meta.ShardInfo, database, retentionPolicy string, points []models.Point) error {
+// writeToShards writes points to a shard with write.
+func (w *PointsWriter) writeToShard(shard *meta.ShardInfo, database, retentionPolicy string, points []models.Point, write func(shardID uint64, points []models.Point) error) error {
 	atomic.AddInt64(&w.stats.PointWriteReqLocal, int64(len(points)))
 
-	err := w.TSDBStore.WriteToShard(shard.ID, points)
+	// Invalidate any cached results once the points may be visible to
+	// queries, even if only some of the points were written.
+	if w.QueryCache != nil {
+		defer w.QueryCache.InvalidatePoints(shard.ID, points)
+	}
+
+	err := write(shard.ID, points)
 	if err == nil {
 		atomic.AddInt64(&w.stats.WriteOK, 1)
 		return nil
@@ -385,7 +409,7 @@ func (w *PointsWriter) writeToShard(shard *meta.ShardInfo, database, retentionPo
 			return err
 		}
 	}
-	err = w.TSDBStore.WriteToShard(shard.ID, points)
+	err = write(shard.ID, points)
 	if err != nil {
 		w.Logger.Info("Write failed", zap.Uint64("shard", shard.ID), zap.Error(err))
 		atomic.AddInt64(&w.stats.WriteErr, 1)
@@ -550,6 +550,7 @@ var shardID uint64
 
 type fakeStore struct {
 	WriteFn       func(shardID uint64, points []models.Point) error
+	BulkLoadFn    func(shardID uint64, points []models.Point) error
 	CreateShardfn func(database, retentionPolicy string, shardID uint64, enabled bool) error
 }
 
@@ -557,6 +558,10 @@ func (f *fakeStore) WriteToShard(shardID uint64, points []models.Point) error {
 	return f.WriteFn(shardID, points)
 }
 
+func (f *fakeStore) BulkLoadShard(shardID uint64, points []models.Point) error {
+	return f.BulkLoadFn(shardID, points)
+}
+
 func (f *fakeStore) CreateShard(database, retentionPolicy string, shardID uint64, enabled bool) error {
 	return f.CreateShardfn(database, retentionPolicy, shardID, enabled)
 }
@@ -0,0 +1,664 @@
+package coordinator
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
+)
+
+// The keys for statistics generated by the query cache.
+const (
+	statQueryCacheHits          = "hits"
+	statQueryCacheMisses        = "misses"
+	statQueryCacheInvalidations = "invalidations"
+	statQueryCacheEvictions     = "evictions"
+	statQueryCacheRejections    = "rejections"
+	statQueryCacheEntries       = "entries"
+	statQueryCacheMemoryBytes   = "memBytes"
+)
+
+const (
+	// queryCacheMaxWindows is the maximum number of windows that a single
+	// iterator will attempt to cache.
+	queryCacheMaxWindows = 10000
+
+	// queryCacheWriteLogSize is the number of writes remembered per shard so
+	// results computed concurrently with a write can be checked before they
+	// are cached.
+	queryCacheWriteLogSize = 64
+
+	// queryCacheWindowOverhead is the approximate memory used by each cached
+	// window in addition to its data.
+	queryCacheWindowOverhead = 64
+)
+
+// queryCacheFunctions are the aggregate functions whose results are cached.
+// The results of these functions from each shard are combined by merging them.
+var queryCacheFunctions = map[string]struct{}{
+	"count": {},
+	"sum":   {},
+	"mean":  {},
+	"min":   {},
+	"max":   {},
+	"first": {},
+	"last":  {},
+}
+
+// QueryCache caches the results of aggregate queries grouped by time.
+//
+// Results are cached per shard for each window of a query that ended before
+// the query was executed. As the time range of a repeated query moves forward,
+// only the windows that are new need to be read from the shard. Writes to a
+// shard invalidate any cached windows that overlap the written points.
+type QueryCache struct {
+	mu      sync.Mutex
+	maxSize int
+	size    int
+	entries map[string]*list.Element // entries by key
+	lru     *list.List               // least recently used entry at the back
+	shards  map[uint64]*queryCacheShard
+	epoch   uint64 // incremented when the cache is cleared
+
+	stats *QueryCacheStatistics
+
+	// now returns the current time. Windows ending after this time are not
+	// cached.
+	now func() time.Time
+}
+
+// NewQueryCache returns a new QueryCache that holds at most maxSize bytes.
+func NewQueryCache(maxSize int) *QueryCache {
+	return &QueryCache{
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		shards:  make(map[uint64]*queryCacheShard),
+		stats:   &QueryCacheStatistics{},
+		now:     time.Now,
+	}
+}
+
+// QueryCacheStatistics keeps statistics related to the QueryCache.
+type QueryCacheStatistics struct {
+	Hits          int64
+	Misses        int64
+	Invalidations int64
+	Evictions     int64
+	Rejections    int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (c *QueryCache) Statistics(tags map[string]string) []models.Statistic {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return []models.Statistic{{
+		Name: "queryCache",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statQueryCacheHits:          c.stats.Hits,
+			statQueryCacheMisses:        c.stats.Misses,
+			statQueryCacheInvalidations: c.stats.Invalidations,
+			statQueryCacheEvictions:     c.stats.Evictions,
+			statQueryCacheRejections:    c.stats.Rejections,
+			statQueryCacheEntries:       len(c.entries),
+			statQueryCacheMemoryBytes:   c.size,
+		},
+	}}
+}
+
+// Invalidate removes any cached windows of the shard that overlap the time
+// range between min and max, inclusive.
+func (c *QueryCache) Invalidate(shardID uint64, min, max int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.shard(shardID)
+	s.gen++
+	s.log = append(s.log, queryCacheWrite{gen: s.gen, min: min, max: max})
+	if len(s.log) > queryCacheWriteLogSize {
+		s.log = s.log[len(s.log)-queryCacheWriteLogSize:]
+	}
+
+	for e := range s.entries {
+		if min >= e.maxEnd {
+			continue
+		}
+		for start, w := range e.windows {
+			if start <= max && w.end > min {
+				delete(e.windows, start)
+				e.size -= w.size()
+				c.size -= w.size()
+				c.stats.Invalidations++
+			}
+		}
+	}
+}
+
+// InvalidatePoints removes any cached windows of the shard that overlap the
+// points.
+func (c *QueryCache) InvalidatePoints(shardID uint64, points []models.Point) {
+	if len(points) == 0 {
+		return
+	}
+
+	min, max := points[0].UnixNano(), points[0].UnixNano()
+	for _, p := range points[1:] {
+		if t := p.UnixNano(); t < min {
+			min = t
+		} else if t > max {
+			max = t
+		}
+	}
+	c.Invalidate(shardID, min, max)
+}
+
+// Clear removes all entries from the cache. Results being computed when the
+// cache is cleared will not be cached.
+func (c *QueryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.shards = make(map[uint64]*queryCacheShard)
+	c.size = 0
+}
+
+// ShardGroup returns a tsdb.ShardGroup for the shards that caches the results
+// of each shard. The shard groups are retrieved using fn.
+func (c *QueryCache) ShardGroup(fn func(ids []uint64) tsdb.ShardGroup, ids []uint64) tsdb.ShardGroup {
+	g := &queryCacheShardGroup{
+		ShardGroup: fn(ids),
+		cache:      c,
+		shards:     make([]queryCacheShardGroupItem, len(ids)),
+	}
+	for i, id := range ids {
+		g.shards[i] = queryCacheShardGroupItem{id: id, sg: fn([]uint64{id})}
+	}
+	return g
+}
+
+// shard returns the state of the shard, creating it if required.
+// Must be called with the lock held.
+func (c *QueryCache) shard(id uint64) *queryCacheShard {
+	s := c.shards[id]
+	if s == nil {
+		s = &queryCacheShard{entries: make(map[*queryCacheEntry]struct{})}
+		c.shards[id] = s
+	}
+	return s
+}
+
+// cacheable returns true if the results of the iterator options can be cached.
+func (c *QueryCache) cacheable(m *influxql.Measurement, opt query.IteratorOptions) bool {
+	call, ok := opt.Expr.(*influxql.Call)
+	if !ok {
+		return false
+	} else if _, ok := queryCacheFunctions[call.Name]; !ok {
+		return false
+	}
+	return m.Regex == nil && !opt.Interval.IsZero() && opt.Ascending &&
+		opt.Limit == 0 && opt.Offset == 0 && query.AuthorizerIsOpen(opt.Authorizer)
+}
+
+// key returns the key of the results of the iterator options for a shard.
+// The key excludes the time range so that the windows of queries over
+// different time ranges are shared.
+func (c *QueryCache) key(shardID uint64, m *influxql.Measurement, opt query.IteratorOptions) (string, error) {
+	opt.StartTime, opt.EndTime = 0, 0
+	opt.InterruptCh = nil
+	opt.Authorizer = nil
+	opt.MaxSeriesN = 0
+	// The grouping is also held in the dimensions, which are ordered.
+	opt.GroupBy = nil
+
+	buf, err := opt.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d\x00%s\x00%s", shardID, m.Name, buf), nil
+}
+
+// windows returns the start and end of each window in the time range of opt
+// that is complete and ended before now. Returns nil if there are too many
+// windows to cache.
+func (c *QueryCache) windows(opt query.IteratorOptions, now int64) []queryCacheRange {
+	end := opt.EndTime + 1
+	if now < end {
+		end = now
+	}
+
+	var windows []queryCacheRange
+	start, stop := opt.Window(opt.StartTime)
+	if start < opt.StartTime {
+		start, stop = opt.Window(stop)
+	}
+	for stop <= end && start < stop {
+		if len(windows) == queryCacheMaxWindows {
+			return nil
+		}
+		windows = append(windows, queryCacheRange{start: start, end: stop})
+		start, stop = opt.Window(stop)
+	}
+	return windows
+}
+
+// createIterator creates an iterator for the shard, reading the cached results
+// for any complete windows and caching the results of windows that are read.
+func (c *QueryCache) createIterator(ctx context.Context, shardID uint64, sg tsdb.ShardGroup, m *influxql.Measurement, opt query.IteratorOptions) (query.Iterator, error) {
+	windows := c.windows(opt, c.now().UnixNano())
+	if len(windows) == 0 {
+		return sg.CreateIterator(ctx, m, opt)
+	}
+
+	key, err := c.key(shardID, m, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Split the complete windows into runs of windows that are either all
+	// cached or all missing.
+	c.mu.Lock()
+	var runs []queryCacheRun
+	var typ influxql.DataType
+	if elem := c.entries[key]; elem != nil {
+		e := elem.Value.(*queryCacheEntry)
+		typ = e.typ
+		c.lru.MoveToFront(elem)
+		for _, w := range windows {
+			cw := e.windows[w.start]
+			if cw != nil && cw.end != w.end {
+				cw = nil
+			}
+			if n := len(runs); n > 0 && runs[n-1].cached == (cw != nil) {
+				runs[n-1].end = w.end
+				runs[n-1].windowN++
+			} else {
+				runs = append(runs, queryCacheRun{queryCacheRange: w, windowN: 1, cached: cw != nil})
+			}
+			if cw != nil {
+				runs[len(runs)-1].data = append(runs[len(runs)-1].data, cw.data...)
+			}
+		}
+	} else {
+		runs = append(runs, queryCacheRun{
+			queryCacheRange: queryCacheRange{start: windows[0].start, end: windows[len(windows)-1].end},
+			windowN:         len(windows),
+		})
+	}
+	gen, epoch := c.shard(shardID).gen, c.epoch
+	for _, r := range runs {
+		if r.cached {
+			c.stats.Hits += int64(r.windowN)
+		} else {
+			c.stats.Misses += int64(r.windowN)
+		}
+	}
+	c.mu.Unlock()
+
+	var itrs []query.Iterator
+	if err := func() error {
+		// Read the incomplete window at the start of the time range.
+		if opt.StartTime < windows[0].start {
+			subOpt := opt
+			subOpt.EndTime = windows[0].start - 1
+			itr, err := sg.CreateIterator(ctx, m, subOpt)
+			if err != nil {
+				return err
+			}
+			itrs = append(itrs, itr)
+		}
+
+		for _, r := range runs {
+			if !r.cached {
+				// Read the missing windows from the shard and cache them.
+				data, t, err := c.read(ctx, sg, m, opt, r.queryCacheRange)
+				if err != nil {
+					return err
+				}
+				if typ == influxql.Unknown {
+					typ = t
+				}
+				c.store(key, shardID, t, data, gen, epoch)
+
+				for _, w := range data {
+					r.data = append(r.data, w.data...)
+				}
+			}
+
+			if len(r.data) > 0 {
+				itrs = append(itrs, query.NewReaderIterator(ctx, bytes.NewReader(r.data), typ, query.IteratorStats{}))
+			}
+		}
+
+		// Read the windows after the last complete window.
+		if last := windows[len(windows)-1].end; last <= opt.EndTime {
+			subOpt := opt
+			subOpt.StartTime = last
+			itr, err := sg.CreateIterator(ctx, m, subOpt)
+			if err != nil {
+				return err
+			}
+			itrs = append(itrs, itr)
+		}
+		return nil
+	}(); err != nil {
+		query.Iterators(itrs).Close()
+		return nil, err
+	}
+	return query.Iterators(itrs).Merge(opt)
+}
+
+// read reads the windows within the time range from the shard and encodes the
+// points of each window.
+func (c *QueryCache) read(ctx context.Context, sg tsdb.ShardGroup, m *influxql.Measurement, opt query.IteratorOptions, r queryCacheRange) ([]*queryCacheWindow, influxql.DataType, error) {
+	subOpt := opt
+	subOpt.StartTime, subOpt.EndTime = r.start, r.end-1
+
+	itr, err := sg.CreateIterator(ctx, m, subOpt)
+	if err != nil {
+		return nil, influxql.Unknown, err
+	}
+
+	// Create a window for every window in the range, even if it is empty, so
+	// that empty windows are cached.
+	var windows []*queryCacheWindow
+	for start, end := opt.Window(r.start); start < r.end; start, end = opt.Window(end) {
+		windows = append(windows, &queryCacheWindow{start: start, end: end})
+	}
+	if itr == nil {
+		return windows, influxql.Unknown, nil
+	}
+	defer itr.Close()
+
+	// The results are ordered by window so the current window only moves
+	// forward.
+	var buf bytes.Buffer
+	i := 0
+	add := func(t int64, encode func(w io.Writer) error) error {
+		for i < len(windows)-1 && t >= windows[i].end {
+			i++
+		}
+		buf.Reset()
+		if err := encode(&buf); err != nil {
+			return err
+		}
+		windows[i].data = append(windows[i].data, buf.Bytes()...)
+		return nil
+	}
+
+	var typ influxql.DataType
+	switch itr := itr.(type) {
+	case query.FloatIterator:
+		typ = influxql.Float
+		err = readQueryCacheFloats(itr, func(p *query.FloatPoint) error {
+			return add(p.Time, func(w io.Writer) error { return query.NewFloatPointEncoder(w).EncodeFloatPoint(p) })
+		})
+	case query.IntegerIterator:
+		typ = influxql.Integer
+		err = readQueryCacheIntegers(itr, func(p *query.IntegerPoint) error {
+			return add(p.Time, func(w io.Writer) error { return query.NewIntegerPointEncoder(w).EncodeIntegerPoint(p) })
+		})
+	case query.UnsignedIterator:
+		typ = influxql.Unsigned
+		err = readQueryCacheUnsigneds(itr, func(p *query.UnsignedPoint) error {
+			return add(p.Time, func(w io.Writer) error { return query.NewUnsignedPointEncoder(w).EncodeUnsignedPoint(p) })
+		})
+	case query.StringIterator:
+		typ = influxql.String
+		err = readQueryCacheStrings(itr, func(p *query.StringPoint) error {
+			return add(p.Time, func(w io.Writer) error { return query.NewStringPointEncoder(w).EncodeStringPoint(p) })
+		})
+	case query.BooleanIterator:
+		typ = influxql.Boolean
+		err = readQueryCacheBooleans(itr, func(p *query.BooleanPoint) error {
+			return add(p.Time, func(w io.Writer) error { return query.NewBooleanPointEncoder(w).EncodeBooleanPoint(p) })
+		})
+	default:
+		return nil, influxql.Unknown, fmt.Errorf("unsupported iterator type for query cache: %T", itr)
+	}
+	if err != nil {
+		return nil, influxql.Unknown, err
+	}
+	return windows, typ, nil
+}
+
+// store adds the windows to the cache entry with the key. The windows are not
+// stored if the cache was cleared or the shard was written to within the time
+// range of the windows after gen.
+func (c *QueryCache) store(key string, shardID uint64, typ influxql.DataType, windows []*queryCacheWindow, gen, epoch uint64) {
+	if len(windows) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.shard(shardID)
+	if epoch != c.epoch || !s.unchangedSince(gen, windows[0].start, windows[len(windows)-1].end) {
+		c.stats.Rejections++
+		return
+	}
+
+	var e *queryCacheEntry
+	if elem := c.entries[key]; elem != nil {
+		e = elem.Value.(*queryCacheEntry)
+		c.lru.MoveToFront(elem)
+	} else {
+		e = &queryCacheEntry{key: key, shardID: shardID, windows: make(map[int64]*queryCacheWindow)}
+		c.entries[key] = c.lru.PushFront(e)
+		s.entries[e] = struct{}{}
+	}
+
+	if e.typ == influxql.Unknown {
+		e.typ = typ
+	} else if typ != influxql.Unknown && typ != e.typ {
+		// The type of the results has changed so the windows cannot be
+		// combined with the cached windows.
+		c.stats.Rejections++
+		return
+	}
+
+	for _, w := range windows {
+		if old := e.windows[w.start]; old != nil {
+			e.size -= old.size()
+			c.size -= old.size()
+		}
+		e.windows[w.start] = w
+		e.size += w.size()
+		c.size += w.size()
+		if w.end > e.maxEnd {
+			e.maxEnd = w.end
+		}
+	}
+	c.evict()
+}
+
+// evict removes the least recently used entries until the cache is within its
+// maximum size. Must be called with the lock held.
+func (c *QueryCache) evict() {
+	for c.size > c.maxSize {
+		elem := c.lru.Back()
+		if elem == nil {
+			return
+		}
+		e := elem.Value.(*queryCacheEntry)
+		c.lru.Remove(elem)
+		delete(c.entries, e.key)
+		if s := c.shards[e.shardID]; s != nil {
+			delete(s.entries, e)
+		}
+		c.size -= e.size
+		c.stats.Evictions++
+	}
+}
+
+// queryCacheRange is a time range of windows, from start inclusive to end
+// exclusive.
+type queryCacheRange struct {
+	start, end int64
+}
+
+// queryCacheRun is a range of windows that are either all cached or all
+// missing from the cache.
+type queryCacheRun struct {
+	queryCacheRange
+	windowN int
+	cached  bool
+	data    []byte // encoded points of the windows
+}
+
+// queryCacheWindow holds the encoded points of a window.
+type queryCacheWindow struct {
+	start, end int64
+	data       []byte
+}
+
+func (w *queryCacheWindow) size() int { return len(w.data) + queryCacheWindowOverhead }
+
+// queryCacheEntry holds the cached windows for a shard and set of iterator
+// options.
+type queryCacheEntry struct {
+	key     string
+	shardID uint64
+	typ     influxql.DataType
+	windows map[int64]*queryCacheWindow
+	maxEnd  int64 // greatest end of any window that has been cached
+	size    int
+}
+
+// queryCacheShard holds the cache state of a shard.
+type queryCacheShard struct {
+	entries map[*queryCacheEntry]struct{}
+	gen     uint64
+	log     []queryCacheWrite
+}
+
+// queryCacheWrite records the time range of a write to a shard.
+type queryCacheWrite struct {
+	gen      uint64
+	min, max int64
+}
+
+// unchangedSince returns true if no write after gen overlapped the time range
+// from start inclusive to end exclusive.
+func (s *queryCacheShard) unchangedSince(gen uint64, start, end int64) bool {
+	if s.gen == gen {
+		return true
+	} else if len(s.log) == 0 || s.log[0].gen > gen+1 {
+		// The writes since gen are no longer known.
+		return false
+	}
+	for _, w := range s.log {
+		if w.gen > gen && w.min < end && w.max >= start {
+			return false
+		}
+	}
+	return true
+}
+
+// queryCacheShardGroup is a tsdb.ShardGroup that caches the results of each
+// of its shards.
+type queryCacheShardGroup struct {
+	tsdb.ShardGroup
+	cache  *QueryCache
+	shards []queryCacheShardGroupItem
+}
+
+type queryCacheShardGroupItem struct {
+	id uint64
+	sg tsdb.ShardGroup
+}
+
+// CreateIterator creates an iterator for the measurement, reading the results
+// of each shard from the cache where possible.
+func (g *queryCacheShardGroup) CreateIterator(ctx context.Context, m *influxql.Measurement, opt query.IteratorOptions) (query.Iterator, error) {
+	if !g.cache.cacheable(m, opt) {
+		return g.ShardGroup.CreateIterator(ctx, m, opt)
+	}
+
+	itrs := make([]query.Iterator, 0, len(g.shards))
+	for _, sh := range g.shards {
+		itr, err := g.cache.createIterator(ctx, sh.id, sh.sg, m, opt)
+		if err != nil {
+			query.Iterators(itrs).Close()
+			return nil, err
+		} else if itr == nil {
+			continue
+		}
+		itrs = append(itrs, itr)
+
+		select {
+		case <-opt.InterruptCh:
+			query.Iterators(itrs).Close()
+			return nil, query.ErrQueryInterrupted
+		default:
+		}
+	}
+	return query.Iterators(itrs).Merge(opt)
+}
+
+func readQueryCacheFloats(itr query.FloatIterator, fn func(p *query.FloatPoint) error) error {
+	for {
+		p, err := itr.Next()
+		if err != nil || p == nil {
+			return err
+		} else if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+func readQueryCacheIntegers(itr query.IntegerIterator, fn func(p *query.IntegerPoint) error) error {
+	for {
+		p, err := itr.Next()
+		if err != nil || p == nil {
+			return err
+		} else if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+func readQueryCacheUnsigneds(itr query.UnsignedIterator, fn func(p *query.UnsignedPoint) error) error {
+	for {
+		p, err := itr.Next()
+		if err != nil || p == nil {
+			return err
+		} else if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+func readQueryCacheStrings(itr query.StringIterator, fn func(p *query.StringPoint) error) error {
+	for {
+		p, err := itr.Next()
+		if err != nil || p == nil {
+			return err
+		} else if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+func readQueryCacheBooleans(itr query.BooleanIterator, fn func(p *query.BooleanPoint) error) error {
+	for {
+		p, err := itr.Next()
+		if err != nil || p == nil {
+			return err
+		} else if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
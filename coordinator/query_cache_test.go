@@ -0,0 +1,145 @@
+package coordinator_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/coordinator"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
+)
+
+func TestQueryCache(t *testing.T) {
+	// The shard returns the sum of each window, which is the number of
+	// windows since the epoch.
+	var ranges [][2]time.Duration
+	var sh MockShard
+	sh.CreateIteratorFn = func(ctx context.Context, m *influxql.Measurement, opt query.IteratorOptions) (query.Iterator, error) {
+		ranges = append(ranges, [2]time.Duration{time.Duration(opt.StartTime), time.Duration(opt.EndTime)})
+
+		var points []query.FloatPoint
+		for ts := opt.StartTime; ts <= opt.EndTime; ts += int64(10 * time.Second) {
+			points = append(points, query.FloatPoint{
+				Name:  "cpu",
+				Time:  ts,
+				Value: float64(ts / int64(10*time.Second)),
+			})
+		}
+		return &FloatIterator{Points: points}, nil
+	}
+
+	cache := coordinator.NewQueryCache(1 << 20)
+	sg := cache.ShardGroup(func(ids []uint64) tsdb.ShardGroup {
+		return &sh
+	}, []uint64{1})
+
+	opt := query.IteratorOptions{
+		Expr:      influxql.MustParseExpr(`sum(value)`),
+		StartTime: 0,
+		EndTime:   int64(60*time.Second) - 1,
+		Interval:  query.Interval{Duration: 10 * time.Second},
+		Ascending: true,
+	}
+	m := &influxql.Measurement{Name: "cpu"}
+
+	read := func() []float64 {
+		itr, err := sg.CreateIterator(context.Background(), m, opt)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		defer itr.Close()
+
+		var values []float64
+		for {
+			p, err := itr.(query.FloatIterator).Next()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			} else if p == nil {
+				return values
+			}
+			values = append(values, p.Value)
+		}
+	}
+	exp := []float64{0, 1, 2, 3, 4, 5}
+
+	// The first query reads every window from the shard.
+	if values := read(); !reflect.DeepEqual(values, exp) {
+		t.Fatalf("unexpected values: %v", values)
+	} else if exp := [][2]time.Duration{{0, 60*time.Second - 1}}; !reflect.DeepEqual(ranges, exp) {
+		t.Fatalf("unexpected ranges: %v", ranges)
+	}
+
+	// The second query reads every window from the cache.
+	ranges = nil
+	if values := read(); !reflect.DeepEqual(values, exp) {
+		t.Fatalf("unexpected values: %v", values)
+	} else if len(ranges) != 0 {
+		t.Fatalf("unexpected ranges: %v", ranges)
+	}
+
+	// A write invalidates the window containing it, which is read from the
+	// shard again.
+	cache.Invalidate(1, int64(25*time.Second), int64(25*time.Second))
+	ranges = nil
+	if values := read(); !reflect.DeepEqual(values, exp) {
+		t.Fatalf("unexpected values: %v", values)
+	} else if exp := [][2]time.Duration{{20 * time.Second, 30*time.Second - 1}}; !reflect.DeepEqual(ranges, exp) {
+		t.Fatalf("unexpected ranges: %v", ranges)
+	}
+
+	// Windows outside of the cached time range are read from the shard.
+	opt.StartTime, opt.EndTime = int64(35*time.Second), int64(70*time.Second)-1
+	ranges = nil
+	if values := read(); !reflect.DeepEqual(values, []float64{3, 4, 5, 6}) {
+		t.Fatalf("unexpected values: %v", values)
+	} else if exp := [][2]time.Duration{
+		{35 * time.Second, 40*time.Second - 1},
+		{60 * time.Second, 70*time.Second - 1},
+	}; !reflect.DeepEqual(ranges, exp) {
+		t.Fatalf("unexpected ranges: %v", ranges)
+	}
+
+	stats := cache.Statistics(nil)
+	if len(stats) != 1 {
+		t.Fatalf("unexpected number of statistics: %d", len(stats))
+	} else if hits := stats[0].Values["hits"]; hits != int64(13) {
+		t.Fatalf("unexpected hits: %v", hits)
+	} else if misses := stats[0].Values["misses"]; misses != int64(8) {
+		t.Fatalf("unexpected misses: %v", misses)
+	} else if invalidations := stats[0].Values["invalidations"]; invalidations != int64(1) {
+		t.Fatalf("unexpected invalidations: %v", invalidations)
+	}
+}
+
+func TestQueryCache_Uncacheable(t *testing.T) {
+	var n int
+	var sh MockShard
+	sh.CreateIteratorFn = func(ctx context.Context, m *influxql.Measurement, opt query.IteratorOptions) (query.Iterator, error) {
+		n++
+		return &FloatIterator{}, nil
+	}
+
+	cache := coordinator.NewQueryCache(1 << 20)
+	sg := cache.ShardGroup(func(ids []uint64) tsdb.ShardGroup {
+		return &sh
+	}, []uint64{1})
+
+	// Raw queries are not cached.
+	opt := query.IteratorOptions{
+		Expr:      &influxql.VarRef{Val: "value"},
+		StartTime: 0,
+		EndTime:   int64(60*time.Second) - 1,
+		Ascending: true,
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := sg.CreateIterator(context.Background(), &influxql.Measurement{Name: "cpu"}, opt); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if n != 2 {
+		t.Fatalf("unexpected number of iterators created: %d", n)
+	}
+}
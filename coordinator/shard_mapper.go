@@ -27,6 +27,10 @@ type LocalShardMapper struct {
 	TSDBStore interface {
 		ShardGroup(ids []uint64) tsdb.ShardGroup
 	}
+
+	// QueryCache caches the results of aggregate queries for each shard.
+	// The cache is not used if it is nil.
+	QueryCache *QueryCache
 }
 
 // MapShards maps the sources to the appropriate shards into an IteratorCreator.
@@ -72,7 +76,11 @@ func (e *LocalShardMapper) mapShards(a *LocalShardMapping, sources influxql.Sour
 						shardIDs = append(shardIDs, si.ID)
 					}
 				}
-				a.ShardMap[source] = e.TSDBStore.ShardGroup(shardIDs)
+				if e.QueryCache != nil {
+					a.ShardMap[source] = e.QueryCache.ShardGroup(e.TSDBStore.ShardGroup, shardIDs)
+				} else {
+					a.ShardMap[source] = e.TSDBStore.ShardGroup(shardIDs)
+				}
 			}
 		case *influxql.SubQuery:
 			if err := e.mapShards(a, s.Statement.Sources, tmin, tmax); err != nil {
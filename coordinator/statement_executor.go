@@ -53,6 +53,10 @@ type StatementExecutor struct {
 	MaxSelectPointN   int
 	MaxSelectSeriesN  int
 	MaxSelectBucketsN int
+
+	// QueryCache is cleared when data is deleted. The cache is not used if
+	// it is nil.
+	QueryCache *QueryCache
 }
 
 // ExecuteStatement executes the given statement with the given execution context.
@@ -180,6 +184,8 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx *query
 		rows, err = e.executeShowRetentionPoliciesStatement(stmt)
 	case *influxql.ShowSeriesCardinalityStatement:
 		rows, err = e.executeShowSeriesCardinalityStatement(stmt)
+	case *query.ShowSeriesLimitsStatement:
+		rows, err = e.executeShowSeriesLimitsStatement(stmt)
 	case *influxql.ShowShardsStatement:
 		rows, err = e.executeShowShardsStatement(stmt)
 	case *influxql.ShowShardGroupsStatement:
@@ -206,6 +212,17 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx *query
 		return query.ErrInvalidQuery
 	}
 
+	// Cached query results may include deleted data. The cache is cleared
+	// even if the statement failed as some of the data may have been deleted.
+	if e.QueryCache != nil {
+		switch stmt.(type) {
+		case *influxql.DeleteSeriesStatement, *influxql.DropDatabaseStatement,
+			*influxql.DropMeasurementStatement, *influxql.DropSeriesStatement,
+			*influxql.DropRetentionPolicyStatement, *influxql.DropShardStatement:
+			e.QueryCache.Clear()
+		}
+	}
+
 	if err != nil {
 		return err
 	}
@@ -412,6 +429,7 @@ func (e *StatementExecutor) executeExplainStatement(q *influxql.ExplainStatement
 		MaxSeriesN:  e.MaxSelectSeriesN,
 		MaxBucketsN: e.MaxSelectBucketsN,
 		Authorizer:  ctx.Authorizer,
+		Join:        ctx.Join,
 	}
 
 	// Prepare the query for execution, but do not actually execute it.
@@ -446,7 +464,7 @@ func (e *StatementExecutor) executeExplainAnalyzeStatement(q *influxql.ExplainSt
 	ctx = query.NewContextWithIterators(ctx, &aux)
 	start := time.Now()
 
-	cur, err := e.createIterators(ctx, stmt, ectx.ExecutionOptions)
+	cur, err := e.createIterators(ctx, stmt, ectx.ExecutionOptions, ectx.Join)
 	if err != nil {
 		return nil, err
 	}
@@ -537,7 +555,7 @@ func (e *StatementExecutor) executeSetPasswordUserStatement(q *influxql.SetPassw
 }
 
 func (e *StatementExecutor) executeSelectStatement(stmt *influxql.SelectStatement, ctx *query.ExecutionContext) error {
-	cur, err := e.createIterators(ctx, stmt, ctx.ExecutionOptions)
+	cur, err := e.createIterators(ctx, stmt, ctx.ExecutionOptions, ctx.Join)
 	if err != nil {
 		return err
 	}
@@ -622,13 +640,14 @@ func (e *StatementExecutor) executeSelectStatement(stmt *influxql.SelectStatemen
 	return nil
 }
 
-func (e *StatementExecutor) createIterators(ctx context.Context, stmt *influxql.SelectStatement, opt query.ExecutionOptions) (query.Cursor, error) {
+func (e *StatementExecutor) createIterators(ctx context.Context, stmt *influxql.SelectStatement, opt query.ExecutionOptions, join query.JoinType) (query.Cursor, error) {
 	sopt := query.SelectOptions{
 		NodeID:      opt.NodeID,
 		MaxSeriesN:  e.MaxSelectSeriesN,
 		MaxPointN:   e.MaxSelectPointN,
 		MaxBucketsN: e.MaxSelectBucketsN,
 		Authorizer:  opt.Authorizer,
+		Join:        join,
 	}
 
 	// Create a set of iterators from a selection.
@@ -790,7 +809,7 @@ func (e *StatementExecutor) executeShowShardsStatement(stmt *influxql.ShowShards
 
 	rows := []*models.Row{}
 	for _, di := range dis {
-		row := &models.Row{Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners"}, Name: di.Name}
+		row := &models.Row{Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners", "hot_bytes", "cold_bytes"}, Name: di.Name}
 		for _, rpi := range di.RetentionPolicies {
 			for _, sgi := range rpi.ShardGroups {
 				// Shards associated with deleted shard groups are effectively deleted.
@@ -805,6 +824,18 @@ func (e *StatementExecutor) executeShowShardsStatement(stmt *influxql.ShowShards
 						ownerIDs[i] = owner.NodeID
 					}
 
+					// Report the size of the shard in the data directory or
+					// the cold directory if it is stored locally.
+					var hotBytes, coldBytes int64
+					if sh := e.TSDBStore.Shard(si.ID); sh != nil {
+						size, _ := sh.DiskSize()
+						if sh.IsCold() {
+							coldBytes = size
+						} else {
+							hotBytes = size
+						}
+					}
+
 					row.Values = append(row.Values, []interface{}{
 						si.ID,
 						di.Name,
@@ -814,6 +845,8 @@ func (e *StatementExecutor) executeShowShardsStatement(stmt *influxql.ShowShards
 						sgi.EndTime.UTC().Format(time.RFC3339),
 						sgi.EndTime.Add(rpi.Duration).UTC().Format(time.RFC3339),
 						joinUint64(ownerIDs),
+						hotBytes,
+						coldBytes,
 					})
 				}
 			}
@@ -839,6 +872,18 @@ func (e *StatementExecutor) executeShowSeriesCardinalityStatement(stmt *influxql
 	}}, nil
 }
 
+func (e *StatementExecutor) executeShowSeriesLimitsStatement(stmt *query.ShowSeriesLimitsStatement) (models.Rows, error) {
+	if stmt.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	row := &models.Row{Columns: []string{"measurement", "limit", "series", "dropped"}}
+	for _, l := range e.TSDBStore.SeriesLimits(stmt.Database) {
+		row.Values = append(row.Values, []interface{}{l.Measurement, l.Limit, l.SeriesN, l.Dropped})
+	}
+	return []*models.Row{row}, nil
+}
+
 func (e *StatementExecutor) executeShowShardGroupsStatement(stmt *influxql.ShowShardGroupsStatement) (models.Rows, error) {
 	dis := e.MetaClient.Databases()
 
@@ -1276,6 +1321,10 @@ func (e *StatementExecutor) NormalizeStatement(stmt influxql.Statement, defaultD
 			if node.Database == "" {
 				node.Database = defaultDatabase
 			}
+		case *query.ShowSeriesLimitsStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
 		case *influxql.Measurement:
 			switch stmt.(type) {
 			case *influxql.DropSeriesStatement, *influxql.DeleteSeriesStatement:
@@ -1349,6 +1398,9 @@ type TSDBStore interface {
 
 	SeriesCardinality(database string) (int64, error)
 	MeasurementsCardinality(database string) (int64, error)
+	SeriesLimits(database string) []tsdb.SeriesLimitInfo
+
+	Shard(id uint64) *tsdb.Shard
 }
 
 var _ TSDBStore = LocalTSDBStore{}
@@ -258,6 +258,35 @@ func TestQueryExecutor_ExecuteQuery_ShowDatabases(t *testing.T) {
 	}
 }
 
+// Ensure query executor can list the series limits of the default database.
+func TestQueryExecutor_ExecuteQuery_ShowSeriesLimits(t *testing.T) {
+	e := DefaultQueryExecutor()
+	e.TSDBStore.SeriesLimitsFn = func(database string) []tsdb.SeriesLimitInfo {
+		if database != "db0" {
+			t.Fatalf("unexpected database: %s", database)
+		}
+		return []tsdb.SeriesLimitInfo{
+			{Measurement: "*", Limit: 10, SeriesN: 4, Dropped: 1},
+			{Measurement: "cpu", Limit: 5, SeriesN: 3},
+		}
+	}
+
+	if a := ReadAllResults(e.ExecuteQuery(`SHOW SERIES LIMITS`, "db0", 0)); !reflect.DeepEqual(a, []*query.Result{
+		{
+			StatementID: 0,
+			Series: []*models.Row{{
+				Columns: []string{"measurement", "limit", "series", "dropped"},
+				Values: [][]interface{}{
+					{"*", 10, int64(4), int64(1)},
+					{"cpu", 5, int64(3), int64(0)},
+				},
+			}},
+		},
+	}) {
+		t.Fatalf("unexpected results: %s", spew.Sdump(a))
+	}
+}
+
 // QueryExecutor is a test wrapper for coordinator.QueryExecutor.
 type QueryExecutor struct {
 	*query.Executor
@@ -15,6 +15,7 @@ import (
 type TSDBStoreMock struct {
 	BackupShardFn             func(id uint64, since time.Time, w io.Writer) error
 	BackupSeriesFileFn        func(database string, w io.Writer) error
+	BulkLoadShardFn           func(shardID uint64, points []models.Point) error
 	ExportShardFn             func(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error
 	CloseFn                   func() error
 	CreateShardFn             func(database, policy string, shardID uint64, enabled bool) error
@@ -28,13 +29,16 @@ type TSDBStoreMock struct {
 	DiskSizeFn                func() (int64, error)
 	ExpandSourcesFn           func(sources influxql.Sources) (influxql.Sources, error)
 	ImportShardFn             func(id uint64, r io.Reader) error
+	IngestTSMFilesFn          func(shardID uint64, paths []string, minTime, maxTime int64) error
 	MeasurementSeriesCountsFn func(database string) (measuments int, series int)
 	MeasurementsCardinalityFn func(database string) (int64, error)
 	MeasurementNamesFn        func(auth query.Authorizer, database string, cond influxql.Expr) ([][]byte, error)
 	OpenFn                    func() error
 	PathFn                    func() string
+	RebuildIndexFn            func(shardID uint64, progress func(seriesN int) error) error
 	RestoreShardFn            func(id uint64, r io.Reader) error
 	SeriesCardinalityFn       func(database string) (int64, error)
+	SeriesLimitsFn            func(database string) []tsdb.SeriesLimitInfo
 	SetShardEnabledFn         func(shardID uint64, enabled bool) error
 	ShardFn                   func(id uint64) *tsdb.Shard
 	ShardGroupFn              func(ids []uint64) tsdb.ShardGroup
@@ -45,6 +49,7 @@ type TSDBStoreMock struct {
 	StatisticsFn              func(tags map[string]string) []models.Statistic
 	TagKeysFn                 func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagKeys, error)
 	TagValuesFn               func(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error)
+	TierShardGroupFn          func(shardIDs []uint64, endTime time.Time, cancel <-chan struct{}) ([]uint64, error)
 	WithLoggerFn              func(log *zap.Logger)
 	WriteToShardFn            func(shardID uint64, points []models.Point) error
 }
@@ -55,6 +60,9 @@ func (s *TSDBStoreMock) BackupShard(id uint64, since time.Time, w io.Writer) err
 func (s *TSDBStoreMock) BackupSeriesFile(database string, w io.Writer) error {
 	return s.BackupSeriesFileFn(database, w)
 }
+func (s *TSDBStoreMock) BulkLoadShard(shardID uint64, points []models.Point) error {
+	return s.BulkLoadShardFn(shardID, points)
+}
 func (s *TSDBStoreMock) ExportShard(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error {
 	return s.ExportShardFn(id, ExportStart, ExportEnd, w)
 }
@@ -92,6 +100,9 @@ func (s *TSDBStoreMock) ExpandSources(sources influxql.Sources) (influxql.Source
 func (s *TSDBStoreMock) ImportShard(id uint64, r io.Reader) error {
 	return s.ImportShardFn(id, r)
 }
+func (s *TSDBStoreMock) IngestTSMFiles(shardID uint64, paths []string, minTime, maxTime int64) error {
+	return s.IngestTSMFilesFn(shardID, paths, minTime, maxTime)
+}
 func (s *TSDBStoreMock) MeasurementNames(auth query.Authorizer, database string, cond influxql.Expr) ([][]byte, error) {
 	return s.MeasurementNamesFn(auth, database, cond)
 }
@@ -107,12 +118,18 @@ func (s *TSDBStoreMock) Open() error {
 func (s *TSDBStoreMock) Path() string {
 	return s.PathFn()
 }
+func (s *TSDBStoreMock) RebuildIndex(shardID uint64, progress func(seriesN int) error) error {
+	return s.RebuildIndexFn(shardID, progress)
+}
 func (s *TSDBStoreMock) RestoreShard(id uint64, r io.Reader) error {
 	return s.RestoreShardFn(id, r)
 }
 func (s *TSDBStoreMock) SeriesCardinality(database string) (int64, error) {
 	return s.SeriesCardinalityFn(database)
 }
+func (s *TSDBStoreMock) SeriesLimits(database string) []tsdb.SeriesLimitInfo {
+	return s.SeriesLimitsFn(database)
+}
 func (s *TSDBStoreMock) SetShardEnabled(shardID uint64, enabled bool) error {
 	return s.SetShardEnabledFn(shardID, enabled)
 }
@@ -143,6 +160,9 @@ func (s *TSDBStoreMock) TagKeys(auth query.Authorizer, shardIDs []uint64, cond i
 func (s *TSDBStoreMock) TagValues(auth query.Authorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error) {
 	return s.TagValuesFn(auth, shardIDs, cond)
 }
+func (s *TSDBStoreMock) TierShardGroup(shardIDs []uint64, endTime time.Time, cancel <-chan struct{}) ([]uint64, error) {
+	return s.TierShardGroupFn(shardIDs, endTime, cancel)
+}
 func (s *TSDBStoreMock) WithLogger(log *zap.Logger) {
 	s.WithLoggerFn(log)
 }
@@ -0,0 +1,194 @@
+// Package tdigest implements the merging t-digest described by Ted Dunning and
+// Otmar Ertl in "Computing Extremely Accurate Quantiles Using t-Digests".
+//
+// A t-digest summarises a distribution as a sorted list of weighted centroids.
+// Centroids near the tails of the distribution are kept small, so quantiles
+// close to 0 or 1 remain accurate. As a digest is fully described by its
+// centroids, digests are merged by adding the centroids of one digest to
+// another.
+package tdigest
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultCompression is the compression used by New. Larger values retain more
+// centroids, trading memory for accuracy.
+const DefaultCompression = 100
+
+// Centroid is the weighted mean of a cluster of values.
+type Centroid struct {
+	Mean   float64
+	Weight float64
+}
+
+type centroids []Centroid
+
+func (a centroids) Len() int           { return len(a) }
+func (a centroids) Less(i, j int) bool { return a[i].Mean < a[j].Mean }
+func (a centroids) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// TDigest estimates the quantiles of a distribution.
+type TDigest struct {
+	compression float64
+
+	processed         centroids
+	processedWeight   float64
+	unprocessed       centroids
+	unprocessedWeight float64
+	maxProcessed      int
+	maxUnprocessed    int
+
+	min, max float64
+}
+
+// New returns a new TDigest using the default compression.
+func New() *TDigest {
+	return NewWithCompression(DefaultCompression)
+}
+
+// NewWithCompression returns a new TDigest using the specified compression.
+func NewWithCompression(compression float64) *TDigest {
+	n := int(math.Ceil(compression))
+	return &TDigest{
+		compression:    compression,
+		maxProcessed:   2 * n,
+		maxUnprocessed: 8 * n,
+		min:            math.Inf(1),
+		max:            math.Inf(-1),
+	}
+}
+
+// Add adds the value v with the weight w to the digest. NaN values and
+// non-positive weights are ignored.
+func (t *TDigest) Add(v, w float64) {
+	if math.IsNaN(v) || !(w > 0) {
+		return
+	}
+
+	t.unprocessed = append(t.unprocessed, Centroid{Mean: v, Weight: w})
+	t.unprocessedWeight += w
+	if v < t.min {
+		t.min = v
+	}
+	if v > t.max {
+		t.max = v
+	}
+
+	if len(t.unprocessed) > t.maxUnprocessed {
+		t.process()
+	}
+}
+
+// Merge adds the centroids of other to the digest.
+func (t *TDigest) Merge(other *TDigest) {
+	for _, c := range other.Centroids() {
+		t.Add(c.Mean, c.Weight)
+	}
+	if other.min < t.min {
+		t.min = other.min
+	}
+	if other.max > t.max {
+		t.max = other.max
+	}
+}
+
+// Centroids returns the centroids of the digest, sorted by mean.
+func (t *TDigest) Centroids() []Centroid {
+	t.process()
+	a := make([]Centroid, len(t.processed))
+	copy(a, t.processed)
+	return a
+}
+
+// Count returns the total weight of the values added to the digest.
+func (t *TDigest) Count() float64 {
+	return t.processedWeight + t.unprocessedWeight
+}
+
+// Quantile returns an estimate of the value at the quantile q, which must be
+// within the range [0, 1]. NaN is returned if q is out of range or the digest
+// is empty.
+func (t *TDigest) Quantile(q float64) float64 {
+	t.process()
+	if !(q >= 0 && q <= 1) || len(t.processed) == 0 {
+		return math.NaN()
+	} else if len(t.processed) == 1 {
+		return t.processed[0].Mean
+	}
+
+	// Each centroid is assumed to be centred on its mean, with half of its
+	// weight either side. Values between the means of adjacent centroids and
+	// between the outer centroids and the extremes are interpolated linearly.
+	index := q * t.processedWeight
+
+	first := t.processed[0]
+	if index < first.Weight/2 {
+		return t.min + (first.Mean-t.min)*index/(first.Weight/2)
+	}
+
+	var cum float64
+	for i := 1; i < len(t.processed); i++ {
+		prev, curr := t.processed[i-1], t.processed[i]
+		lo := cum + prev.Weight/2
+		hi := cum + prev.Weight + curr.Weight/2
+		if index < hi {
+			return prev.Mean + (curr.Mean-prev.Mean)*(index-lo)/(hi-lo)
+		}
+		cum += prev.Weight
+	}
+
+	last := t.processed[len(t.processed)-1]
+	lo := t.processedWeight - last.Weight/2
+	if index >= t.processedWeight {
+		return t.max
+	}
+	return last.Mean + (t.max-last.Mean)*(index-lo)/(last.Weight/2)
+}
+
+// process merges the unprocessed centroids into the processed centroids,
+// combining adjacent centroids while the combined centroid remains within the
+// size bound for its position in the distribution.
+func (t *TDigest) process() {
+	if len(t.unprocessed) == 0 && len(t.processed) <= t.maxProcessed {
+		return
+	}
+
+	all := append(t.unprocessed, t.processed...)
+	sort.Sort(all)
+
+	t.processedWeight += t.unprocessedWeight
+	t.unprocessedWeight = 0
+
+	processed := make(centroids, 0, t.maxProcessed)
+	processed = append(processed, all[0])
+
+	soFar := all[0].Weight
+	limit := t.processedWeight * t.integratedQ(1)
+	for _, c := range all[1:] {
+		if soFar+c.Weight <= limit {
+			last := &processed[len(processed)-1]
+			last.Weight += c.Weight
+			last.Mean += (c.Mean - last.Mean) * c.Weight / last.Weight
+		} else {
+			k := t.integratedLocation(soFar / t.processedWeight)
+			limit = t.processedWeight * t.integratedQ(k+1)
+			processed = append(processed, c)
+		}
+		soFar += c.Weight
+	}
+
+	t.processed = processed
+	t.unprocessed = all[:0]
+}
+
+// integratedLocation maps the quantile q to the scale k1(q) = δ/π * (asin(2q-1) + π/2).
+func (t *TDigest) integratedLocation(q float64) float64 {
+	return t.compression * (math.Asin(2*q-1) + math.Pi/2) / math.Pi
+}
+
+// integratedQ is the inverse of integratedLocation.
+func (t *TDigest) integratedQ(k float64) float64 {
+	return (math.Sin(math.Min(k, t.compression)*math.Pi/t.compression-math.Pi/2) + 1) / 2
+}
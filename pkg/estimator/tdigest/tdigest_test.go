@@ -0,0 +1,132 @@
+package tdigest_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/influxdata/influxdb/pkg/estimator/tdigest"
+)
+
+func TestTDigest_Quantile_Empty(t *testing.T) {
+	td := tdigest.New()
+	if v := td.Quantile(0.5); !math.IsNaN(v) {
+		t.Fatalf("unexpected quantile: %v", v)
+	}
+}
+
+func TestTDigest_Quantile_Single(t *testing.T) {
+	td := tdigest.New()
+	td.Add(5, 1)
+	for _, q := range []float64{0, 0.5, 1} {
+		if v := td.Quantile(q); v != 5 {
+			t.Fatalf("unexpected quantile(%v): %v", q, v)
+		}
+	}
+}
+
+func TestTDigest_Quantile_InvalidQuantile(t *testing.T) {
+	td := tdigest.New()
+	td.Add(5, 1)
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		if v := td.Quantile(q); !math.IsNaN(v) {
+			t.Fatalf("unexpected quantile(%v): %v", q, v)
+		}
+	}
+}
+
+func TestTDigest_Quantile(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	values := make([]float64, 100000)
+	td := tdigest.New()
+	for i := range values {
+		values[i] = rnd.NormFloat64()*10 + 100
+		td.Add(values[i], 1)
+	}
+	sort.Float64s(values)
+
+	if got, exp := td.Count(), float64(len(values)); got != exp {
+		t.Fatalf("unexpected count: got=%v, exp=%v", got, exp)
+	}
+
+	if got, exp := td.Quantile(0), values[0]; got != exp {
+		t.Fatalf("unexpected minimum: got=%v, exp=%v", got, exp)
+	}
+	if got, exp := td.Quantile(1), values[len(values)-1]; got != exp {
+		t.Fatalf("unexpected maximum: got=%v, exp=%v", got, exp)
+	}
+
+	assertQuantiles(t, td, values)
+}
+
+func TestTDigest_Merge(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	values := make([]float64, 0, 100000)
+
+	// Each digest sees a different part of the distribution.
+	merged := tdigest.New()
+	for i := 0; i < 10; i++ {
+		td := tdigest.New()
+		for j := 0; j < 10000; j++ {
+			v := rnd.ExpFloat64() * float64(i+1)
+			values = append(values, v)
+			td.Add(v, 1)
+		}
+		merged.Merge(td)
+	}
+	sort.Float64s(values)
+
+	if got, exp := merged.Count(), float64(len(values)); got != exp {
+		t.Fatalf("unexpected count: got=%v, exp=%v", got, exp)
+	}
+	assertQuantiles(t, merged, values)
+}
+
+func TestTDigest_Centroids(t *testing.T) {
+	td := tdigest.New()
+	for i := 0; i < 10000; i++ {
+		td.Add(float64(i), 1)
+	}
+
+	// A digest rebuilt from the weighted centroids must produce the same estimates.
+	cs := td.Centroids()
+	if len(cs) == 0 || len(cs) > 2*tdigest.DefaultCompression {
+		t.Fatalf("unexpected number of centroids: %d", len(cs))
+	}
+
+	other := tdigest.New()
+	var weight float64
+	for i, c := range cs {
+		if i > 0 && c.Mean < cs[i-1].Mean {
+			t.Fatalf("centroids not sorted at %d", i)
+		}
+		weight += c.Weight
+		other.Add(c.Mean, c.Weight)
+	}
+	if weight != 10000 {
+		t.Fatalf("unexpected total weight: %v", weight)
+	}
+
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		if got, exp := other.Quantile(q), td.Quantile(q); math.Abs(got-exp) > 10 {
+			t.Errorf("unexpected quantile(%v): got=%v, exp=%v", q, got, exp)
+		}
+	}
+}
+
+// assertQuantiles verifies the rank of each estimated quantile of td is within
+// an acceptable error of the requested quantile of the sorted values.
+func assertQuantiles(t *testing.T, td *tdigest.TDigest, values []float64) {
+	t.Helper()
+	for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+		v := td.Quantile(q)
+		rank := float64(sort.SearchFloat64s(values, v)) / float64(len(values))
+
+		// The error of a t-digest is proportional to q(1-q).
+		maxErr := 0.0005 + 0.05*q*(1-q)
+		if math.Abs(rank-q) > maxErr {
+			t.Errorf("unexpected quantile(%v): value=%v rank=%v", q, v, rank)
+		}
+	}
+}
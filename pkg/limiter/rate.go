@@ -0,0 +1,64 @@
+package limiter
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// AdjustableRate is a Rate whose limit can be changed while it is in use.
+type AdjustableRate struct {
+	limiter *rate.Limiter
+	burst   int
+	limit   int64 // bytes per second, 0 when unlimited
+}
+
+// NewAdjustableRate returns a Rate that limits the rate to bytesPerSec with a
+// maximum burst of burstLimit.  A bytesPerSec of 0 disables the limit.
+func NewAdjustableRate(bytesPerSec, burstLimit int) *AdjustableRate {
+	r := &AdjustableRate{
+		limiter: rate.NewLimiter(rateLimit(bytesPerSec), burstLimit),
+		burst:   burstLimit,
+		limit:   int64(bytesPerSec),
+	}
+	r.limiter.AllowN(time.Now(), burstLimit) // spend initial burst
+	return r
+}
+
+// WaitN blocks until n bytes are allowed.  Unlike a rate.Limiter, n may be
+// larger than the burst limit.
+func (r *AdjustableRate) WaitN(ctx context.Context, n int) error {
+	for n > 0 {
+		m := n
+		if r.burst > 0 && m > r.burst {
+			m = r.burst
+		}
+		if err := r.limiter.WaitN(ctx, m); err != nil {
+			return err
+		}
+		n -= m
+	}
+	return nil
+}
+
+// SetLimit changes the limit to bytesPerSec.  A bytesPerSec of 0 disables the
+// limit.
+func (r *AdjustableRate) SetLimit(bytesPerSec int) {
+	atomic.StoreInt64(&r.limit, int64(bytesPerSec))
+	r.limiter.SetLimit(rateLimit(bytesPerSec))
+}
+
+// Limit returns the current limit in bytes per second, or 0 if the rate is
+// not limited.
+func (r *AdjustableRate) Limit() int {
+	return int(atomic.LoadInt64(&r.limit))
+}
+
+func rateLimit(bytesPerSec int) rate.Limit {
+	if bytesPerSec <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(bytesPerSec)
+}
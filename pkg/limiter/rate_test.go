@@ -0,0 +1,43 @@
+package limiter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/pkg/limiter"
+)
+
+func TestAdjustableRate_WaitN_LargerThanBurst(t *testing.T) {
+	limit := 512 * 1024
+	r := limiter.NewAdjustableRate(limit, 64*1024)
+
+	start := time.Now()
+	if err := r.WaitN(context.Background(), 256*1024); err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(start)
+
+	if exp := 400 * time.Millisecond; elapsed < exp {
+		t.Errorf("elapsed mismatch: exp at least %s, got %s", exp, elapsed)
+	}
+}
+
+func TestAdjustableRate_SetLimit(t *testing.T) {
+	r := limiter.NewAdjustableRate(1024, 1024)
+	if got, exp := r.Limit(), 1024; got != exp {
+		t.Fatalf("limit mismatch: exp %d, got %d", exp, got)
+	}
+
+	// Removing the limit must not wait for the slow rate.
+	r.SetLimit(0)
+	if got, exp := r.Limit(), 0; got != exp {
+		t.Fatalf("limit mismatch: exp %d, got %d", exp, got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.WaitN(ctx, 1024*1024); err != nil {
+		t.Fatal(err)
+	}
+}
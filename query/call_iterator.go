@@ -52,6 +52,10 @@ func NewCallIterator(input Iterator, opt IteratorOptions) (Iterator, error) {
 		return newLastIterator(input, opt)
 	case "mean":
 		return newMeanIterator(input, opt)
+	case "quantile":
+		return newQuantileIterator(input, opt)
+	case "histogram":
+		return newHistogramIterator(input, opt)
 	default:
 		return nil, fmt.Errorf("unsupported function call: %s", name)
 	}
@@ -1131,6 +1135,232 @@ func NewUnsignedPercentileReduceSliceFunc(percentile float64) UnsignedReduceSlic
 	}
 }
 
+// newRateIterator returns an iterator for operating on a rate(), irate() or
+// increase() call. The points of each window are reduced by the RateReducer
+// returned by newReducer.
+func newRateIterator(input Iterator, opt IteratorOptions, newReducer func() *RateReducer) (Iterator, error) {
+	switch input := input.(type) {
+	case FloatIterator:
+		createFn := func() (FloatPointAggregator, FloatPointEmitter) {
+			fn := newReducer()
+			return fn, fn
+		}
+		return newFloatReduceFloatIterator(input, opt, createFn), nil
+	case IntegerIterator:
+		createFn := func() (IntegerPointAggregator, FloatPointEmitter) {
+			fn := newReducer()
+			return fn, fn
+		}
+		return newIntegerReduceFloatIterator(input, opt, createFn), nil
+	case UnsignedIterator:
+		createFn := func() (UnsignedPointAggregator, FloatPointEmitter) {
+			fn := newReducer()
+			return fn, fn
+		}
+		return newUnsignedReduceFloatIterator(input, opt, createFn), nil
+	default:
+		name := opt.Expr.(*influxql.Call).Name
+		return nil, fmt.Errorf("unsupported %s iterator type: %T", name, input)
+	}
+}
+
+// newQuantileIterator returns an iterator for operating on a quantile() call.
+// Rather than the quantile, it emits the t-digest of each window as weighted
+// centroids so the digests of each series and shard can be merged. The merged
+// digest is reduced to the quantile by newQuantileEstimateIterator.
+func newQuantileIterator(input Iterator, opt IteratorOptions) (Iterator, error) {
+	switch input := input.(type) {
+	case FloatIterator:
+		createFn := func() (FloatPointAggregator, FloatPointEmitter) {
+			fn := NewTDigestReducer()
+			return fn, fn
+		}
+		return newFloatReduceFloatIterator(input, opt, createFn), nil
+	case IntegerIterator:
+		createFn := func() (IntegerPointAggregator, FloatPointEmitter) {
+			fn := NewTDigestReducer()
+			return fn, fn
+		}
+		return newIntegerReduceFloatIterator(input, opt, createFn), nil
+	case UnsignedIterator:
+		createFn := func() (UnsignedPointAggregator, FloatPointEmitter) {
+			fn := NewTDigestReducer()
+			return fn, fn
+		}
+		return newUnsignedReduceFloatIterator(input, opt, createFn), nil
+	default:
+		return nil, fmt.Errorf("unsupported quantile iterator type: %T", input)
+	}
+}
+
+// newQuantileEstimateIterator returns an iterator that estimates the quantile
+// of a quantile() call from the centroids emitted by newQuantileIterator.
+func newQuantileEstimateIterator(input Iterator, opt IteratorOptions) (Iterator, error) {
+	q := numberLiteral(opt.Expr.(*influxql.Call).Args[1])
+	switch input := input.(type) {
+	case FloatIterator:
+		createFn := func() (FloatPointAggregator, FloatPointEmitter) {
+			fn := NewQuantileReducer(q)
+			return fn, fn
+		}
+		return newFloatReduceFloatIterator(input, opt, createFn), nil
+	default:
+		return nil, fmt.Errorf("unsupported quantile iterator type: %T", input)
+	}
+}
+
+// newHistogramIterator returns an iterator for operating on a histogram() call.
+// It emits a weighted point for each non-empty bucket of each window so the
+// histograms of each series and shard can be merged. The merged histogram is
+// reduced to the count of each bucket by newHistogramCountIterator.
+func newHistogramIterator(input Iterator, opt IteratorOptions) (Iterator, error) {
+	b := newHistogramBuckets(opt.Expr.(*influxql.Call))
+	switch input := input.(type) {
+	case FloatIterator:
+		createFn := func() (FloatPointAggregator, FloatPointEmitter) {
+			fn := NewHistogramReducer(b.min, b.max, b.n)
+			return fn, fn
+		}
+		return newFloatReduceFloatIterator(input, opt, createFn), nil
+	case IntegerIterator:
+		createFn := func() (IntegerPointAggregator, FloatPointEmitter) {
+			fn := NewHistogramReducer(b.min, b.max, b.n)
+			return fn, fn
+		}
+		return newIntegerReduceFloatIterator(input, opt, createFn), nil
+	case UnsignedIterator:
+		createFn := func() (UnsignedPointAggregator, FloatPointEmitter) {
+			fn := NewHistogramReducer(b.min, b.max, b.n)
+			return fn, fn
+		}
+		return newUnsignedReduceFloatIterator(input, opt, createFn), nil
+	default:
+		return nil, fmt.Errorf("unsupported histogram iterator type: %T", input)
+	}
+}
+
+// newHistogramCountIterator returns an iterator that emits the count of each
+// bucket of a histogram() call from the points emitted by newHistogramIterator.
+// The counts are tagged with their bucket by a histogramBucketIterator.
+func newHistogramCountIterator(input Iterator, opt IteratorOptions) (Iterator, error) {
+	b := newHistogramBuckets(opt.Expr.(*influxql.Call))
+	switch input := input.(type) {
+	case FloatIterator:
+		createFn := func() (FloatPointAggregator, IntegerPointEmitter) {
+			fn := NewHistogramCountReducer(b.min, b.max, b.n)
+			return fn, fn
+		}
+		return newHistogramBucketIterator(newFloatReduceIntegerIterator(input, opt, createFn), b), nil
+	default:
+		return nil, fmt.Errorf("unsupported histogram iterator type: %T", input)
+	}
+}
+
+// newHistogramBuckets returns the buckets of a histogram(field, min, max, n) call.
+func newHistogramBuckets(call *influxql.Call) histogramBuckets {
+	return histogramBuckets{
+		min: numberLiteral(call.Args[1]),
+		max: numberLiteral(call.Args[2]),
+		n:   int(call.Args[3].(*influxql.IntegerLiteral).Val),
+	}
+}
+
+// histogramBucketTag is the tag holding the lower bound of the bucket of the
+// counts emitted by histogram().
+const histogramBucketTag = "bucket"
+
+// histogramBucketIterator tags the counts of a histogram() call with the lower
+// bound of their bucket. The input emits the counts of all buckets for each
+// window, so the counts of a series are buffered and emitted bucket by bucket,
+// making each bucket a series of its own.
+type histogramBucketIterator struct {
+	input   IntegerIterator
+	buckets histogramBuckets
+	buf     *IntegerPoint  // next point of the input, read ahead
+	points  []IntegerPoint // tagged counts of the current series
+}
+
+func newHistogramBucketIterator(input IntegerIterator, b histogramBuckets) *histogramBucketIterator {
+	return &histogramBucketIterator{input: input, buckets: b}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *histogramBucketIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *histogramBucketIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next count, reading the counts of the next series if the
+// counts of the current series have all been emitted.
+func (itr *histogramBucketIterator) Next() (*IntegerPoint, error) {
+	if len(itr.points) == 0 {
+		if err := itr.read(); err != nil || len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	p := &itr.points[0]
+	itr.points = itr.points[1:]
+	return p, nil
+}
+
+// read reads the counts of the next series and orders them by bucket.
+func (itr *histogramBucketIterator) read() error {
+	buckets := make([][]IntegerPoint, itr.buckets.n)
+	for i := 0; ; i++ {
+		p := itr.buf
+		itr.buf = nil
+		if p == nil {
+			var err error
+			if p, err = itr.input.Next(); err != nil {
+				return err
+			} else if p == nil {
+				break
+			}
+		}
+
+		if i > 0 && (p.Name != buckets[0][0].Name || p.Tags.ID() != buckets[0][0].Tags.ID()) {
+			itr.buf = p
+			break
+		}
+
+		// Each window emits the counts of all of the buckets in order.
+		buckets[i%itr.buckets.n] = append(buckets[i%itr.buckets.n], *p)
+	}
+
+	itr.points = nil
+	for i, a := range buckets {
+		if len(a) == 0 {
+			continue
+		}
+
+		m := make(map[string]string, len(a[0].Tags.KeyValues())+1)
+		for k, v := range a[0].Tags.KeyValues() {
+			m[k] = v
+		}
+		m[histogramBucketTag] = itr.buckets.label(i)
+		tags := NewTags(m)
+
+		for _, p := range a {
+			p.Tags = tags
+			itr.points = append(itr.points, p)
+		}
+	}
+	return nil
+}
+
+// numberLiteral returns the value of an integer or number literal.
+func numberLiteral(expr influxql.Expr) float64 {
+	switch expr := expr.(type) {
+	case *influxql.NumberLiteral:
+		return expr.Val
+	case *influxql.IntegerLiteral:
+		return float64(expr.Val)
+	default:
+		panic(fmt.Sprintf("unexpected literal: %T", expr))
+	}
+}
+
 // newDerivativeIterator returns an iterator for operating on a derivative() call.
 func newDerivativeIterator(input Iterator, opt IteratorOptions, interval Interval, isNonNegative bool) (Iterator, error) {
 	switch input := input.(type) {
@@ -1361,3 +1591,29 @@ func newIntegralIterator(input Iterator, opt IteratorOptions, interval Interval)
 		return nil, fmt.Errorf("unsupported integral iterator type: %T", input)
 	}
 }
+
+// newTimeWeightedAverageIterator returns an iterator for operating on a time_weighted_avg() call.
+func newTimeWeightedAverageIterator(input Iterator, opt IteratorOptions, step bool) (Iterator, error) {
+	switch input := input.(type) {
+	case FloatIterator:
+		createFn := func() (FloatPointAggregator, FloatPointEmitter) {
+			fn := NewTimeWeightedAverageReducer(step, opt)
+			return fn, fn
+		}
+		return newFloatStreamFloatIterator(input, createFn, opt), nil
+	case IntegerIterator:
+		createFn := func() (IntegerPointAggregator, FloatPointEmitter) {
+			fn := NewTimeWeightedAverageReducer(step, opt)
+			return fn, fn
+		}
+		return newIntegerStreamFloatIterator(input, createFn, opt), nil
+	case UnsignedIterator:
+		createFn := func() (UnsignedPointAggregator, FloatPointEmitter) {
+			fn := NewTimeWeightedAverageReducer(step, opt)
+			return fn, fn
+		}
+		return newUnsignedStreamFloatIterator(input, createFn, opt), nil
+	default:
+		return nil, fmt.Errorf("unsupported time_weighted_avg iterator type: %T", input)
+	}
+}
@@ -13,6 +13,10 @@ import (
 // CompileOptions are the customization options for the compiler.
 type CompileOptions struct {
 	Now time.Time
+
+	// Join is the type of join performed by statements selecting qualified
+	// fields from more than one measurement.
+	Join JoinType
 }
 
 // Statement is a compiled query statement.
@@ -46,12 +50,18 @@ type compiledStatement struct {
 	// call that has been encountered.
 	FunctionCalls []*influxql.Call
 
+	// Join is set when the fields are read from more than one measurement.
+	Join *joinInfo
+
 	// OnlySelectors is set to true when there are no aggregate functions.
 	OnlySelectors bool
 
 	// HasDistinct is set when the distinct() function is encountered.
 	HasDistinct bool
 
+	// HasHistogram is set when the histogram() function is encountered.
+	HasHistogram bool
+
 	// FillOption contains the fill option for aggregates.
 	FillOption influxql.FillOption
 
@@ -160,6 +170,11 @@ func (c *compiledStatement) compile(stmt *influxql.SelectStatement) error {
 	if err := c.compileFields(stmt); err != nil {
 		return err
 	}
+	if c.Join = newJoinInfo(stmt, c.Options.Join); c.Join != nil {
+		if err := c.Join.validate(c, stmt); err != nil {
+			return err
+		}
+	}
 	if err := c.validateFields(); err != nil {
 		return err
 	}
@@ -252,6 +267,10 @@ func (c *compiledField) compileExpr(expr influxql.Expr) error {
 			return c.compilePercentile(expr.Args)
 		case "sample":
 			return c.compileSample(expr.Args)
+		case "quantile":
+			return c.compileQuantile(expr.Args)
+		case "histogram":
+			return c.compileHistogram(expr.Args)
 		case "distinct":
 			return c.compileDistinct(expr.Args, false)
 		case "top", "bottom":
@@ -262,6 +281,10 @@ func (c *compiledField) compileExpr(expr influxql.Expr) error {
 		case "difference", "non_negative_difference":
 			isNonNegative := expr.Name == "non_negative_difference"
 			return c.compileDifference(expr.Args, isNonNegative)
+		case "rate", "irate":
+			return c.compileRate(expr.Name, expr.Args)
+		case "increase":
+			return c.compileIncrease(expr.Args)
 		case "cumulative_sum":
 			return c.compileCumulativeSum(expr.Args)
 		case "moving_average":
@@ -270,6 +293,8 @@ func (c *compiledField) compileExpr(expr influxql.Expr) error {
 			return c.compileElapsed(expr.Args)
 		case "integral":
 			return c.compileIntegral(expr.Args)
+		case "time_weighted_avg":
+			return c.compileTimeWeightedAverage(expr.Args)
 		case "holt_winters", "holt_winters_with_fit":
 			withFit := expr.Name == "holt_winters_with_fit"
 			return c.compileHoltWinters(expr.Args, withFit)
@@ -375,6 +400,62 @@ func (c *compiledField) compilePercentile(args []influxql.Expr) error {
 	return c.compileSymbol("percentile", args[0])
 }
 
+func (c *compiledField) compileQuantile(args []influxql.Expr) error {
+	if exp, got := 2, len(args); got != exp {
+		return fmt.Errorf("invalid number of arguments for quantile, expected %d, got %d", exp, got)
+	}
+
+	var q float64
+	switch arg1 := args[1].(type) {
+	case *influxql.IntegerLiteral:
+		q = float64(arg1.Val)
+	case *influxql.NumberLiteral:
+		q = arg1.Val
+	default:
+		return fmt.Errorf("expected float argument in quantile()")
+	}
+
+	if q < 0 || q > 1 {
+		return fmt.Errorf("quantile must be between 0 and 1, got %v", q)
+	}
+	c.global.OnlySelectors = false
+	return c.compileSymbol("quantile", args[0])
+}
+
+func (c *compiledField) compileHistogram(args []influxql.Expr) error {
+	if exp, got := 4, len(args); got != exp {
+		return fmt.Errorf("invalid number of arguments for histogram, expected %d, got %d", exp, got)
+	}
+
+	var bounds [2]float64
+	for i, arg := range args[1:3] {
+		switch arg := arg.(type) {
+		case *influxql.IntegerLiteral:
+			bounds[i] = float64(arg.Val)
+		case *influxql.NumberLiteral:
+			bounds[i] = arg.Val
+		default:
+			return fmt.Errorf("expected float argument in histogram()")
+		}
+	}
+
+	if bounds[0] >= bounds[1] {
+		return fmt.Errorf("histogram minimum must be less than the maximum, got %v and %v", bounds[0], bounds[1])
+	}
+
+	switch arg3 := args[3].(type) {
+	case *influxql.IntegerLiteral:
+		if arg3.Val <= 0 {
+			return fmt.Errorf("histogram buckets must be greater than 0, got %d", arg3.Val)
+		}
+	default:
+		return fmt.Errorf("expected integer argument in histogram()")
+	}
+	c.global.HasHistogram = true
+	c.global.OnlySelectors = false
+	return c.compileSymbol("histogram", args[0])
+}
+
 func (c *compiledField) compileSample(args []influxql.Expr) error {
 	if exp, got := 2, len(args); got != exp {
 		return fmt.Errorf("invalid number of arguments for sample, expected %d, got %d", exp, got)
@@ -462,6 +543,34 @@ func (c *compiledField) compileElapsed(args []influxql.Expr) error {
 	}
 }
 
+func (c *compiledField) compileRate(name string, args []influxql.Expr) error {
+	if min, max, got := 1, 2, len(args); got > max || got < min {
+		return fmt.Errorf("invalid number of arguments for %s, expected at least %d but no more than %d, got %d", name, min, max, got)
+	}
+
+	// Retrieve the unit from the rate() call, if specified.
+	if len(args) == 2 {
+		switch arg1 := args[1].(type) {
+		case *influxql.DurationLiteral:
+			if arg1.Val <= 0 {
+				return fmt.Errorf("duration argument must be positive, got %s", influxql.FormatDuration(arg1.Val))
+			}
+		default:
+			return fmt.Errorf("second argument to %s must be a duration, got %T", name, args[1])
+		}
+	}
+	c.global.OnlySelectors = false
+	return c.compileSymbol(name, args[0])
+}
+
+func (c *compiledField) compileIncrease(args []influxql.Expr) error {
+	if exp, got := 1, len(args); exp != got {
+		return fmt.Errorf("invalid number of arguments for increase, expected %d, got %d", exp, got)
+	}
+	c.global.OnlySelectors = false
+	return c.compileSymbol("increase", args[0])
+}
+
 func (c *compiledField) compileDifference(args []influxql.Expr, isNonNegative bool) error {
 	name := "difference"
 	if isNonNegative {
@@ -560,6 +669,27 @@ func (c *compiledField) compileIntegral(args []influxql.Expr) error {
 	return c.compileSymbol("integral", args[0])
 }
 
+func (c *compiledField) compileTimeWeightedAverage(args []influxql.Expr) error {
+	if min, max, got := 1, 2, len(args); got > max || got < min {
+		return fmt.Errorf("invalid number of arguments for time_weighted_avg, expected at least %d but no more than %d, got %d", min, max, got)
+	}
+
+	if len(args) == 2 {
+		switch arg1 := args[1].(type) {
+		case *influxql.StringLiteral:
+			if arg1.Val != "linear" && arg1.Val != "step" {
+				return fmt.Errorf("time_weighted_avg method must be 'linear' or 'step', got '%s'", arg1.Val)
+			}
+		default:
+			return errors.New("second argument to time_weighted_avg must be a string")
+		}
+	}
+	c.global.OnlySelectors = false
+
+	// Must be a variable reference, wildcard, or regexp.
+	return c.compileSymbol("time_weighted_avg", args[0])
+}
+
 func (c *compiledField) compileHoltWinters(args []influxql.Expr, withFit bool) error {
 	name := "holt_winters"
 	if withFit {
@@ -729,6 +859,10 @@ func (c *compiledStatement) validateFields() error {
 	} else if len(c.FunctionCalls) == 0 {
 		switch c.FillOption {
 		case influxql.NoFill:
+			// A join uses fill(none) to request an inner join.
+			if c.Join != nil {
+				break
+			}
 			return errors.New("fill(none) must be used with a function")
 		case influxql.LinearFill:
 			return errors.New("fill(linear) must be used with a function")
@@ -741,6 +875,11 @@ func (c *compiledStatement) validateFields() error {
 	if c.HasDistinct && (len(c.FunctionCalls) != 1 || c.HasAuxiliaryFields) {
 		return errors.New("aggregate function distinct() cannot be combined with other functions or fields")
 	}
+	// The counts of a histogram() call are tagged with their bucket, so they
+	// cannot be joined with the values of other functions.
+	if c.HasHistogram && len(c.FunctionCalls) != 1 {
+		return errors.New("aggregate function histogram() cannot be combined with other functions")
+	}
 	// Validate we are using a selector or raw query if auxiliary fields are required.
 	if c.HasAuxiliaryFields {
 		if !c.OnlySelectors {
@@ -835,8 +974,8 @@ func (c *compiledStatement) Prepare(shardMapper ShardMapper, sopt SelectOptions)
 		return nil, err
 	}
 
-	// Rewrite wildcards, if any exist.
-	stmt, err := c.stmt.RewriteFields(shards)
+	// Resolve the types of any joined fields and rewrite wildcards, if any exist.
+	stmt, err := rewriteJoinFields(c.stmt, shards, c.Options.Join).RewriteFields(shards)
 	if err != nil {
 		shards.Close()
 		return nil, err
@@ -850,6 +989,7 @@ func (c *compiledStatement) Prepare(shardMapper ShardMapper, sopt SelectOptions)
 	}
 	opt.StartTime, opt.EndTime = c.TimeRange.MinTimeNano(), c.TimeRange.MaxTimeNano()
 	opt.Ascending = c.Ascending
+	opt.Join = c.Options.Join
 
 	if sopt.MaxBucketsN > 0 && !stmt.IsRawQuery && c.TimeRange.MinTimeNano() > influxql.MinTime {
 		interval, err := stmt.GroupByInterval()
@@ -65,6 +65,10 @@ func TestCompile_Success(t *testing.T) {
 		`SELECT max(bottom) FROM (SELECT bottom(value, host, 1) FROM cpu) GROUP BY region`,
 		`SELECT percentile(value, 75) FROM cpu`,
 		`SELECT percentile(value, 75.0) FROM cpu`,
+		`SELECT quantile(value, 0.99) FROM cpu`,
+		`SELECT quantile(value, 1) FROM cpu GROUP BY time(1m)`,
+		`SELECT histogram(value, 0, 100, 10) FROM cpu`,
+		`SELECT histogram(value, -1.5, 1.5, 3) FROM cpu GROUP BY time(1m)`,
 		`SELECT sample(value, 2) FROM cpu`,
 		`SELECT sample(*, 2) FROM cpu`,
 		`SELECT sample(/val/, 2) FROM cpu`,
@@ -72,6 +76,11 @@ func TestCompile_Success(t *testing.T) {
 		`SELECT elapsed(value, 10s) FROM cpu`,
 		`SELECT integral(value) FROM cpu`,
 		`SELECT integral(value, 10s) FROM cpu`,
+		`SELECT rate(value) FROM cpu WHERE time >= now() - 1h GROUP BY time(5m)`,
+		`SELECT irate(value, 1m) FROM cpu`,
+		`SELECT time_weighted_avg(value) FROM cpu WHERE time >= now() - 1h GROUP BY time(5m)`,
+		`SELECT time_weighted_avg(value, 'step') FROM cpu`,
+		`SELECT increase(value) FROM cpu WHERE time >= now() - 1h GROUP BY time(5m)`,
 		`SELECT max(value) FROM cpu WHERE time >= now() - 1m GROUP BY time(10s, 5s)`,
 		`SELECT max(value) FROM cpu WHERE time >= now() - 1m GROUP BY time(10s, '2000-01-01T00:00:05Z')`,
 		`SELECT max(value) FROM cpu WHERE time >= now() - 1m GROUP BY time(10s, now())`,
@@ -195,6 +204,16 @@ func TestCompile_Failures(t *testing.T) {
 		{s: `SELECT percentile(field1) FROM myseries`, err: `invalid number of arguments for percentile, expected 2, got 1`},
 		{s: `SELECT percentile(field1, foo) FROM myseries`, err: `expected float argument in percentile()`},
 		{s: `SELECT percentile(max(field1), 75) FROM myseries`, err: `expected field argument in percentile()`},
+		{s: `SELECT quantile(field1) FROM myseries`, err: `invalid number of arguments for quantile, expected 2, got 1`},
+		{s: `SELECT quantile(field1, foo) FROM myseries`, err: `expected float argument in quantile()`},
+		{s: `SELECT quantile(field1, 1.5) FROM myseries`, err: `quantile must be between 0 and 1, got 1.5`},
+		{s: `SELECT quantile(max(field1), 0.5) FROM myseries`, err: `expected field argument in quantile()`},
+		{s: `SELECT histogram(field1, 0, 10) FROM myseries`, err: `invalid number of arguments for histogram, expected 4, got 3`},
+		{s: `SELECT histogram(field1, foo, 10, 5) FROM myseries`, err: `expected float argument in histogram()`},
+		{s: `SELECT histogram(field1, 10, 10, 5) FROM myseries`, err: `histogram minimum must be less than the maximum, got 10 and 10`},
+		{s: `SELECT histogram(field1, 0, 10, 0) FROM myseries`, err: `histogram buckets must be greater than 0, got 0`},
+		{s: `SELECT histogram(field1, 0, 10, 2.5) FROM myseries`, err: `expected integer argument in histogram()`},
+		{s: `SELECT histogram(field1, 0, 10, 5), mean(field1) FROM myseries`, err: `aggregate function histogram() cannot be combined with other functions`},
 		{s: `SELECT field1 FROM foo group by time(1s)`, err: `GROUP BY requires at least one aggregate function`},
 		{s: `SELECT field1 FROM foo fill(none)`, err: `fill(none) must be used with a function`},
 		{s: `SELECT field1 FROM foo fill(linear)`, err: `fill(linear) must be used with a function`},
@@ -289,6 +308,14 @@ func TestCompile_Failures(t *testing.T) {
 		{s: `SELECT integral(value, 10s, host) FROM myseries`, err: `invalid number of arguments for integral, expected at least 1 but no more than 2, got 3`},
 		{s: `SELECT integral(value, -10s) FROM myseries`, err: `duration argument must be positive, got -10s`},
 		{s: `SELECT integral(value, 10) FROM myseries`, err: `second argument must be a duration`},
+		{s: `SELECT rate() FROM myseries`, err: `invalid number of arguments for rate, expected at least 1 but no more than 2, got 0`},
+		{s: `SELECT irate(value, 10s, host) FROM myseries`, err: `invalid number of arguments for irate, expected at least 1 but no more than 2, got 3`},
+		{s: `SELECT rate(value, -10s) FROM myseries`, err: `duration argument must be positive, got -10s`},
+		{s: `SELECT rate(value, 10) FROM myseries`, err: `second argument to rate must be a duration, got *influxql.IntegerLiteral`},
+		{s: `SELECT increase(value, 10s) FROM myseries`, err: `invalid number of arguments for increase, expected 1, got 2`},
+		{s: `SELECT time_weighted_avg() FROM myseries`, err: `invalid number of arguments for time_weighted_avg, expected at least 1 but no more than 2, got 0`},
+		{s: `SELECT time_weighted_avg(value, 'cubic') FROM myseries`, err: `time_weighted_avg method must be 'linear' or 'step', got 'cubic'`},
+		{s: `SELECT time_weighted_avg(value, 10s) FROM myseries`, err: `second argument to time_weighted_avg must be a string`},
 		{s: `SELECT holt_winters(value) FROM myseries where time < now() and time > now() - 1d`, err: `invalid number of arguments for holt_winters, expected 3, got 1`},
 		{s: `SELECT holt_winters(value, 10, 2) FROM myseries where time < now() and time > now() - 1d`, err: `must use aggregate function with holt_winters`},
 		{s: `SELECT holt_winters(min(value), 10, 2) FROM myseries where time < now() and time > now() - 1d`, err: `holt_winters aggregate requires a GROUP BY interval`},
@@ -335,3 +362,42 @@ func TestCompile_Failures(t *testing.T) {
 		})
 	}
 }
+
+func TestCompile_Join(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		join query.JoinType
+		err  string
+	}{
+		{s: `SELECT cpu.usage / mem.total FROM cpu, mem GROUP BY host`, join: query.OuterJoin},
+		{s: `SELECT cpu.usage, mem.total FROM cpu, mem WHERE host = 'server01'`, join: query.InnerJoin},
+		{s: `SELECT mean(ratio) FROM (SELECT cpu.usage / mem.total AS ratio FROM cpu, mem) WHERE time >= now() - 1h GROUP BY time(1m)`, join: query.OuterJoin},
+		{s: `SELECT cpu.usage, mem.total FROM cpu, mem GROUP BY time(1m)`, join: query.OuterJoin, err: `GROUP BY requires at least one aggregate function`},
+		{s: `SELECT mean(cpu.usage) FROM cpu, mem GROUP BY time(1m)`, join: query.OuterJoin, err: `aggregate functions are not supported in a join, use a subquery`},
+		{s: `SELECT cpu.usage, total FROM cpu, mem`, join: query.OuterJoin, err: `field must be qualified with a measurement in a join: total`},
+		{s: `SELECT cpu.usage, * FROM cpu, mem`, join: query.OuterJoin, err: `wildcards are not supported in a join`},
+		{s: `SELECT cpu.usage, mem.total FROM cpu, mem fill(none)`, join: query.InnerJoin, err: `fill is not supported in a join, use an inner join to only return times where every measurement has a point`},
+		{s: `SELECT cpu.usage, mem.total FROM cpu, mem WHERE cpu.usage > 10`, join: query.OuterJoin, err: `conditions on joined fields are not supported: cpu.usage`},
+
+		// Without a join, qualified names are field keys.
+		{s: `SELECT cpu.usage, total FROM cpu, mem`},
+		{s: `SELECT mean(cpu.usage) FROM cpu, mem GROUP BY time(1m) fill(0)`},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			stmt, err := influxql.ParseStatement(tt.s)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			s := stmt.(*influxql.SelectStatement)
+
+			opt := query.CompileOptions{Join: tt.join}
+			if _, err := query.Compile(s, opt); tt.err == "" && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			} else if tt.err != "" && err == nil {
+				t.Error("expected error")
+			} else if err != nil && err.Error() != tt.err {
+				t.Errorf("unexpected error: %s != %s", err.Error(), tt.err)
+			}
+		})
+	}
+}
@@ -153,7 +153,7 @@ func (cur *cursor) loadBuf() (t int64, name string, tags Tags, err error) {
 	for i := range cur.itrs {
 		// Load buffer, if empty.
 		if cur.buf[i] == nil {
-			cur.buf[i], err = cur.readIterator(cur.itrs[i])
+			cur.buf[i], err = readIterator(cur.itrs[i])
 			if err != nil {
 				break
 			}
@@ -211,7 +211,7 @@ func (cur *cursor) readInto(t int64, name string, tags Tags, values []interface{
 }
 
 // readIterator reads the next point from itr.
-func (cur *cursor) readIterator(itr Iterator) (Point, error) {
+func readIterator(itr Iterator) (Point, error) {
 	if itr == nil {
 		return nil, nil
 	}
@@ -12,6 +12,9 @@ type ExecutionContext struct {
 	// The statement ID of the executing query.
 	statementID int
 
+	// The type of join of the executing statement.
+	Join JoinType
+
 	// The query ID of the executing query.
 	QueryID uint64
 
@@ -74,6 +77,13 @@ func (ctx *ExecutionContext) Done() <-chan struct{} {
 	return ctx.done
 }
 
+// SetProgress sets the progress of the task, listed by SHOW QUERIES.
+func (ctx *ExecutionContext) SetProgress(progress string) {
+	if ctx.task != nil {
+		ctx.task.setProgress(progress)
+	}
+}
+
 func (ctx *ExecutionContext) Err() error {
 	ctx.mu.RLock()
 	defer ctx.mu.RUnlock()
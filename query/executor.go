@@ -135,6 +135,11 @@ type ExecutionOptions struct {
 	// Quiet suppresses non-essential output from the query executor.
 	Quiet bool
 
+	// Joins holds the type of join of each statement of the query, by
+	// position, for statements selecting qualified fields from more than one
+	// measurement.  Statements without an entry are not joined.
+	Joins []JoinType
+
 	// AbortCh is a channel that signals when results are no longer desired by the caller.
 	AbortCh <-chan struct{}
 }
@@ -186,6 +191,10 @@ type Executor struct {
 	// Defaults to discarding all log output.
 	Logger *zap.Logger
 
+	// ObserveQueryDuration, if set, is called with the wall time of every
+	// finished query.
+	ObserveQueryDuration func(d time.Duration)
+
 	// expvar-based stats.
 	stats *Statistics
 }
@@ -251,7 +260,11 @@ func (e *Executor) executeQuery(query *influxql.Query, opt ExecutionOptions, clo
 	defer func(start time.Time) {
 		atomic.AddInt64(&e.stats.ActiveQueries, -1)
 		atomic.AddInt64(&e.stats.FinishedQueries, 1)
-		atomic.AddInt64(&e.stats.QueryExecutionDuration, time.Since(start).Nanoseconds())
+		d := time.Since(start)
+		atomic.AddInt64(&e.stats.QueryExecutionDuration, d.Nanoseconds())
+		if e.ObserveQueryDuration != nil {
+			e.ObserveQueryDuration(d)
+		}
 	}(time.Now())
 
 	ctx, detach, err := e.TaskManager.AttachQuery(query, opt, closing)
@@ -271,6 +284,10 @@ func (e *Executor) executeQuery(query *influxql.Query, opt ExecutionOptions, clo
 LOOP:
 	for ; i < len(query.Statements); i++ {
 		ctx.statementID = i
+		ctx.Join = NoJoin
+		if i < len(opt.Joins) {
+			ctx.Join = opt.Joins[i]
+		}
 		stmt := query.Statements[i]
 
 		// If a default database wasn't passed in by the caller, check the statement.
@@ -415,6 +432,7 @@ func (e *Executor) recover(query *influxql.Query, results chan *Result) {
 // For the public use data structure that gets returned, see Task.
 type Task struct {
 	query     string
+	progress  string
 	database  string
 	status    TaskStatus
 	startTime time.Time
@@ -440,6 +458,22 @@ func (q *Task) Error() error {
 	return q.err
 }
 
+// String returns the query of the task followed by its progress, if any.
+func (q *Task) String() string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.progress == "" {
+		return q.query
+	}
+	return fmt.Sprintf("%s (%s)", q.query, q.progress)
+}
+
+func (q *Task) setProgress(progress string) {
+	q.mu.Lock()
+	q.progress = progress
+	q.mu.Unlock()
+}
+
 func (q *Task) setError(err error) {
 	q.mu.Lock()
 	q.err = err
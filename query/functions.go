@@ -4,8 +4,10 @@ import (
 	"container/heap"
 	"math"
 	"sort"
+	"strconv"
 	"time"
 
+	"github.com/influxdata/influxdb/pkg/estimator/tdigest"
 	"github.com/influxdata/influxdb/query/neldermead"
 	"github.com/influxdata/influxql"
 )
@@ -1572,3 +1574,529 @@ func (r *UnsignedBottomReducer) Emit() []UnsignedPoint {
 	sort.Sort(sort.Reverse(&h))
 	return points
 }
+
+// pointWeight returns the number of points represented by a point with the
+// aggregated count n.
+func pointWeight(n uint32) float64 {
+	if n == 0 {
+		return 1
+	}
+	return float64(n)
+}
+
+// appendWeightedPoints appends points with the value v to a so that their
+// aggregated counts total the weight w.
+func appendWeightedPoints(a []FloatPoint, v, w float64) []FloatPoint {
+	for w > 0 {
+		n := math.Min(w, math.MaxUint32)
+		a = append(a, FloatPoint{Time: ZeroTime, Value: v, Aggregated: uint32(n)})
+		w -= n
+	}
+	return a
+}
+
+// TDigestReducer accumulates the aggregated points into a t-digest. The digest
+// is emitted as a point per centroid whose aggregated count is the weight of
+// the centroid, so the digests of several series or shards can be merged by
+// aggregating the emitted points with another TDigestReducer.
+type TDigestReducer struct {
+	digest   *tdigest.TDigest
+	min, max float64
+}
+
+// NewTDigestReducer creates a new TDigestReducer.
+func NewTDigestReducer() *TDigestReducer {
+	return &TDigestReducer{
+		digest: tdigest.New(),
+		min:    math.Inf(1),
+		max:    math.Inf(-1),
+	}
+}
+
+// AggregateFloat aggregates a point into the reducer.
+func (r *TDigestReducer) AggregateFloat(p *FloatPoint) {
+	r.add(p.Value, pointWeight(p.Aggregated))
+}
+
+// AggregateInteger aggregates a point into the reducer.
+func (r *TDigestReducer) AggregateInteger(p *IntegerPoint) {
+	r.add(float64(p.Value), pointWeight(p.Aggregated))
+}
+
+// AggregateUnsigned aggregates a point into the reducer.
+func (r *TDigestReducer) AggregateUnsigned(p *UnsignedPoint) {
+	r.add(float64(p.Value), pointWeight(p.Aggregated))
+}
+
+func (r *TDigestReducer) add(v, w float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	r.digest.Add(v, w)
+	r.min, r.max = math.Min(r.min, v), math.Max(r.max, v)
+}
+
+// Emit emits the centroids of the digest as weighted points.
+func (r *TDigestReducer) Emit() []FloatPoint {
+	centroids := r.digest.Centroids()
+	if len(centroids) == 0 {
+		return nil
+	}
+
+	// The outer centroids may combine several points, which would lose the
+	// minimum and maximum values when merged. Split the extremes into points
+	// of their own, preserving the weight and mean of the centroids.
+	points := make([]FloatPoint, 0, len(centroids)+2)
+	for i, c := range centroids {
+		if i == 0 && c.Weight > 1 && c.Mean != r.min {
+			points = append(points, FloatPoint{Time: ZeroTime, Value: r.min, Aggregated: 1})
+			c.Mean, c.Weight = (c.Mean*c.Weight-r.min)/(c.Weight-1), c.Weight-1
+		}
+
+		var max bool
+		if i == len(centroids)-1 && c.Weight > 1 && c.Mean != r.max {
+			c.Mean, c.Weight = (c.Mean*c.Weight-r.max)/(c.Weight-1), c.Weight-1
+			max = true
+		}
+
+		points = appendWeightedPoints(points, c.Mean, c.Weight)
+		if max {
+			points = append(points, FloatPoint{Time: ZeroTime, Value: r.max, Aggregated: 1})
+		}
+	}
+	return points
+}
+
+// QuantileReducer estimates a quantile of the aggregated points, which are
+// typically the weighted centroids emitted by a TDigestReducer.
+type QuantileReducer struct {
+	digest   *tdigest.TDigest
+	quantile float64
+}
+
+// NewQuantileReducer creates a new QuantileReducer for the quantile q.
+func NewQuantileReducer(q float64) *QuantileReducer {
+	return &QuantileReducer{
+		digest:   tdigest.New(),
+		quantile: q,
+	}
+}
+
+// AggregateFloat aggregates a point into the reducer.
+func (r *QuantileReducer) AggregateFloat(p *FloatPoint) {
+	r.digest.Add(p.Value, pointWeight(p.Aggregated))
+}
+
+// Emit emits the estimated quantile as a single point.
+func (r *QuantileReducer) Emit() []FloatPoint {
+	if r.digest.Count() == 0 {
+		return nil
+	}
+	return []FloatPoint{{
+		Time:  ZeroTime,
+		Value: r.digest.Quantile(r.quantile),
+	}}
+}
+
+// histogramBuckets divides the range [min, max] into n buckets of equal width.
+// Each bucket includes its lower bound and the last bucket also includes max.
+type histogramBuckets struct {
+	min, max float64
+	n        int
+}
+
+// index returns the index of the bucket containing v. ok is false if v is
+// outside of the range of the buckets.
+func (b histogramBuckets) index(v float64) (i int, ok bool) {
+	if !(v >= b.min && v <= b.max) {
+		return 0, false
+	}
+
+	i = int(float64(b.n) * (v - b.min) / (b.max - b.min))
+	if i >= b.n {
+		i = b.n - 1
+	}
+	return i, true
+}
+
+// label returns the lower bound of the bucket at index i.
+func (b histogramBuckets) label(i int) string {
+	return strconv.FormatFloat(b.min+float64(i)*(b.max-b.min)/float64(b.n), 'f', -1, 64)
+}
+
+// midpoint returns the value in the middle of the bucket at index i.
+func (b histogramBuckets) midpoint(i int) float64 {
+	return b.min + (float64(i)+0.5)*(b.max-b.min)/float64(b.n)
+}
+
+// HistogramReducer counts the aggregated points into equal-width buckets. The
+// counts are emitted as a point per non-empty bucket, valued at the midpoint
+// of the bucket, whose aggregated count is the number of points in the bucket.
+// This allows the histograms of several series or shards to be merged by
+// aggregating the emitted points with another HistogramReducer or a
+// HistogramCountReducer using the same buckets.
+type HistogramReducer struct {
+	buckets histogramBuckets
+	counts  []float64
+}
+
+// NewHistogramReducer creates a new HistogramReducer with n buckets
+// spanning [min, max].
+func NewHistogramReducer(min, max float64, n int) *HistogramReducer {
+	return &HistogramReducer{
+		buckets: histogramBuckets{min: min, max: max, n: n},
+		counts:  make([]float64, n),
+	}
+}
+
+// AggregateFloat aggregates a point into the reducer.
+func (r *HistogramReducer) AggregateFloat(p *FloatPoint) {
+	r.add(p.Value, pointWeight(p.Aggregated))
+}
+
+// AggregateInteger aggregates a point into the reducer.
+func (r *HistogramReducer) AggregateInteger(p *IntegerPoint) {
+	r.add(float64(p.Value), pointWeight(p.Aggregated))
+}
+
+// AggregateUnsigned aggregates a point into the reducer.
+func (r *HistogramReducer) AggregateUnsigned(p *UnsignedPoint) {
+	r.add(float64(p.Value), pointWeight(p.Aggregated))
+}
+
+func (r *HistogramReducer) add(v, w float64) {
+	if i, ok := r.buckets.index(v); ok {
+		r.counts[i] += w
+	}
+}
+
+// Emit emits a weighted point for each non-empty bucket. If every bucket is
+// empty, a NaN point outside of the buckets is emitted so the window is still
+// reported with empty buckets.
+func (r *HistogramReducer) Emit() []FloatPoint {
+	var points []FloatPoint
+	for i, n := range r.counts {
+		if n > 0 {
+			points = appendWeightedPoints(points, r.buckets.midpoint(i), n)
+		}
+	}
+
+	if len(points) == 0 {
+		points = append(points, FloatPoint{Time: ZeroTime, Value: math.NaN()})
+	}
+	return points
+}
+
+// HistogramCountReducer counts the aggregated points into equal-width buckets,
+// emitting the count of every bucket in order of the buckets.
+type HistogramCountReducer struct {
+	HistogramReducer
+}
+
+// NewHistogramCountReducer creates a new HistogramCountReducer with n buckets
+// spanning [min, max].
+func NewHistogramCountReducer(min, max float64, n int) *HistogramCountReducer {
+	return &HistogramCountReducer{HistogramReducer: *NewHistogramReducer(min, max, n)}
+}
+
+// Emit emits a point with the count of each bucket.
+func (r *HistogramCountReducer) Emit() []IntegerPoint {
+	points := make([]IntegerPoint, len(r.counts))
+	for i, n := range r.counts {
+		points[i] = IntegerPoint{Time: ZeroTime, Value: int64(n)}
+	}
+	return points
+}
+
+// rateSample is a sample of a counter aggregated by a RateReducer.
+type rateSample struct {
+	time  int64
+	value float64
+}
+
+type rateSamples []rateSample
+
+func (a rateSamples) Len() int           { return len(a) }
+func (a rateSamples) Less(i, j int) bool { return a[i].time < a[j].time }
+func (a rateSamples) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// RateReducer calculates the increase of a counter within a window using the
+// semantics of the Prometheus rate(), irate() and increase() functions. A
+// decrease in the value of the counter is treated as a counter reset.
+type RateReducer struct {
+	opt     IteratorOptions
+	unit    time.Duration
+	instant bool
+	samples rateSamples
+}
+
+// NewRateReducer creates a new RateReducer that calculates the average rate of
+// increase per unit of the counter within each window. The increase is
+// extrapolated to the boundaries of the window.
+func NewRateReducer(unit Interval, opt IteratorOptions) *RateReducer {
+	return &RateReducer{opt: opt, unit: unit.Duration}
+}
+
+// NewInstantRateReducer creates a new RateReducer that calculates the rate of
+// increase per unit between the last two points of the counter in each window.
+func NewInstantRateReducer(unit Interval, opt IteratorOptions) *RateReducer {
+	return &RateReducer{opt: opt, unit: unit.Duration, instant: true}
+}
+
+// NewIncreaseReducer creates a new RateReducer that calculates the increase of
+// the counter within each window. The increase is extrapolated to the
+// boundaries of the window.
+func NewIncreaseReducer(opt IteratorOptions) *RateReducer {
+	return &RateReducer{opt: opt}
+}
+
+// AggregateFloat aggregates a point into the reducer.
+func (r *RateReducer) AggregateFloat(p *FloatPoint) {
+	r.samples = append(r.samples, rateSample{time: p.Time, value: p.Value})
+}
+
+// AggregateInteger aggregates a point into the reducer.
+func (r *RateReducer) AggregateInteger(p *IntegerPoint) {
+	r.samples = append(r.samples, rateSample{time: p.Time, value: float64(p.Value)})
+}
+
+// AggregateUnsigned aggregates a point into the reducer.
+func (r *RateReducer) AggregateUnsigned(p *UnsignedPoint) {
+	r.samples = append(r.samples, rateSample{time: p.Time, value: float64(p.Value)})
+}
+
+// Emit emits the rate or increase of the counter as a single point. Nothing
+// is emitted if there are less than two points at different times.
+func (r *RateReducer) Emit() []FloatPoint {
+	if len(r.samples) < 2 {
+		return nil
+	}
+	sort.Stable(r.samples)
+
+	var value float64
+	if r.instant {
+		prev, last := r.samples[len(r.samples)-2], r.samples[len(r.samples)-1]
+		elapsed := last.time - prev.time
+		if elapsed == 0 {
+			return nil
+		}
+
+		value = last.value - prev.value
+		if last.value < prev.value {
+			value = last.value
+		}
+		value /= float64(elapsed) / float64(r.unit)
+		return []FloatPoint{{Time: ZeroTime, Value: value}}
+	}
+
+	first, last := r.samples[0], r.samples[len(r.samples)-1]
+	sampled := float64(last.time - first.time)
+	if sampled == 0 {
+		return nil
+	}
+
+	// Add the value before each reset to account for the decrease.
+	value = last.value - first.value
+	for i := 1; i < len(r.samples); i++ {
+		if prev := r.samples[i-1].value; r.samples[i].value < prev {
+			value += prev
+		}
+	}
+
+	// Determine the boundaries of the window, limited to the time range of
+	// the query. An unbounded window is not extrapolated.
+	start, end := r.opt.Window(first.time)
+	if start < r.opt.StartTime {
+		start = r.opt.StartTime
+	}
+	if end > r.opt.EndTime+1 {
+		end = r.opt.EndTime + 1
+	}
+	if start <= influxql.MinTime {
+		start = first.time
+	}
+	if end >= influxql.MaxTime {
+		end = last.time
+	}
+
+	// Extrapolate the increase to the boundaries of the window if the first
+	// and last points are close to the boundaries, otherwise extrapolate by
+	// half of the average interval between points. A counter is not
+	// extrapolated below zero.
+	toStart, toEnd := float64(first.time-start), float64(end-last.time)
+	if value > 0 && first.value >= 0 {
+		if toZero := sampled * (first.value / value); toZero < toStart {
+			toStart = toZero
+		}
+	}
+
+	avg := sampled / float64(len(r.samples)-1)
+	threshold := avg * 1.1
+	extrapolated := sampled
+	for _, d := range []float64{toStart, toEnd} {
+		if d < threshold {
+			extrapolated += d
+		} else {
+			extrapolated += avg / 2
+		}
+	}
+	value *= extrapolated / sampled
+
+	if r.unit > 0 {
+		value /= float64(end-start) / float64(r.unit)
+	}
+	return []FloatPoint{{Time: ZeroTime, Value: value}}
+}
+
+// TimeWeightedAverageReducer calculates the average of the aggregated points
+// weighted by the time each value was held. It shares the windowing of the
+// integral reducers: the value at each window boundary is interpolated and
+// carried into the next window, so a window begins with the last value of the
+// window before it. Windows without points are emitted with the carried value.
+type TimeWeightedAverageReducer struct {
+	step    bool
+	sum     float64
+	elapsed float64
+	prev    FloatPoint
+	window  struct {
+		start int64
+		end   int64
+	}
+	points []FloatPoint
+	opt    IteratorOptions
+}
+
+// NewTimeWeightedAverageReducer creates a new TimeWeightedAverageReducer. If
+// step is true, each value is held until the next point. Otherwise values are
+// interpolated linearly between points.
+func NewTimeWeightedAverageReducer(step bool, opt IteratorOptions) *TimeWeightedAverageReducer {
+	return &TimeWeightedAverageReducer{
+		step: step,
+		prev: FloatPoint{Nil: true},
+		opt:  opt,
+	}
+}
+
+// AggregateFloat aggregates a point into the reducer.
+func (r *TimeWeightedAverageReducer) AggregateFloat(p *FloatPoint) {
+	r.aggregate(p.Time, p.Value)
+}
+
+// AggregateInteger aggregates a point into the reducer.
+func (r *TimeWeightedAverageReducer) AggregateInteger(p *IntegerPoint) {
+	r.aggregate(p.Time, float64(p.Value))
+}
+
+// AggregateUnsigned aggregates a point into the reducer.
+func (r *TimeWeightedAverageReducer) AggregateUnsigned(p *UnsignedPoint) {
+	r.aggregate(p.Time, float64(p.Value))
+}
+
+func (r *TimeWeightedAverageReducer) aggregate(t int64, v float64) {
+	// If this is the first point, just save it.
+	if r.prev.Nil {
+		r.prev = FloatPoint{Time: t, Value: v}
+		if !r.opt.Interval.IsZero() {
+			r.window.start, r.window.end = r.opt.Window(t)
+		}
+		return
+	}
+
+	// If this point has the same timestamp as the previous one, it replaces
+	// the previous point. Points sent into this reducer are expected to be
+	// fed in order.
+	if r.prev.Time == t {
+		r.prev.Value = v
+		return
+	}
+
+	// Close every window between the previous point and this one. The value
+	// at the boundary of each window is carried into the next.
+	for !r.opt.Interval.IsZero() {
+		var boundary int64
+		if r.opt.Ascending {
+			if t < r.window.end {
+				break
+			}
+			boundary = r.window.end
+		} else {
+			if t >= r.window.start {
+				break
+			}
+			boundary = r.window.start
+		}
+
+		if r.prev.Time != boundary {
+			value := r.prev.Value
+			if !r.step {
+				value = linearFloat(boundary, r.prev.Time, t, r.prev.Value, v)
+			} else if !r.opt.Ascending {
+				value = v
+			}
+			r.add(boundary, value)
+		}
+		r.points = append(r.points, r.average())
+
+		if r.opt.Ascending {
+			r.window.start, r.window.end = r.opt.Window(boundary)
+		} else {
+			r.window.start, r.window.end = r.opt.Window(boundary - 1)
+		}
+		r.sum, r.elapsed = 0, 0
+	}
+	r.add(t, v)
+}
+
+// add accumulates the area between the previous point and the point at time t
+// with the value v, and makes that point the previous point.
+func (r *TimeWeightedAverageReducer) add(t int64, v float64) {
+	elapsed := float64(t - r.prev.Time)
+	if !r.opt.Ascending {
+		elapsed = -elapsed
+	}
+	if !r.step {
+		r.sum += 0.5 * (v + r.prev.Value) * elapsed
+	} else if r.opt.Ascending {
+		r.sum += r.prev.Value * elapsed
+	} else {
+		r.sum += v * elapsed
+	}
+	r.elapsed += elapsed
+	r.prev = FloatPoint{Time: t, Value: v}
+}
+
+// average returns the time-weighted average of the current window. A window
+// with a single point has the value of that point.
+func (r *TimeWeightedAverageReducer) average() FloatPoint {
+	p := FloatPoint{Time: r.window.start, Value: r.prev.Value}
+	if r.elapsed != 0 {
+		p.Value = r.sum / r.elapsed
+	}
+	return p
+}
+
+// Emit emits the time-weighted averages of the windows that have been closed.
+// As with the integral reducers, the time of the point is zero outside of a
+// group-by-time and the start of the window within one.
+func (r *TimeWeightedAverageReducer) Emit() []FloatPoint {
+	if len(r.points) == 0 {
+		return nil
+	}
+
+	// The points are consumed from the end, so reverse them to emit them in
+	// the order their windows were closed.
+	points := r.points
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	r.points = nil
+	return points
+}
+
+// Close closes the current window so its average will be emitted.
+func (r *TimeWeightedAverageReducer) Close() error {
+	if !r.prev.Nil {
+		r.points = append(r.points, r.average())
+	}
+	return nil
+}
@@ -497,3 +497,144 @@ func TestSample_SampleSizeGreaterThanNumPoints(t *testing.T) {
 		t.Fatalf("unexpected points: %s", spew.Sdump(points))
 	}
 }
+
+// counterPoints is a counter which resets between the third and fourth points.
+var counterPoints = []query.FloatPoint{
+	{Time: 10 * Second, Value: 10},
+	{Time: 20 * Second, Value: 20},
+	{Time: 30 * Second, Value: 5},
+	{Time: 40 * Second, Value: 15},
+	{Time: 50 * Second, Value: 25},
+}
+
+func TestRateReducer(t *testing.T) {
+	opt := query.IteratorOptions{
+		StartTime: influxql.MinTime,
+		EndTime:   influxql.MaxTime,
+		Interval:  query.Interval{Duration: time.Minute},
+	}
+
+	for _, tt := range []struct {
+		name   string
+		r      *query.RateReducer
+		points []query.FloatPoint
+		exp    float64
+	}{
+		// The increase of 35 over the 40s sampled is extrapolated by 10s to
+		// each edge of the window.
+		{name: "rate", r: query.NewRateReducer(query.Interval{Duration: time.Second}, opt), points: counterPoints, exp: 0.875},
+		{name: "increase", r: query.NewIncreaseReducer(opt), points: counterPoints, exp: 52.5},
+		{name: "irate", r: query.NewInstantRateReducer(query.Interval{Duration: time.Second}, opt), points: counterPoints, exp: 1},
+		{name: "irate reset", r: query.NewInstantRateReducer(query.Interval{Duration: time.Minute}, opt), points: counterPoints[:3], exp: 30},
+		// Samples more than 1.1 sample intervals from the window edges are
+		// extrapolated by half an interval.
+		{
+			name: "increase partial window",
+			r:    query.NewIncreaseReducer(opt),
+			points: []query.FloatPoint{
+				{Time: 25 * Second, Value: 100},
+				{Time: 30 * Second, Value: 110},
+				{Time: 35 * Second, Value: 120},
+			},
+			exp: 30,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.points {
+				tt.r.AggregateFloat(&tt.points[i])
+			}
+			points := tt.r.Emit()
+			if len(points) != 1 {
+				t.Fatalf("unexpected number of points emitted: got %d exp 1", len(points))
+			} else if !almostEqual(points[0].Value, tt.exp) {
+				t.Fatalf("unexpected value: got %v exp %v", points[0].Value, tt.exp)
+			}
+		})
+	}
+}
+
+func TestRateReducer_SinglePoint(t *testing.T) {
+	r := query.NewIncreaseReducer(query.IteratorOptions{StartTime: influxql.MinTime, EndTime: influxql.MaxTime})
+	r.AggregateFloat(&query.FloatPoint{Time: 0, Value: 1})
+	if points := r.Emit(); len(points) != 0 {
+		t.Fatalf("unexpected points: %s", spew.Sdump(points))
+	}
+}
+
+func TestTimeWeightedAverageReducer(t *testing.T) {
+	// A sensor which only reports changes of state.
+	points := []query.FloatPoint{
+		{Time: 0 * Second, Value: 0},
+		{Time: 10 * Second, Value: 10},
+		{Time: 40 * Second, Value: 0},
+		{Time: 90 * Second, Value: 20},
+	}
+
+	for _, tt := range []struct {
+		name string
+		step bool
+		exp  []query.FloatPoint
+	}{
+		{
+			name: "step",
+			step: true,
+			exp: []query.FloatPoint{
+				{Time: 0 * Second, Value: 6},
+				{Time: 50 * Second, Value: 0},
+			},
+		},
+		{
+			name: "linear",
+			exp: []query.FloatPoint{
+				{Time: 0 * Second, Value: 4.4},
+				{Time: 50 * Second, Value: 12},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := query.NewTimeWeightedAverageReducer(tt.step, query.IteratorOptions{
+				StartTime: influxql.MinTime,
+				EndTime:   influxql.MaxTime,
+				Interval:  query.Interval{Duration: 50 * time.Second},
+				Ascending: true,
+			})
+
+			var got []query.FloatPoint
+			for i := range points {
+				r.AggregateFloat(&points[i])
+				got = append(got, r.Emit()...)
+			}
+			r.Close()
+			got = append(got, r.Emit()...)
+
+			if !deep.Equal(tt.exp, got) {
+				t.Fatalf("unexpected points: %s", spew.Sdump(got))
+			}
+		})
+	}
+}
+
+func TestTimeWeightedAverageReducer_CarryValue(t *testing.T) {
+	r := query.NewTimeWeightedAverageReducer(true, query.IteratorOptions{
+		StartTime: influxql.MinTime,
+		EndTime:   influxql.MaxTime,
+		Interval:  query.Interval{Duration: 10 * time.Second},
+		Ascending: true,
+	})
+	r.AggregateFloat(&query.FloatPoint{Time: 0, Value: 5})
+	r.AggregateFloat(&query.FloatPoint{Time: 35 * Second, Value: 7})
+
+	// The windows are emitted so the last window closed is at the end.
+	points := r.Emit()
+	r.Close()
+	points = append(r.Emit(), points...)
+
+	if exp := []query.FloatPoint{
+		{Time: 30 * Second, Value: 5},
+		{Time: 20 * Second, Value: 5},
+		{Time: 10 * Second, Value: 5},
+		{Time: 0 * Second, Value: 5},
+	}; !deep.Equal(exp, points) {
+		t.Fatalf("unexpected points: %s", spew.Sdump(points))
+	}
+}
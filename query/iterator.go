@@ -724,6 +724,10 @@ type IteratorOptions struct {
 
 	// Authorizer can limit access to data
 	Authorizer Authorizer
+
+	// Join is the type of join performed by statements selecting qualified
+	// fields from more than one measurement.
+	Join JoinType
 }
 
 // newIteratorOptionsStmt creates the iterator options from stmt.
@@ -792,6 +796,7 @@ func newIteratorOptionsStmt(stmt *influxql.SelectStatement, sopt SelectOptions)
 	opt.SLimit, opt.SOffset = stmt.SLimit, stmt.SOffset
 	opt.MaxSeriesN = sopt.MaxSeriesN
 	opt.Authorizer = sopt.Authorizer
+	opt.Join = sopt.Join
 
 	return opt, nil
 }
@@ -819,6 +824,7 @@ func newIteratorOptionsSubstatement(ctx context.Context, stmt *influxql.SelectSt
 		subOpt.GroupBy[d] = struct{}{}
 	}
 	subOpt.InterruptCh = opt.InterruptCh
+	subOpt.Join = opt.Join
 
 	// Extract the time range and condition from the condition.
 	cond, t, err := influxql.ConditionExpr(stmt.Condition, nil)
@@ -996,6 +1002,25 @@ func (opt IteratorOptions) IntegralInterval() Interval {
 	return Interval{Duration: time.Second}
 }
 
+// RateInterval returns the time interval for the rate and irate functions.
+func (opt IteratorOptions) RateInterval() Interval {
+	// Use the interval on the rate() or irate() call, if specified.
+	if expr, ok := opt.Expr.(*influxql.Call); ok && len(expr.Args) == 2 {
+		return Interval{Duration: expr.Args[1].(*influxql.DurationLiteral).Val}
+	}
+
+	return Interval{Duration: time.Second}
+}
+
+// TimeWeightedAverageStep returns true if the time_weighted_avg function
+// should use step interpolation rather than linear interpolation.
+func (opt IteratorOptions) TimeWeightedAverageStep() bool {
+	if expr, ok := opt.Expr.(*influxql.Call); ok && len(expr.Args) == 2 {
+		return expr.Args[1].(*influxql.StringLiteral).Val == "step"
+	}
+	return false
+}
+
 // GetDimensions retrieves the dimensions for this query.
 func (opt IteratorOptions) GetDimensions() []string {
 	if len(opt.GroupBy) > 0 {
@@ -0,0 +1,436 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/influxql"
+)
+
+// JoinType determines whether the measurements of a statement are joined.
+type JoinType int
+
+const (
+	// NoJoin reads names with a dot, such as "cpu.usage", as field keys.
+	NoJoin JoinType = iota
+
+	// OuterJoin joins the measurements of a statement, where the fields of
+	// measurements without a matching point are null.
+	OuterJoin
+
+	// InnerJoin joins the measurements of a statement and only returns the
+	// times where every measurement has a point.
+	InnerJoin
+)
+
+// ParseJoinType parses a join type of "outer", "inner" or an empty string
+// for no join.
+func ParseJoinType(s string) (JoinType, error) {
+	switch s {
+	case "":
+		return NoJoin, nil
+	case "outer":
+		return OuterJoin, nil
+	case "inner":
+		return InnerJoin, nil
+	default:
+		return NoJoin, fmt.Errorf("invalid join type: %q", s)
+	}
+}
+
+// ParseJoinTypes parses a comma-separated list of join types, one for each of
+// the n statements of a query, such as "outer,,inner" for a query of three
+// statements where the second statement is not joined.  InfluxQL has no JOIN
+// clause, so the join type of each statement is passed alongside the query.
+// An empty string joins none of the statements.
+func ParseJoinTypes(s string, n int) ([]JoinType, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	a := strings.Split(s, ",")
+	if len(a) != n {
+		return nil, fmt.Errorf("expected a join type for each of the %d statements, got %d", n, len(a))
+	}
+
+	joins := make([]JoinType, len(a))
+	for i := range a {
+		join, err := ParseJoinType(strings.TrimSpace(a[i]))
+		if err != nil {
+			return nil, err
+		}
+		joins[i] = join
+	}
+	return joins, nil
+}
+
+// joinInfo describes a join between the measurements of a statement.
+//
+// Joins must be requested with a join type for each statement, as InfluxQL
+// has no JOIN clause; see ParseJoinTypes.  A statement then joins its
+// sources when it selects from more than one measurement and its fields are
+// qualified with the name of the measurement they are read from, such as:
+//
+//	SELECT cpu.usage / mem.cores FROM cpu, mem GROUP BY host
+//
+// Points are joined on their time and the tags in the GROUP BY clause.
+// Without a join type, such names are read as field keys as before.
+type joinInfo struct {
+	// Measurements holds the joined measurements in the order they appear in
+	// the FROM clause.
+	Measurements []*influxql.Measurement
+
+	// Inner is set if rows are only returned when every measurement matches.
+	Inner bool
+}
+
+// newJoinInfo returns the join performed by the statement with the join
+// type. If the statement does not join its sources, nil is returned.
+func newJoinInfo(stmt *influxql.SelectStatement, typ JoinType) *joinInfo {
+	if typ == NoJoin || len(stmt.Sources) < 2 {
+		return nil
+	}
+
+	measurements := make([]*influxql.Measurement, 0, len(stmt.Sources))
+	for _, source := range stmt.Sources {
+		m, ok := source.(*influxql.Measurement)
+		if !ok || m.Regex != nil {
+			return nil
+		}
+		measurements = append(measurements, m)
+	}
+	join := &joinInfo{
+		Measurements: measurements,
+		Inner:        typ == InnerJoin,
+	}
+
+	// The statement is a join if any field is qualified with a measurement.
+	qualified := false
+	influxql.WalkFunc(stmt.Fields, func(n influxql.Node) {
+		if ref, ok := n.(*influxql.VarRef); ok {
+			if m, _ := join.lookup(ref.Val); m != nil {
+				qualified = true
+			}
+		}
+	})
+	if !qualified {
+		return nil
+	}
+	return join
+}
+
+// lookup splits a qualified field name into the measurement it is read from
+// and the name of the field. If the name is not qualified with one of the
+// joined measurements, a nil measurement is returned.
+func (j *joinInfo) lookup(name string) (*influxql.Measurement, string) {
+	for _, m := range j.Measurements {
+		if strings.HasPrefix(name, m.Name+".") {
+			return m, name[len(m.Name)+1:]
+		}
+	}
+	return nil, ""
+}
+
+// Name returns the name of the series produced by the join.
+func (j *joinInfo) Name() string {
+	names := make([]string, len(j.Measurements))
+	for i, m := range j.Measurements {
+		names[i] = m.Name
+	}
+	return strings.Join(names, ",")
+}
+
+// validate ensures the compiled statement can be executed as a join.
+func (j *joinInfo) validate(c *compiledStatement, stmt *influxql.SelectStatement) error {
+	if len(c.FunctionCalls) > 0 {
+		return errors.New("aggregate functions are not supported in a join, use a subquery")
+	} else if stmt.HasWildcard() {
+		return errors.New("wildcards are not supported in a join")
+	}
+
+	if c.FillOption != influxql.NullFill {
+		return errors.New("fill is not supported in a join, use an inner join to only return times where every measurement has a point")
+	}
+
+	for _, ref := range influxql.ExprNames(c.Condition) {
+		if m, _ := j.lookup(ref.Val); m != nil {
+			return fmt.Errorf("conditions on joined fields are not supported: %s", ref.Val)
+		}
+	}
+
+	var err error
+	influxql.WalkFunc(stmt.Fields, func(n influxql.Node) {
+		if ref, ok := n.(*influxql.VarRef); ok && err == nil {
+			if m, _ := j.lookup(ref.Val); m == nil {
+				err = fmt.Errorf("field must be qualified with a measurement in a join: %s", ref.Val)
+			}
+		}
+	})
+	return err
+}
+
+// rewriteJoinFields resolves the types of the qualified fields in any join
+// within the statement or its subqueries. The field mapper is unable to
+// resolve qualified names, so this is done before the fields are rewritten.
+// The statement is cloned if it contains a join.
+func rewriteJoinFields(stmt *influxql.SelectStatement, m influxql.FieldMapper, typ JoinType) *influxql.SelectStatement {
+	if !hasJoin(stmt, typ) {
+		return stmt
+	}
+	other := stmt.Clone()
+	mapJoinTypes(other, m, typ)
+	return other
+}
+
+func hasJoin(stmt *influxql.SelectStatement, typ JoinType) bool {
+	if newJoinInfo(stmt, typ) != nil {
+		return true
+	}
+	for _, source := range stmt.Sources {
+		if s, ok := source.(*influxql.SubQuery); ok && hasJoin(s.Statement, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapJoinTypes(stmt *influxql.SelectStatement, m influxql.FieldMapper, typ JoinType) {
+	for _, source := range stmt.Sources {
+		if s, ok := source.(*influxql.SubQuery); ok {
+			mapJoinTypes(s.Statement, m, typ)
+		}
+	}
+
+	join := newJoinInfo(stmt, typ)
+	if join == nil {
+		return
+	}
+	influxql.WalkFunc(stmt.Fields, func(n influxql.Node) {
+		ref, ok := n.(*influxql.VarRef)
+		if !ok || (ref.Type != influxql.Unknown && ref.Type != influxql.AnyField) {
+			return
+		}
+		if mm, name := join.lookup(ref.Val); mm != nil {
+			ref.Type = m.MapType(mm, name)
+		}
+	})
+}
+
+// buildJoinIterators creates an iterator for each field of a join. An
+// auxiliary iterator is created for each measurement and the points of each
+// are combined into a single auxiliary iterator by a join iterator.
+func buildJoinIterators(ctx context.Context, fields influxql.Fields, ic IteratorCreator, join *joinInfo, opt IteratorOptions) ([]Iterator, error) {
+	inputs := make([]*joinInput, 0, len(join.Measurements))
+	if err := func() error {
+		for _, m := range join.Measurements {
+			// Select the fields read from this measurement and record where
+			// they belong in the joined point.
+			subOpt := opt
+			subOpt.Aux = nil
+			subOpt.Limit, subOpt.Offset = 0, 0
+			subOpt.Dedupe = false
+
+			var indexes []int
+			for i, ref := range opt.Aux {
+				if mm, name := join.lookup(ref.Val); mm == m {
+					subOpt.Aux = append(subOpt.Aux, influxql.VarRef{Val: name, Type: ref.Type})
+					indexes = append(indexes, i)
+				}
+			}
+
+			input, err := ic.CreateIterator(ctx, m, subOpt)
+			if err != nil {
+				return err
+			}
+			inputs = append(inputs, &joinInput{itr: input, indexes: indexes})
+		}
+		return nil
+	}(); err != nil {
+		for _, input := range inputs {
+			if input.itr != nil {
+				input.itr.Close()
+			}
+		}
+		return nil, err
+	}
+
+	itr := newJoinIterator(inputs, join.Name(), len(opt.Aux), join.Inner, opt)
+	return buildAuxFieldIterators(ctx, fields, itr, opt)
+}
+
+// joinInput is an input to a join iterator.
+type joinInput struct {
+	itr     Iterator
+	indexes []int // index of each auxiliary value in the joined point
+	buf     *joinPoint
+}
+
+// joinPoint holds a copy of a point read from a join input.
+type joinPoint struct {
+	time int64
+	tags Tags
+	id   string
+	aux  []interface{}
+}
+
+// joinIterator combines the points of several auxiliary iterators with the
+// same time and tags into single points.
+type joinIterator struct {
+	inputs []*joinInput
+	name   string
+	auxN   int
+	inner  bool
+	opt    IteratorOptions
+
+	rows []FloatPoint
+}
+
+func newJoinIterator(inputs []*joinInput, name string, auxN int, inner bool, opt IteratorOptions) *joinIterator {
+	return &joinIterator{
+		inputs: inputs,
+		name:   name,
+		auxN:   auxN,
+		inner:  inner,
+		opt:    opt,
+	}
+}
+
+// Stats returns the aggregated stats of the inputs.
+func (itr *joinIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		if input.itr != nil {
+			stats.Add(input.itr.Stats())
+		}
+	}
+	return stats
+}
+
+// Close closes the inputs.
+func (itr *joinIterator) Close() error {
+	for _, input := range itr.inputs {
+		if input.itr != nil {
+			input.itr.Close()
+		}
+	}
+	return nil
+}
+
+// Next returns the next joined point.
+func (itr *joinIterator) Next() (*FloatPoint, error) {
+	for len(itr.rows) == 0 {
+		if ok, err := itr.join(); err != nil || !ok {
+			return nil, err
+		}
+	}
+
+	p := &itr.rows[0]
+	itr.rows = itr.rows[1:]
+	return p, nil
+}
+
+// join reads every point with the next time and tags from the inputs and
+// generates the joined rows. Returns false once the inputs are exhausted.
+func (itr *joinIterator) join() (bool, error) {
+	var next *joinPoint
+	for _, input := range itr.inputs {
+		p, err := itr.peek(input)
+		if err != nil {
+			return false, err
+		} else if p != nil && (next == nil || itr.less(p, next)) {
+			next = p
+		}
+	}
+	if next == nil {
+		return false, nil
+	}
+	time, tags, id := next.time, next.tags, next.id
+
+	// Read the matching points from each input. An input may contain several
+	// points with the same time and tags.
+	matches := make([][]*joinPoint, len(itr.inputs))
+	for i, input := range itr.inputs {
+		for {
+			p, err := itr.peek(input)
+			if err != nil {
+				return false, err
+			} else if p == nil || p.time != time || p.id != id {
+				break
+			}
+			matches[i] = append(matches[i], p)
+			input.buf = nil
+		}
+	}
+
+	// An inner join skips the time unless every input has a match.
+	if itr.inner {
+		for i := range matches {
+			if len(matches[i]) == 0 {
+				return true, nil
+			}
+		}
+	}
+
+	// Generate the cross product of the matching points. An input without
+	// any matches contributes null values.
+	rows := []FloatPoint{{Name: itr.name, Tags: tags, Time: time, Aux: make([]interface{}, itr.auxN)}}
+	for i, input := range itr.inputs {
+		if len(matches[i]) == 0 {
+			continue
+		}
+
+		product := make([]FloatPoint, 0, len(rows)*len(matches[i]))
+		for _, row := range rows {
+			for _, p := range matches[i] {
+				aux := make([]interface{}, itr.auxN)
+				copy(aux, row.Aux)
+				for j, idx := range input.indexes {
+					if j < len(p.aux) {
+						aux[idx] = p.aux[j]
+					}
+				}
+				product = append(product, FloatPoint{Name: row.Name, Tags: row.Tags, Time: row.Time, Aux: aux})
+			}
+		}
+		rows = product
+	}
+	itr.rows = rows
+	return true, nil
+}
+
+// peek returns the next point of the input without consuming it.
+func (itr *joinIterator) peek(input *joinInput) (*joinPoint, error) {
+	if input.buf != nil {
+		return input.buf, nil
+	}
+
+	p, err := readIterator(input.itr)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	// Copy the point as iterators may reuse the points they return.
+	tags := p.tags()
+	tags = tags.Subset(itr.opt.Dimensions)
+	aux := make([]interface{}, len(p.aux()))
+	copy(aux, p.aux())
+	input.buf = &joinPoint{time: p.time(), tags: tags, id: tags.ID(), aux: aux}
+	return input.buf, nil
+}
+
+// less returns true if the point x should be joined before the point y.
+func (itr *joinIterator) less(x, y *joinPoint) bool {
+	if itr.opt.Ascending {
+		if x.id != y.id {
+			return x.id < y.id
+		}
+		return x.time < y.time
+	}
+
+	if x.id != y.id {
+		return x.id > y.id
+	}
+	return x.time > y.time
+}
@@ -32,6 +32,10 @@ type SelectOptions struct {
 
 	// Maximum number of buckets for a statement.
 	MaxBucketsN int
+
+	// Join is the type of join performed by statements selecting qualified
+	// fields from more than one measurement.
+	Join JoinType
 }
 
 // ShardMapper retrieves and maps shards into an IteratorCreator that can later be
@@ -72,7 +76,7 @@ type PreparedStatement interface {
 // Prepare will compile the statement with the default compile options and
 // then prepare the query.
 func Prepare(stmt *influxql.SelectStatement, shardMapper ShardMapper, opt SelectOptions) (PreparedStatement, error) {
-	c, err := Compile(stmt, CompileOptions{})
+	c, err := Compile(stmt, CompileOptions{Join: opt.Join})
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +186,19 @@ func buildIterators(ctx context.Context, stmt *influxql.SelectStatement, ic Iter
 	}
 	sort.Sort(influxql.VarRefs(opt.Aux))
 
+	// Join the measurements if the fields are read from more than one of them.
+	// Aggregates are not permitted within a join.
+	if join := newJoinInfo(stmt, opt.Join); join != nil {
+		if span != nil {
+			span = span.StartSpan("join_iterators")
+			defer span.Finish()
+
+			span.SetLabels("statement", stmt.String())
+			ctx = tracing.NewContextWithSpan(ctx, span)
+		}
+		return buildJoinIterators(ctx, stmt.Fields, ic, join, opt)
+	}
+
 	// If there are multiple auxilary fields and no calls then construct an aux iterator.
 	if len(info.calls) == 0 && len(info.refs) > 0 {
 		if span != nil {
@@ -284,7 +301,12 @@ func buildAuxIterators(ctx context.Context, fields influxql.Fields, ic IteratorC
 	} else if input == nil {
 		input = &nilFloatIterator{}
 	}
+	return buildAuxFieldIterators(ctx, fields, input, opt)
+}
 
+// buildAuxFieldIterators creates an iterator for each field from an iterator
+// of auxiliary fields.
+func buildAuxFieldIterators(ctx context.Context, fields influxql.Fields, input Iterator, opt IteratorOptions) ([]Iterator, error) {
 	// Filter out duplicate rows, if required.
 	if opt.Dedupe {
 		// If there is no group by and it is a float iterator, see if we can use a fast dedupe.
@@ -496,6 +518,40 @@ type exprIteratorBuilder struct {
 	writeMode bool
 }
 
+// seriesDimensions returns the dimensions of the statement together with the
+// tag keys of its sources, so that grouping by them keeps each series apart.
+func (b *exprIteratorBuilder) seriesDimensions() (map[string]struct{}, error) {
+	dims := make(map[string]struct{}, len(b.opt.GroupBy))
+	for d := range b.opt.GroupBy {
+		dims[d] = struct{}{}
+	}
+
+	for _, source := range b.sources {
+		switch source := source.(type) {
+		case *influxql.Measurement:
+			m, ok := b.ic.(influxql.FieldMapper)
+			if !ok {
+				continue
+			}
+			_, d, err := m.FieldDimensions(source)
+			if err != nil {
+				return nil, err
+			}
+			for k := range d {
+				dims[k] = struct{}{}
+			}
+		case *influxql.SubQuery:
+			// The points of a subquery only have the tags it groups by.
+			for _, d := range source.Statement.Dimensions {
+				if ref, ok := d.Expr.(*influxql.VarRef); ok {
+					dims[ref.Val] = struct{}{}
+				}
+			}
+		}
+	}
+	return dims, nil
+}
+
 func (b *exprIteratorBuilder) buildVarRefIterator(ctx context.Context, expr *influxql.VarRef) (Iterator, error) {
 	inputs := make([]Iterator, 0, len(b.sources))
 	if err := func() error {
@@ -621,6 +677,18 @@ func (b *exprIteratorBuilder) buildCallIterator(ctx context.Context, expr *influ
 			return newMovingAverageIterator(input, int(n.Val), opt)
 		}
 		panic(fmt.Sprintf("invalid series aggregate function: %s", expr.Name))
+	case "histogram":
+		// The histogram emits a point for each bucket so it cannot be filled.
+		input, err := b.callIterator(ctx, expr, opt)
+		if err != nil {
+			return nil, err
+		}
+		itr, err := newHistogramCountIterator(input, opt)
+		if err != nil {
+			input.Close()
+			return nil, err
+		}
+		return NewIntervalIterator(itr, opt), nil
 	case "cumulative_sum":
 		opt.Ordered = true
 		input, err := buildExprIterator(ctx, expr.Args[0], b.ic, b.sources, opt, b.selector, false)
@@ -636,6 +704,13 @@ func (b *exprIteratorBuilder) buildCallIterator(ctx context.Context, expr *influ
 		}
 		interval := opt.IntegralInterval()
 		return newIntegralIterator(input, opt, interval)
+	case "time_weighted_avg":
+		opt.Ordered = true
+		input, err := buildExprIterator(ctx, expr.Args[0].(*influxql.VarRef), b.ic, b.sources, opt, false, false)
+		if err != nil {
+			return nil, err
+		}
+		return newTimeWeightedAverageIterator(input, opt, opt.TimeWeightedAverageStep())
 	case "top":
 		if len(expr.Args) < 2 {
 			return nil, fmt.Errorf("top() requires 2 or more arguments, got %d", len(expr.Args))
@@ -760,6 +835,19 @@ func (b *exprIteratorBuilder) buildCallIterator(ctx context.Context, expr *influ
 			fallthrough
 		case "min", "max", "sum", "first", "last", "mean":
 			return b.callIterator(ctx, expr, opt)
+		case "quantile":
+			// The digests calculated for each shard are merged into a single
+			// estimate of the quantile.
+			input, err := b.callIterator(ctx, expr, opt)
+			if err != nil {
+				return nil, err
+			}
+			itr, err := newQuantileEstimateIterator(input, opt)
+			if err != nil {
+				input.Close()
+				return nil, err
+			}
+			return itr, nil
 		case "median":
 			opt.Ordered = true
 			input, err := buildExprIterator(ctx, expr.Args[0].(*influxql.VarRef), b.ic, b.sources, opt, false, false)
@@ -767,6 +855,41 @@ func (b *exprIteratorBuilder) buildCallIterator(ctx context.Context, expr *influ
 				return nil, err
 			}
 			return newMedianIterator(input, opt)
+		case "rate", "irate", "increase":
+			// Each series is a separate counter, so the points of each series
+			// are reduced on their own and the results are summed within the
+			// groups of the statement.
+			dims, err := b.seriesDimensions()
+			if err != nil {
+				return nil, err
+			}
+			seriesOpt := opt
+			seriesOpt.Ordered = true
+			seriesOpt.GroupBy = dims
+			input, err := buildExprIterator(ctx, expr.Args[0].(*influxql.VarRef), b.ic, b.sources, seriesOpt, false, false)
+			if err != nil {
+				return nil, err
+			}
+			rate, err := newRateIterator(input, seriesOpt, func() *RateReducer {
+				switch expr.Name {
+				case "rate":
+					return NewRateReducer(seriesOpt.RateInterval(), seriesOpt)
+				case "irate":
+					return NewInstantRateReducer(seriesOpt.RateInterval(), seriesOpt)
+				default:
+					return NewIncreaseReducer(seriesOpt)
+				}
+			})
+			if err != nil {
+				input.Close()
+				return nil, err
+			}
+			itr, err := newSumIterator(rate, opt)
+			if err != nil {
+				rate.Close()
+				return nil, err
+			}
+			return itr, nil
 		case "mode":
 			input, err := buildExprIterator(ctx, expr.Args[0].(*influxql.VarRef), b.ic, b.sources, opt, false, false)
 			if err != nil {
@@ -401,6 +401,121 @@ func TestSelect(t *testing.T) {
 			itrs: []query.Iterator{&BooleanIterator{}},
 			err:  `unsupported median iterator type: *query_test.BooleanIterator`,
 		},
+		{
+			name: "Quantile_Float",
+			q:    `SELECT quantile(value, 0.5) FROM cpu WHERE time >= '1970-01-01T00:00:00Z' AND time < '1970-01-02T00:00:00Z' GROUP BY time(10s), host fill(none)`,
+			typ:  influxql.Float,
+			expr: `quantile(value::float, 0.5)`,
+			itrs: []query.Iterator{
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 0 * Second, Value: 20},
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 11 * Second, Value: 3},
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 31 * Second, Value: 100},
+				}},
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 5 * Second, Value: 10},
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 50 * Second, Value: 1},
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 51 * Second, Value: 2},
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 52 * Second, Value: 3},
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 53 * Second, Value: 4},
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 53 * Second, Value: 5},
+				}},
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 9 * Second, Value: 19},
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 10 * Second, Value: 2},
+				}},
+			},
+			rows: []query.Row{
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=A")}, Values: []interface{}{19.5}},
+				{Time: 10 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=A")}, Values: []interface{}{2.5}},
+				{Time: 30 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=A")}, Values: []interface{}{float64(100)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=B")}, Values: []interface{}{float64(10)}},
+				{Time: 50 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=B")}, Values: []interface{}{float64(3)}},
+			},
+		},
+		{
+			name: "Quantile_Integer",
+			q:    `SELECT quantile(value, 1) FROM cpu WHERE time >= '1970-01-01T00:00:00Z' AND time < '1970-01-02T00:00:00Z' GROUP BY time(10s), host fill(none)`,
+			typ:  influxql.Integer,
+			itrs: []query.Iterator{
+				&IntegerIterator{Points: []query.IntegerPoint{
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 0 * Second, Value: 20},
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 11 * Second, Value: 3},
+				}},
+				&IntegerIterator{Points: []query.IntegerPoint{
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 9 * Second, Value: 19},
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 10 * Second, Value: 2},
+				}},
+			},
+			rows: []query.Row{
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=A")}, Values: []interface{}{float64(20)}},
+				{Time: 10 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=A")}, Values: []interface{}{float64(3)}},
+			},
+		},
+		{
+			name: "Quantile_String",
+			q:    `SELECT quantile(value, 0.5) FROM cpu WHERE time >= '1970-01-01T00:00:00Z' AND time < '1970-01-02T00:00:00Z' GROUP BY time(10s), host fill(none)`,
+			typ:  influxql.String,
+			itrs: []query.Iterator{&StringIterator{}},
+			err:  `unsupported quantile iterator type: *query_test.StringIterator`,
+		},
+		{
+			name: "Histogram_Float",
+			q:    `SELECT histogram(value, 0, 30, 3) FROM cpu WHERE time >= '1970-01-01T00:00:00Z' AND time < '1970-01-02T00:00:00Z' GROUP BY time(10s), host fill(none)`,
+			typ:  influxql.Float,
+			expr: `histogram(value::float, 0, 30, 3)`,
+			itrs: []query.Iterator{
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 0 * Second, Value: 20},
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 11 * Second, Value: 3},
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 31 * Second, Value: 100},
+				}},
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 5 * Second, Value: 10},
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 50 * Second, Value: 1},
+					{Name: "cpu", Tags: ParseTags("region=west,host=B"), Time: 51 * Second, Value: 30},
+				}},
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 9 * Second, Value: 19},
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 10 * Second, Value: 2},
+				}},
+			},
+			rows: []query.Row{
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=0,host=A")}, Values: []interface{}{int64(0)}},
+				{Time: 10 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=0,host=A")}, Values: []interface{}{int64(2)}},
+				{Time: 30 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=0,host=A")}, Values: []interface{}{int64(0)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=10,host=A")}, Values: []interface{}{int64(1)}},
+				{Time: 10 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=10,host=A")}, Values: []interface{}{int64(0)}},
+				{Time: 30 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=10,host=A")}, Values: []interface{}{int64(0)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=20,host=A")}, Values: []interface{}{int64(1)}},
+				{Time: 10 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=20,host=A")}, Values: []interface{}{int64(0)}},
+				{Time: 30 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=20,host=A")}, Values: []interface{}{int64(0)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=0,host=B")}, Values: []interface{}{int64(0)}},
+				{Time: 50 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=0,host=B")}, Values: []interface{}{int64(1)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=10,host=B")}, Values: []interface{}{int64(1)}},
+				{Time: 50 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=10,host=B")}, Values: []interface{}{int64(0)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=20,host=B")}, Values: []interface{}{int64(0)}},
+				{Time: 50 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=20,host=B")}, Values: []interface{}{int64(1)}},
+			},
+		},
+		{
+			name: "Histogram_Unsigned",
+			q:    `SELECT histogram(value, 0, 10, 2) FROM cpu WHERE time >= '1970-01-01T00:00:00Z' AND time < '1970-01-02T00:00:00Z'`,
+			typ:  influxql.Unsigned,
+			itrs: []query.Iterator{
+				&UnsignedIterator{Points: []query.UnsignedPoint{
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 0 * Second, Value: 2},
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 11 * Second, Value: 7},
+				}},
+				&UnsignedIterator{Points: []query.UnsignedPoint{
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 9 * Second, Value: 4},
+				}},
+			},
+			rows: []query.Row{
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=0")}, Values: []interface{}{int64(2)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("bucket=5")}, Values: []interface{}{int64(1)}},
+			},
+		},
 		{
 			name: "Mode_Float",
 			q:    `SELECT mode(value) FROM cpu WHERE time >= '1970-01-01T00:00:00Z' AND time < '1970-01-02T00:00:00Z' GROUP BY time(10s), host fill(none)`,
@@ -2603,6 +2718,24 @@ func TestSelect(t *testing.T) {
 				{Time: 4 * Second, Series: query.Series{Name: "cpu"}, Values: []interface{}{uint64(52)}},
 			},
 		},
+		{
+			name: "Increase_Series",
+			q:    `SELECT increase(value) FROM cpu WHERE time >= '1970-01-01T00:00:00Z' AND time < '1970-01-01T00:00:20Z' GROUP BY time(10s), host fill(none)`,
+			typ:  influxql.Float,
+			itrs: []query.Iterator{
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 1 * Second, Value: 100},
+					{Name: "cpu", Tags: ParseTags("region=east,host=A"), Time: 6 * Second, Value: 110},
+				}},
+				&FloatIterator{Points: []query.FloatPoint{
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 0 * Second, Value: 10},
+					{Name: "cpu", Tags: ParseTags("region=west,host=A"), Time: 5 * Second, Value: 20},
+				}},
+			},
+			rows: []query.Row{
+				{Time: 0 * Second, Series: query.Series{Name: "cpu", Tags: ParseTags("host=A")}, Values: []interface{}{float64(40)}},
+			},
+		},
 		{
 			name: "HoltWinters_GroupBy_Agg",
 			q:    `SELECT holt_winters(mean(value), 2, 2) FROM cpu WHERE time >= '1970-01-01T00:00:10Z' AND time < '1970-01-01T00:00:20Z' GROUP BY time(2s)`,
@@ -3807,6 +3940,87 @@ func TestSelect_BinaryExpr_NilValues(t *testing.T) {
 	}
 }
 
+// Ensure fields from different measurements can be joined on time and tags.
+func TestSelect_Join(t *testing.T) {
+	shardMapper := ShardMapper{
+		MapShardsFn: func(sources influxql.Sources, _ influxql.TimeRange) query.ShardGroup {
+			return &ShardGroup{
+				Fields: map[string]influxql.DataType{
+					"usage": influxql.Float,
+					"cores": influxql.Integer,
+				},
+				Dimensions: []string{"host"},
+				CreateIteratorFn: func(ctx context.Context, m *influxql.Measurement, opt query.IteratorOptions) (query.Iterator, error) {
+					switch m.Name {
+					case "cpu":
+						if !reflect.DeepEqual(opt.Aux, []influxql.VarRef{{Val: "usage", Type: influxql.Float}}) {
+							t.Fatalf("unexpected auxiliary fields: %v", opt.Aux)
+						}
+						return &FloatIterator{Points: []query.FloatPoint{
+							{Name: "cpu", Tags: ParseTags("host=A"), Time: 0 * Second, Aux: []interface{}{float64(4)}},
+							{Name: "cpu", Tags: ParseTags("host=A"), Time: 10 * Second, Aux: []interface{}{float64(6)}},
+							{Name: "cpu", Tags: ParseTags("host=B"), Time: 0 * Second, Aux: []interface{}{float64(3)}},
+						}}, nil
+					case "system":
+						if !reflect.DeepEqual(opt.Aux, []influxql.VarRef{{Val: "cores", Type: influxql.Integer}}) {
+							t.Fatalf("unexpected auxiliary fields: %v", opt.Aux)
+						}
+						return &FloatIterator{Points: []query.FloatPoint{
+							{Name: "system", Tags: ParseTags("host=A"), Time: 0 * Second, Aux: []interface{}{int64(2)}},
+							{Name: "system", Tags: ParseTags("host=B"), Time: 0 * Second, Aux: []interface{}{int64(4)}},
+							{Name: "system", Tags: ParseTags("host=B"), Time: 10 * Second, Aux: []interface{}{int64(4)}},
+						}}, nil
+					default:
+						t.Fatalf("unexpected source: %s", m.Name)
+						return nil, nil
+					}
+				},
+			}
+		},
+	}
+
+	for _, test := range []struct {
+		Name      string
+		Statement string
+		Join      query.JoinType
+		Rows      []query.Row
+	}{
+		{
+			Name:      "Outer",
+			Statement: `SELECT cpu.usage / system.cores FROM cpu, system GROUP BY host`,
+			Join:      query.OuterJoin,
+			Rows: []query.Row{
+				{Time: 0 * Second, Series: query.Series{Name: "cpu,system", Tags: ParseTags("host=A")}, Values: []interface{}{float64(2)}},
+				{Time: 10 * Second, Series: query.Series{Name: "cpu,system", Tags: ParseTags("host=A")}, Values: []interface{}{nil}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu,system", Tags: ParseTags("host=B")}, Values: []interface{}{float64(0.75)}},
+				{Time: 10 * Second, Series: query.Series{Name: "cpu,system", Tags: ParseTags("host=B")}, Values: []interface{}{nil}},
+			},
+		},
+		{
+			Name:      "Inner",
+			Statement: `SELECT cpu.usage, system.cores FROM cpu, system GROUP BY host`,
+			Join:      query.InnerJoin,
+			Rows: []query.Row{
+				{Time: 0 * Second, Series: query.Series{Name: "cpu,system", Tags: ParseTags("host=A")}, Values: []interface{}{float64(4), int64(2)}},
+				{Time: 0 * Second, Series: query.Series{Name: "cpu,system", Tags: ParseTags("host=B")}, Values: []interface{}{float64(3), int64(4)}},
+			},
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			stmt := MustParseSelectStatement(test.Statement)
+			stmt.OmitTime = true
+			cur, err := query.Select(context.Background(), stmt, &shardMapper, query.SelectOptions{Join: test.Join})
+			if err != nil {
+				t.Fatalf("%s: parse error: %s", test.Name, err)
+			} else if a, err := ReadCursor(cur); err != nil {
+				t.Fatalf("%s: unexpected error: %s", test.Name, err)
+			} else if diff := cmp.Diff(a, test.Rows); diff != "" {
+				t.Errorf("%s: unexpected points:\n%s", test.Name, diff)
+			}
+		})
+	}
+}
+
 type ShardMapper struct {
 	MapShardsFn func(sources influxql.Sources, t influxql.TimeRange) query.ShardGroup
 }
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/influxdata/influxql"
+)
+
+func init() {
+	// Extend the SHOW SERIES handler of the parser with SHOW SERIES LIMITS,
+	// as influxql only lets a token have either a handler or a subtree.
+	show := influxql.Language.Group(influxql.SHOW)
+	showSeries := show.Handlers[influxql.SERIES]
+	show.Handlers[influxql.SERIES] = func(p *influxql.Parser) (influxql.Statement, error) {
+		if tok, _, lit := p.ScanIgnoreWhitespace(); tok == influxql.IDENT && strings.EqualFold(lit, "limits") {
+			return parseShowSeriesLimitsStatement(p)
+		}
+		p.Unscan()
+		return showSeries(p)
+	}
+}
+
+// statement lets ShowSeriesLimitsStatement implement influxql.Statement,
+// whose marker methods are unexported.
+type statement = influxql.Statement
+
+// ShowSeriesLimitsStatement represents a command for listing the series
+// limits of a database and the estimated number of series they count.
+type ShowSeriesLimitsStatement struct {
+	statement
+
+	// Database to query. If blank, use the default database.
+	Database string
+}
+
+// parseShowSeriesLimitsStatement parses a string and returns a
+// ShowSeriesLimitsStatement.  This function assumes the "SHOW SERIES LIMITS"
+// tokens have already been consumed.
+func parseShowSeriesLimitsStatement(p *influxql.Parser) (*ShowSeriesLimitsStatement, error) {
+	stmt := &ShowSeriesLimitsStatement{}
+
+	// Parse optional ON clause.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == influxql.ON {
+		var err error
+		if stmt.Database, err = p.ParseIdent(); err != nil {
+			return nil, err
+		}
+	} else {
+		p.Unscan()
+	}
+	return stmt, nil
+}
+
+// String returns a string representation of the statement.
+func (s *ShowSeriesLimitsStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW SERIES LIMITS")
+
+	if s.Database != "" {
+		_, _ = buf.WriteString(" ON ")
+		_, _ = buf.WriteString(influxql.QuoteIdent(s.Database))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute the statement.
+func (s *ShowSeriesLimitsStatement) RequiredPrivileges() (influxql.ExecutionPrivileges, error) {
+	return influxql.ExecutionPrivileges{{Admin: false, Name: s.Database, Privilege: influxql.ReadPrivilege}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *ShowSeriesLimitsStatement) DefaultDatabase() string {
+	return s.Database
+}
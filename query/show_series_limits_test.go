@@ -0,0 +1,53 @@
+package query_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxql"
+)
+
+func TestParseShowSeriesLimitsStatement(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		stmt influxql.Statement
+		str  string
+	}{
+		{
+			s:    `SHOW SERIES LIMITS`,
+			stmt: &query.ShowSeriesLimitsStatement{},
+			str:  `SHOW SERIES LIMITS`,
+		},
+		{
+			s:    `show series limits on db0`,
+			stmt: &query.ShowSeriesLimitsStatement{Database: "db0"},
+			str:  `SHOW SERIES LIMITS ON db0`,
+		},
+		{
+			s:    `SHOW SERIES ON db0 FROM cpu`,
+			stmt: &influxql.ShowSeriesStatement{Database: "db0", Sources: []influxql.Source{&influxql.Measurement{Name: "cpu"}}},
+			str:  `SHOW SERIES ON db0 FROM cpu`,
+		},
+		{
+			s:    `SHOW SERIES CARDINALITY ON db0`,
+			stmt: &influxql.ShowSeriesCardinalityStatement{Database: "db0"},
+			str:  `SHOW SERIES CARDINALITY ON db0`,
+		},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			stmt, err := influxql.ParseStatement(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			} else if !reflect.DeepEqual(stmt, tt.stmt) {
+				t.Fatalf("unexpected statement:\n\tgot=%#v\n\texp=%#v", stmt, tt.stmt)
+			} else if got := stmt.String(); got != tt.str {
+				t.Fatalf("unexpected string: got=%q exp=%q", got, tt.str)
+			}
+		})
+	}
+
+	if _, err := influxql.ParseStatement(`SHOW SERIES LIMITS ON`); err == nil {
+		t.Fatal("expected error for missing database")
+	}
+}
@@ -124,7 +124,7 @@ func (t *TaskManager) executeShowQueriesStatement(q *influxql.ShowQueriesStateme
 			d = d - (d % time.Microsecond)
 		}
 
-		values = append(values, []interface{}{id, qi.query, qi.database, d.String(), qi.status.String()})
+		values = append(values, []interface{}{id, qi.String(), qi.database, d.String(), qi.status.String()})
 	}
 
 	return []*models.Row{{
@@ -172,7 +172,7 @@ func (t *TaskManager) AttachQuery(q *influxql.Query, opt ExecutionOptions, inter
 	}
 	t.queries[qid] = query
 
-	go t.waitForQuery(qid, query.closing, interrupt, query.monitorCh)
+	go t.waitForQuery(qid, query.closing, interrupt, query.monitorCh, t.QueryTimeout)
 	if t.LogQueriesAfter != 0 {
 		go query.monitor(func(closing <-chan struct{}) error {
 			timer := time.NewTimer(t.LogQueriesAfter)
@@ -199,6 +199,44 @@ func (t *TaskManager) AttachQuery(q *influxql.Query, opt ExecutionOptions, inter
 	return ctx, func() { t.DetachQuery(qid) }, nil
 }
 
+// AttachTask attaches a background task, such as an index rebuild, to be
+// managed by the TaskManager so it is listed by SHOW QUERIES and can be
+// killed with KILL QUERY.  The task is described by description, followed by
+// its progress once set on the returned context.  Unlike queries, tasks are
+// not limited by the query timeout or the maximum number of concurrent
+// queries.  The returned function detaches the task once it is done.
+func (t *TaskManager) AttachTask(description, database string) (*ExecutionContext, func(), error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.shutdown {
+		return nil, nil, ErrQueryEngineShutdown
+	}
+
+	qid := t.nextID
+	task := &Task{
+		query:     description,
+		database:  database,
+		status:    RunningTask,
+		startTime: time.Now(),
+		closing:   make(chan struct{}),
+		monitorCh: make(chan error),
+	}
+	t.queries[qid] = task
+
+	go t.waitForQuery(qid, task.closing, nil, task.monitorCh, 0)
+	t.nextID++
+
+	ctx := &ExecutionContext{
+		Context:          context.Background(),
+		QueryID:          qid,
+		task:             task,
+		ExecutionOptions: ExecutionOptions{Database: database},
+	}
+	ctx.watch()
+	return ctx, func() { t.DetachQuery(qid) }, nil
+}
+
 // KillQuery enters a query into the killed state and closes the channel
 // from the TaskManager. This method can be used to forcefully terminate a
 // running query.
@@ -247,7 +285,7 @@ func (t *TaskManager) Queries() []QueryInfo {
 	for id, qi := range t.queries {
 		queries = append(queries, QueryInfo{
 			ID:       id,
-			Query:    qi.query,
+			Query:    qi.String(),
 			Database: qi.database,
 			Duration: now.Sub(qi.startTime),
 		})
@@ -255,10 +293,10 @@ func (t *TaskManager) Queries() []QueryInfo {
 	return queries
 }
 
-func (t *TaskManager) waitForQuery(qid uint64, interrupt <-chan struct{}, closing <-chan struct{}, monitorCh <-chan error) {
+func (t *TaskManager) waitForQuery(qid uint64, interrupt <-chan struct{}, closing <-chan struct{}, monitorCh <-chan error, timeout time.Duration) {
 	var timerCh <-chan time.Time
-	if t.QueryTimeout != 0 {
-		timer := time.NewTimer(t.QueryTimeout)
+	if timeout != 0 {
+		timer := time.NewTimer(timeout)
 		timerCh = timer.C
 		defer timer.Stop()
 	}
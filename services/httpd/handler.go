@@ -32,6 +32,7 @@ import (
 	"github.com/influxdata/influxdb/prometheus/remote"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/uuid"
 	"github.com/influxdata/influxql"
@@ -106,6 +107,11 @@ type Handler struct {
 
 	PointsWriter interface {
 		WritePoints(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, user meta.User, points []models.Point) error
+		BulkLoadPoints(database, retentionPolicy string, user meta.User, points []models.Point) error
+	}
+
+	Compactions interface {
+		SetCompactionThroughput(read, write int)
 	}
 
 	Config    *Config
@@ -177,6 +183,10 @@ func NewHandler(c Config) *Handler {
 			"prometheus-metrics",
 			"GET", "/metrics", false, true, promhttp.Handler().ServeHTTP,
 		},
+		Route{
+			"compaction-throughput",
+			"POST", "/debug/compaction-throughput", false, true, h.serveCompactionThroughput,
+		},
 	}...)
 
 	return h
@@ -441,11 +451,20 @@ func (h *Handler) serveQuery(w http.ResponseWriter, r *http.Request, user meta.U
 	// Parse whether this is an async command.
 	async := r.FormValue("async") == "true"
 
+	// Parse the type of join of each statement selecting from more than one
+	// measurement.
+	joins, err := query.ParseJoinTypes(r.FormValue("join"), len(q.Statements))
+	if err != nil {
+		h.httpError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	opts := query.ExecutionOptions{
 		Database:  db,
 		ChunkSize: chunkSize,
 		ReadOnly:  r.Method == "GET",
 		NodeID:    nodeID,
+		Joins:     joins,
 	}
 
 	if h.Config.AuthEnabled {
@@ -739,8 +758,13 @@ func (h *Handler) serveWrite(w http.ResponseWriter, r *http.Request, user meta.U
 		}
 	}
 
-	// Write points.
-	if err := h.PointsWriter.WritePoints(database, r.URL.Query().Get("rp"), consistency, user, points); influxdb.IsClientError(err) {
+	// Write points.  Bulk loads write sorted points directly to new TSM files.
+	if r.URL.Query().Get("bulk") == "true" {
+		err = h.PointsWriter.BulkLoadPoints(database, r.URL.Query().Get("rp"), user, points)
+	} else {
+		err = h.PointsWriter.WritePoints(database, r.URL.Query().Get("rp"), consistency, user, points)
+	}
+	if influxdb.IsClientError(err) {
 		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
 		h.httpError(w, err.Error(), http.StatusBadRequest)
 		return
@@ -1183,6 +1207,33 @@ func (h *Handler) serveExpvar(w http.ResponseWriter, r *http.Request) {
 }
 
 // serveDebugRequests will track requests for a period of time.
+// serveCompactionThroughput changes the read and write throughput limits of
+// compactions to the sizes in bytes per second of the read and write
+// parameters.  A limit of 0 disables it.
+func (h *Handler) serveCompactionThroughput(w http.ResponseWriter, r *http.Request, user meta.User) {
+	if h.Config.AuthEnabled {
+		if u, ok := user.(*meta.UserInfo); !ok || !u.Admin {
+			h.httpError(w, "admin privilege required to change the compaction throughput", http.StatusForbidden)
+			return
+		}
+	}
+
+	var read, write toml.Size
+	if err := read.UnmarshalText([]byte(r.FormValue("read"))); err != nil {
+		h.httpError(w, "invalid read throughput: "+err.Error(), http.StatusBadRequest)
+		return
+	} else if err := write.UnmarshalText([]byte(r.FormValue("write"))); err != nil {
+		h.httpError(w, "invalid write throughput: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.Compactions.SetCompactionThroughput(int(read), int(write))
+	h.Logger.Info("Compaction throughput limits changed",
+		zap.Int("read_bytes_per_second", int(read)),
+		zap.Int("write_bytes_per_second", int(write)))
+	h.writeHeader(w, http.StatusNoContent)
+}
+
 func (h *Handler) serveDebugRequests(w http.ResponseWriter, r *http.Request) {
 	var d time.Duration
 	if s := r.URL.Query().Get("seconds"); s == "" {
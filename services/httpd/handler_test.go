@@ -311,6 +311,33 @@ func TestHandler_Query_Chunked(t *testing.T) {
 	}
 }
 
+// Ensure the handler passes the join type of each statement to the executor.
+func TestHandler_Query_Join(t *testing.T) {
+	var joins []query.JoinType
+	h := NewHandler(false)
+	h.StatementExecutor.ExecuteStatementFn = func(stmt influxql.Statement, ctx *query.ExecutionContext) error {
+		joins = append(joins, ctx.Join)
+		return ctx.Send(&query.Result{Series: models.Rows{}})
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewJSONRequest("GET", "/query?db=foo&q=SELECT+*+FROM+bar%3BSELECT+*+FROM+baz%3BSELECT+*+FROM+qux&join=inner,,outer", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if exp := []query.JoinType{query.InnerJoin, query.NoJoin, query.OuterJoin}; !reflect.DeepEqual(joins, exp) {
+		t.Fatalf("unexpected joins: %v", joins)
+	}
+
+	// A join type is required for each statement.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewJSONRequest("GET", "/query?db=foo&q=SELECT+*+FROM+bar%3BSELECT+*+FROM+baz&join=inner", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if body := strings.TrimSpace(w.Body.String()); body != `{"error":"expected a join type for each of the 2 statements, got 1"}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
 // Ensure the handler can accept an async query.
 func TestHandler_Query_Async(t *testing.T) {
 	done := make(chan struct{})
@@ -662,6 +689,47 @@ func TestHandler_PromRead(t *testing.T) {
 
 // Ensure the handler handles ping requests correctly.
 // TODO: This should be expanded to verify the MetaClient check in servePing is working correctly
+// Ensure the handler changes the compaction throughput for admin users.
+func TestHandler_CompactionThroughput(t *testing.T) {
+	h := NewHandler(true)
+	h.MetaClient.AdminUserExistsFn = func() bool { return true }
+	h.MetaClient.AuthenticateFn = func(u, p string) (meta.User, error) {
+		switch u {
+		case "admin":
+			return &meta.UserInfo{Name: "admin", Admin: true}, nil
+		case "user1":
+			return &meta.UserInfo{Name: "user1"}, nil
+		}
+		return nil, meta.ErrUserNotFound
+	}
+
+	var read, write int
+	h.Handler.Compactions = CompactionsFunc(func(r, w int) {
+		read, write = r, w
+	})
+
+	for i, tt := range []struct {
+		user string
+		url  string
+		code int
+	}{
+		{user: "user1", url: "/debug/compaction-throughput?read=0&write=1m", code: http.StatusForbidden},
+		{user: "admin", url: "/debug/compaction-throughput?read=0", code: http.StatusBadRequest},
+		{user: "admin", url: "/debug/compaction-throughput?read=0&write=1m", code: http.StatusNoContent},
+	} {
+		w := httptest.NewRecorder()
+		r := MustNewRequest("POST", tt.url, nil)
+		r.SetBasicAuth(tt.user, "")
+		h.ServeHTTP(w, r)
+		if w.Code != tt.code {
+			t.Errorf("%d. unexpected status: got=%d exp=%d\noutput: %s", i, w.Code, tt.code, w.Body.String())
+		}
+	}
+	if read != 0 || write != 1<<20 {
+		t.Fatalf("unexpected throughput: read=%d write=%d", read, write)
+	}
+}
+
 func TestHandler_Ping(t *testing.T) {
 	h := NewHandler(false)
 	w := httptest.NewRecorder()
@@ -817,6 +885,36 @@ func TestHandler_Write_NegativeMaxBodySize(t *testing.T) {
 	}
 }
 
+// Ensure bulk writes are sent to the points writer as bulk loads.
+func TestHandler_Write_Bulk(t *testing.T) {
+	b := bytes.NewReader([]byte("cpu value=2 2\ncpu value=1 1"))
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	h.PointsWriter.WritePointsFn = func(_, _ string, _ models.ConsistencyLevel, _ meta.User, _ []models.Point) error {
+		t.Fatal("WritePoints: unexpected call")
+		return nil
+	}
+	var n int
+	h.PointsWriter.BulkLoadPointsFn = func(db, rp string, _ meta.User, points []models.Point) error {
+		if db != "foo" || rp != "bar" {
+			t.Fatalf("unexpected target: %s.%s", db, rp)
+		}
+		n = len(points)
+		return nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/write?db=foo&rp=bar&bulk=true", b))
+	if n != 2 {
+		t.Fatalf("BulkLoadPoints: expected 2 points, got %d", n)
+	}
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d", w.Code)
+	}
+}
+
 // Ensure X-Forwarded-For header writes the correct log message.
 func TestHandler_XForwardedFor(t *testing.T) {
 	var buf bytes.Buffer
@@ -931,6 +1029,11 @@ func NewHandler(requireAuthentication bool) *Handler {
 	return h
 }
 
+// CompactionsFunc is a mock implementation of Handler.Compactions.
+type CompactionsFunc func(read, write int)
+
+func (fn CompactionsFunc) SetCompactionThroughput(read, write int) { fn(read, write) }
+
 // HandlerStatementExecutor is a mock implementation of Handler.StatementExecutor.
 type HandlerStatementExecutor struct {
 	ExecuteStatementFn func(stmt influxql.Statement, ctx *query.ExecutionContext) error
@@ -950,13 +1053,18 @@ func (a *HandlerQueryAuthorizer) AuthorizeQuery(u meta.User, query *influxql.Que
 }
 
 type HandlerPointsWriter struct {
-	WritePointsFn func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, user meta.User, points []models.Point) error
+	WritePointsFn    func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, user meta.User, points []models.Point) error
+	BulkLoadPointsFn func(database, retentionPolicy string, user meta.User, points []models.Point) error
 }
 
 func (h *HandlerPointsWriter) WritePoints(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, user meta.User, points []models.Point) error {
 	return h.WritePointsFn(database, retentionPolicy, consistencyLevel, user, points)
 }
 
+func (h *HandlerPointsWriter) BulkLoadPoints(database, retentionPolicy string, user meta.User, points []models.Point) error {
+	return h.BulkLoadPointsFn(database, retentionPolicy, user, points)
+}
+
 // MustNewRequest returns a new HTTP request. Panic on error.
 func MustNewRequest(method, urlStr string, body io.Reader) *http.Request {
 	r, err := http.NewRequest(method, urlStr, body)
@@ -20,12 +20,17 @@ type Service struct {
 	TSDBStore interface {
 		ShardIDs() []uint64
 		DeleteShard(shardID uint64) error
+		TierShardGroup(shardIDs []uint64, endTime time.Time, cancel <-chan struct{}) ([]uint64, error)
 	}
 
 	config Config
 	wg     sync.WaitGroup
 	done   chan struct{}
 
+	// tierC passes the shard groups to move to cold storage to the goroutine
+	// moving them, so that moves do not delay retention policy enforcement.
+	tierC chan []tierGroup
+
 	logger *zap.Logger
 }
 
@@ -46,9 +51,11 @@ func (s *Service) Open() error {
 	s.logger.Info("Starting retention policy enforcement service",
 		logger.DurationLiteral("check_interval", time.Duration(s.config.CheckInterval)))
 	s.done = make(chan struct{})
+	s.tierC = make(chan []tierGroup)
 
-	s.wg.Add(1)
+	s.wg.Add(2)
 	go func() { defer s.wg.Done(); s.run() }()
+	go func() { defer s.wg.Done(); s.runTiering() }()
 	return nil
 }
 
@@ -139,6 +146,46 @@ func (s *Service) run() {
 				}
 			}
 
+			// Move the remaining shards of shard groups that have ended to the
+			// cold directory once they are old enough and fully compacted.
+			localShardIDs := make(map[uint64]struct{})
+			for _, id := range s.TSDBStore.ShardIDs() {
+				localShardIDs[id] = struct{}{}
+			}
+			var tier []tierGroup
+			now := time.Now().UTC()
+			for _, d := range dbs {
+				for _, r := range d.RetentionPolicies {
+					for _, g := range r.ShardGroups {
+						if g.Deleted() || g.EndTime.After(now) {
+							continue
+						}
+
+						var shardIDs []uint64
+						for _, sh := range g.Shards {
+							if _, ok := localShardIDs[sh.ID]; !ok {
+								continue
+							} else if _, ok := deletedShardIDs[sh.ID]; ok {
+								continue
+							}
+							shardIDs = append(shardIDs, sh.ID)
+						}
+						if len(shardIDs) > 0 {
+							tier = append(tier, tierGroup{db: d.Name, rp: r.Name, id: g.ID, shardIDs: shardIDs, endTime: g.EndTime})
+						}
+					}
+				}
+			}
+
+			// Shards still being moved from a previous check are retried on
+			// the next check instead of blocking this one.
+			if len(tier) > 0 {
+				select {
+				case s.tierC <- tier:
+				default:
+				}
+			}
+
 			if err := s.MetaClient.PruneShardGroups(); err != nil {
 				log.Info("Problem pruning shard groups", zap.Error(err))
 				retryNeeded = true
@@ -152,3 +199,48 @@ func (s *Service) run() {
 		}
 	}
 }
+
+// tierGroup is a shard group whose local shards are moved to cold storage
+// once it is old enough.
+type tierGroup struct {
+	db, rp   string
+	id       uint64
+	shardIDs []uint64
+	endTime  time.Time
+}
+
+// runTiering moves the shard groups received from the retention policy check
+// to cold storage, one at a time.
+func (s *Service) runTiering() {
+	for {
+		select {
+		case <-s.done:
+			return
+
+		case groups := <-s.tierC:
+			for _, g := range groups {
+				select {
+				case <-s.done:
+					return
+				default:
+				}
+
+				moved, err := s.TSDBStore.TierShardGroup(g.shardIDs, g.endTime, s.done)
+				for _, id := range moved {
+					s.logger.Info("Moved shard to cold storage",
+						logger.Database(g.db),
+						logger.ShardGroup(g.id),
+						logger.Shard(id),
+						logger.RetentionPolicy(g.rp))
+				}
+				if err != nil {
+					s.logger.Info("Failed to move shard group to cold storage",
+						logger.Database(g.db),
+						logger.ShardGroup(g.id),
+						logger.RetentionPolicy(g.rp),
+						zap.Error(err))
+				}
+			}
+		}
+	}
+}
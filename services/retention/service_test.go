@@ -170,6 +170,21 @@ func TestService_CheckShards(t *testing.T) {
 		return nil
 	}
 
+	var tierMu sync.Mutex
+	tieredGroups := make(map[time.Time][]uint64)
+	tiered := make(chan struct{})
+	s.TSDBStore.TierShardGroupFn = func(shardIDs []uint64, endTime time.Time, cancel <-chan struct{}) ([]uint64, error) {
+		tierMu.Lock()
+		defer tierMu.Unlock()
+		tieredGroups[endTime] = shardIDs
+		select {
+		case <-tiered:
+		default:
+			close(tiered)
+		}
+		return nil, nil
+	}
+
 	if err := s.Open(); err != nil {
 		t.Fatalf("unexpected open error: %s", err)
 	}
@@ -203,6 +218,25 @@ func TestService_CheckShards(t *testing.T) {
 	}; !reflect.DeepEqual(got, want) {
 		t.Errorf("unexpected deleted shards: got=%#v want=%#v", got, want)
 	}
+
+	// Only the local shards of shard groups that have ended are moved to cold
+	// storage, together.
+	timer = time.NewTimer(100 * time.Millisecond)
+	select {
+	case <-tiered:
+		timer.Stop()
+	case <-timer.C:
+		t.Errorf("timeout waiting for shards to be moved")
+		return
+	}
+
+	tierMu.Lock()
+	defer tierMu.Unlock()
+	if got, want := tieredGroups, map[time.Time][]uint64{
+		now.Truncate(time.Hour): {5, 6},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected tiered shard groups: got=%#v want=%#v", got, want)
+	}
 }
 
 // This reproduces https://github.com/influxdata/influxdb/issues/8819
@@ -383,5 +417,10 @@ func NewService(c retention.Config) *Service {
 
 	s.Service.MetaClient = s.MetaClient
 	s.Service.TSDBStore = s.TSDBStore
+
+	// Shards are not moved to cold storage unless a test expects them to be.
+	s.TSDBStore.TierShardGroupFn = func(shardIDs []uint64, endTime time.Time, cancel <-chan struct{}) ([]uint64, error) {
+		return nil, nil
+	}
 	return s
 }
@@ -156,6 +156,70 @@ func (c *Client) UploadShard(shardID, newShardID uint64, destinationDatabase, re
 	return nil
 }
 
+// IngestTSMFile uploads the TSM file of size bytes read from r, whose values
+// are between minTime and maxTime.  The server validates the file and adds it
+// to the shard of the retention policy of the database covering the time
+// range, the default retention policy if retentionPolicy is empty.  It returns
+// the relative path of the shard.
+func (c *Client) IngestTSMFile(database, retentionPolicy string, r io.Reader, size, minTime, maxTime int64) (string, error) {
+	conn, err := tcp.Dial("tcp", c.host, MuxHeader)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	req := &Request{
+		Type:                   RequestTSMIngest,
+		RestoreDatabase:        database,
+		RestoreRetentionPolicy: retentionPolicy,
+		UploadSize:             size,
+		UploadMinTime:          minTime,
+		UploadMaxTime:          maxTime,
+	}
+	if _, err := conn.Write([]byte{byte(req.Type)}); err != nil {
+		return "", err
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return "", fmt.Errorf("encode snapshot request: %s", err)
+	}
+
+	if n, err := io.CopyN(conn, r, size); err != nil {
+		return "", fmt.Errorf("error uploading file: err=%v, n=%d, uploadSize: %d", err, n, size)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return "", fmt.Errorf("decode ingest response: %s", err)
+	} else if resp.Error != "" {
+		return "", errors.New(resp.Error)
+	} else if len(resp.Paths) != 1 {
+		return "", fmt.Errorf("unexpected ingest response: %v", resp.Paths)
+	}
+	return resp.Paths[0], nil
+}
+
+// RebuildIndex starts rebuilding the index of a shard in the background and
+// returns the query id of the rebuild, which is listed by SHOW QUERIES with
+// its progress and can be killed with KILL QUERY.
+func (c *Client) RebuildIndex(shardID uint64) (uint64, error) {
+	b, err := c.doRequest(&Request{
+		Type:    RequestIndexRebuild,
+		ShardID: shardID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return 0, fmt.Errorf("decode rebuild response: %s", err)
+	} else if resp.Error != "" {
+		return 0, errors.New(resp.Error)
+	}
+	return resp.QueryID, nil
+}
+
 // MetastoreBackup returns a snapshot of the meta store.
 func (c *Client) MetastoreBackup() (*meta.Data, error) {
 	req := &Request{
@@ -9,13 +9,17 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
 	"go.uber.org/zap"
 )
 
@@ -37,6 +41,7 @@ type Service struct {
 	MetaClient interface {
 		encoding.BinaryMarshaler
 		Database(name string) *meta.DatabaseInfo
+		CreateShardGroup(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error)
 	}
 
 	TSDBStore interface {
@@ -44,9 +49,16 @@ type Service struct {
 		ExportShard(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error
 		Shard(id uint64) *tsdb.Shard
 		ShardRelativePath(id uint64) (string, error)
+		Path() string
 		SetShardEnabled(shardID uint64, enabled bool) error
 		RestoreShard(id uint64, r io.Reader) error
 		CreateShard(database, retentionPolicy string, shardID uint64, enabled bool) error
+		IngestTSMFiles(shardID uint64, paths []string, minTime, maxTime int64) error
+		RebuildIndex(shardID uint64, progress func(seriesN int) error) error
+	}
+
+	TaskManager interface {
+		AttachTask(description, database string) (*query.ExecutionContext, func(), error)
 	}
 
 	Listener net.Listener
@@ -123,6 +135,8 @@ func (s *Service) handleConn(conn net.Conn) error {
 
 	if RequestType(typ[0]) == RequestShardUpdate {
 		return s.updateShardsLive(conn)
+	} else if RequestType(typ[0]) == RequestTSMIngest {
+		return s.ingestTSMFile(conn)
 	}
 
 	r, bytes, err := s.readRequest(conn)
@@ -149,6 +163,8 @@ func (s *Service) handleConn(conn net.Conn) error {
 		return s.writeRetentionPolicyInfo(conn, r.BackupDatabase, r.BackupRetentionPolicy)
 	case RequestMetaStoreUpdate:
 		return s.updateMetaStore(conn, bytes, r.BackupDatabase, r.RestoreDatabase, r.BackupRetentionPolicy, r.RestoreRetentionPolicy)
+	case RequestIndexRebuild:
+		return s.rebuildIndex(conn, r.ShardID)
 	default:
 		return fmt.Errorf("request type unknown: %v", r.Type)
 	}
@@ -172,6 +188,144 @@ func (s *Service) updateShardsLive(conn net.Conn) error {
 	return s.TSDBStore.RestoreShard(sid, conn)
 }
 
+// ingestTSMFile reads a request followed by an uploaded TSM file from conn
+// and adds the file to the shard of the restore retention policy covering
+// its time range.  The response holds the relative path of the shard, or the
+// error if the file was rejected.
+func (s *Service) ingestTSMFile(conn net.Conn) error {
+	var r Request
+	d := json.NewDecoder(conn)
+	if err := d.Decode(&r); err != nil {
+		return fmt.Errorf("read request: %s", err)
+	}
+
+	res := Response{}
+	path, err := s.ingestUpload(io.MultiReader(d.Buffered(), conn), r)
+	if err != nil {
+		res.Error = err.Error()
+	} else {
+		res.Paths = append(res.Paths, path)
+	}
+
+	if err := json.NewEncoder(conn).Encode(res); err != nil {
+		return fmt.Errorf("encode response: %s", err.Error())
+	}
+	return err
+}
+
+// ingestUpload receives the uploaded TSM file in the directory of the shard
+// covering the time range of the file, creating the shard group if it does not
+// exist yet, and ingests it into the shard.  It returns the relative path of
+// the shard.
+func (s *Service) ingestUpload(upload io.Reader, r Request) (string, error) {
+	database, policy := r.RestoreDatabase, r.RestoreRetentionPolicy
+	if policy == "" {
+		db := s.MetaClient.Database(database)
+		if db == nil {
+			return "", influxdb.ErrDatabaseNotFound(database)
+		}
+		policy = db.DefaultRetentionPolicy
+	}
+
+	// The JSON encoder of the client writes a newline after the request.
+	var newline [1]byte
+	if _, err := io.ReadFull(upload, newline[:]); err != nil {
+		return "", err
+	}
+
+	if r.UploadMinTime > r.UploadMaxTime {
+		return "", fmt.Errorf("invalid time range of file %d-%d", r.UploadMinTime, r.UploadMaxTime)
+	}
+	sg, err := s.MetaClient.CreateShardGroup(database, policy, time.Unix(0, r.UploadMinTime))
+	if err != nil {
+		return "", err
+	} else if r.UploadMaxTime >= sg.EndTime.UnixNano() {
+		return "", fmt.Errorf("time range of file %d-%d exceeds shard group %d ending at %s", r.UploadMinTime, r.UploadMaxTime, sg.ID, sg.EndTime)
+	} else if len(sg.Shards) == 0 {
+		return "", fmt.Errorf("shard group %d has no shards", sg.ID)
+	}
+
+	shardID := sg.Shards[0].ID
+	if err := s.TSDBStore.CreateShard(database, policy, shardID, true); err != nil {
+		return "", err
+	}
+	relPath, err := s.TSDBStore.ShardRelativePath(shardID)
+	if err != nil {
+		return "", err
+	}
+
+	// Receive the file in the shard directory, so that it is linked into the
+	// shard instead of copied.  The temporary file extension of the engine
+	// ensures the file is removed when the shard is opened after a crash.
+	path := filepath.Join(s.TSDBStore.Path(), relPath, fmt.Sprintf("ingest-%d.%s.%s", time.Now().UnixNano(), tsm1.TSMFileExtension, tsm1.TmpTSMFileExtension))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(path)
+
+	if n, err := io.CopyN(f, upload, r.UploadSize); err != nil {
+		f.Close()
+		return "", fmt.Errorf("error receiving file: err=%v, n=%d, uploadSize: %d", err, n, r.UploadSize)
+	} else if err := f.Sync(); err != nil {
+		f.Close()
+		return "", err
+	} else if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if err := s.TSDBStore.IngestTSMFiles(shardID, []string{path}, sg.StartTime.UnixNano(), sg.EndTime.UnixNano()-1); err != nil {
+		return "", err
+	}
+	return relPath, nil
+}
+
+// rebuildIndex starts rebuilding the index of a shard in the background and
+// responds with the query id of the rebuild task.  The task is listed by
+// SHOW QUERIES with its progress and can be killed with KILL QUERY.
+func (s *Service) rebuildIndex(conn net.Conn, shardID uint64) error {
+	res := Response{}
+	if err := s.startIndexRebuild(shardID, &res); err != nil {
+		res.Error = err.Error()
+	}
+
+	if err := json.NewEncoder(conn).Encode(res); err != nil {
+		return fmt.Errorf("encode response: %s", err.Error())
+	}
+	return nil
+}
+
+func (s *Service) startIndexRebuild(shardID uint64, res *Response) error {
+	sh := s.TSDBStore.Shard(shardID)
+	if sh == nil {
+		return tsdb.ErrShardNotFound
+	}
+
+	ctx, detach, err := s.TaskManager.AttachTask(fmt.Sprintf("REBUILD INDEX ON SHARD %d", shardID), sh.Database())
+	if err != nil {
+		return err
+	}
+	res.QueryID = ctx.QueryID
+
+	go func() {
+		defer detach()
+
+		logger := s.Logger.With(zap.Uint64("shard_id", shardID))
+		if err := s.TSDBStore.RebuildIndex(shardID, func(seriesN int) error {
+			ctx.SetProgress(fmt.Sprintf("%d series indexed", seriesN))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				return nil
+			}
+		}); err != nil {
+			logger.Info("Error rebuilding index", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
 func (s *Service) updateMetaStore(conn net.Conn, bits []byte, backupDBName, restoreDBName, backupRPName, restoreRPName string) error {
 	md := meta.Data{}
 	err := md.UnmarshalBinary(bits)
@@ -440,6 +594,13 @@ const (
 	// RequestShardUpdate will initiate the upload of a shard data tar file
 	// and have the engine import the data.
 	RequestShardUpdate
+
+	// RequestTSMIngest will initiate the upload of an externally produced TSM file
+	// and have the engine of the shard covering its time range ingest the file.
+	RequestTSMIngest
+
+	// RequestIndexRebuild starts rebuilding the index of a shard in the background.
+	RequestIndexRebuild
 )
 
 // Request represents a request for a specific backup or for information
@@ -455,10 +616,16 @@ type Request struct {
 	ExportStart            time.Time
 	ExportEnd              time.Time
 	UploadSize             int64
+	UploadMinTime          int64
+	UploadMaxTime          int64
 }
 
 // Response contains the relative paths for all the shards on this server
-// that are in the requested database or retention policy.
+// that are in the requested database or retention policy.  Error is set
+// if a TSM file ingest or index rebuild request failed.  QueryID is the
+// query id of a started index rebuild.
 type Response struct {
-	Paths []string
+	Paths   []string
+	Error   string `json:",omitempty"`
+	QueryID uint64 `json:",omitempty"`
 }
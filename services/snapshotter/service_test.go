@@ -1,12 +1,14 @@
 package snapshotter_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -378,6 +380,92 @@ func TestSnapshotter_RequestRetentionPolicyInfo(t *testing.T) {
 	}
 }
 
+func TestSnapshotter_RequestTSMIngest(t *testing.T) {
+	s, l, err := NewTestService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	dir, err := ioutil.TempDir("", "snapshotter-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	shardDir := filepath.Join(dir, "db0", "rp0", "2")
+	if err := os.MkdirAll(shardDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	upload := []byte("tsm file data")
+	var ingested bool
+	var store internal.TSDBStoreMock
+	store.PathFn = func() string { return dir }
+	store.CreateShardFn = func(database, policy string, shardID uint64, enabled bool) error {
+		if database != "db0" || policy != "rp0" || shardID != 2 {
+			t.Errorf("unexpected shard: %s.%s %d", database, policy, shardID)
+		}
+		return nil
+	}
+	store.ShardRelativePathFn = func(id uint64) (string, error) {
+		return filepath.Join("db0", "rp0", fmt.Sprint(id)), nil
+	}
+	store.IngestTSMFilesFn = func(shardID uint64, paths []string, minTime, maxTime int64) error {
+		ingested = true
+		if len(paths) != 1 {
+			t.Fatalf("unexpected paths: %v", paths)
+		} else if got, want := filepath.Dir(paths[0]), shardDir; got != want {
+			t.Errorf("file not received in the shard directory: got=%s want=%s", got, want)
+		}
+
+		if buf, err := ioutil.ReadFile(paths[0]); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(buf, upload) {
+			t.Errorf("unexpected file contents: %q", buf)
+		}
+
+		if got, want := minTime, int64(0); got != want {
+			t.Errorf("unexpected min time: got=%d want=%d", got, want)
+		} else if got, want := maxTime, int64(24*time.Hour)-1; got != want {
+			t.Errorf("unexpected max time: got=%d want=%d", got, want)
+		}
+		return nil
+	}
+	s.TSDBStore = &store
+	s.MetaClient = &MetaClient{Data: data}
+
+	if err := s.Open(); err != nil {
+		t.Fatalf("unexpected open error: %s", err)
+	}
+	defer s.Close()
+
+	c := snapshotter.NewClient(l.Addr().String())
+	path, err := c.IngestTSMFile("db0", "rp0", bytes.NewReader(upload), int64(len(upload)), int64(time.Hour), int64(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	} else if got, want := path, filepath.Join("db0", "rp0", "2"); got != want {
+		t.Errorf("unexpected shard path: got=%s want=%s", got, want)
+	} else if !ingested {
+		t.Fatal("expected file to be ingested")
+	}
+
+	// The received file is removed once it is ingested.
+	if fis, err := ioutil.ReadDir(shardDir); err != nil {
+		t.Fatal(err)
+	} else if len(fis) != 0 {
+		t.Errorf("unexpected files left in shard directory: %d", len(fis))
+	}
+
+	// Files spanning several shard groups are rejected.
+	ingested = false
+	if _, err := c.IngestTSMFile("db0", "rp0", bytes.NewReader(upload), int64(len(upload)), int64(time.Hour), int64(25*time.Hour)); err == nil {
+		t.Fatal("expected error")
+	} else if ingested {
+		t.Fatal("unexpected ingest")
+	}
+}
+
 func TestSnapshotter_InvalidRequest(t *testing.T) {
 	s, l, err := NewTestService()
 	if err != nil {
@@ -446,3 +534,10 @@ func (m *MetaClient) Database(name string) *meta.DatabaseInfo {
 	}
 	return nil
 }
+
+func (m *MetaClient) CreateShardGroup(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
+	if err := m.Data.CreateShardGroup(database, policy, timestamp); err != nil {
+		return nil, err
+	}
+	return m.Data.ShardGroupByTimestamp(database, policy, timestamp)
+}
@@ -185,6 +185,156 @@ func (c *floatSumBatchCursor) Next() (key []int64, value []float64) {
 	}
 }
 
+type floatWindowSumBatchCursor struct {
+	tsdb.FloatBatchCursor
+	every, offset int64
+	ks            []int64
+	vs            []float64
+	t             []int64
+	v             []float64
+}
+
+func newFloatWindowSumBatchCursor(cur tsdb.FloatBatchCursor, every, offset int64) *floatWindowSumBatchCursor {
+	return &floatWindowSumBatchCursor{
+		FloatBatchCursor: cur,
+		every:            every,
+		offset:           offset,
+		t:                make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                make([]float64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowSumBatchCursor) Next() (key []int64, value []float64) {
+	var (
+		pos  int
+		acc  float64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.FloatBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc += c.vs[i]
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+type floatFloatMeanBatchCursor struct {
+	tsdb.FloatBatchCursor
+}
+
+func (c *floatFloatMeanBatchCursor) Next() (key []int64, value []float64) {
+	ks, vs := c.FloatBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	ts := ks[0]
+	var sum float64
+	var count int64
+
+	for {
+		for _, v := range vs {
+			sum += float64(v)
+		}
+		count += int64(len(vs))
+		ks, vs = c.FloatBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{ts}, []float64{sum / float64(count)}
+		}
+	}
+}
+
+type floatFloatWindowMeanBatchCursor struct {
+	tsdb.FloatBatchCursor
+	every, offset int64
+	ks            []int64
+	vs            []float64
+	t             []int64
+	v             []float64
+}
+
+func newFloatFloatWindowMeanBatchCursor(cur tsdb.FloatBatchCursor, every, offset int64) *floatFloatWindowMeanBatchCursor {
+	return &floatFloatWindowMeanBatchCursor{
+		FloatBatchCursor: cur,
+		every:            every,
+		offset:           offset,
+		t:                make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                make([]float64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *floatFloatWindowMeanBatchCursor) Next() (key []int64, value []float64) {
+	var (
+		pos   int
+		sum   float64
+		count int64
+		ws    int64
+		open  bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.FloatBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, sum/float64(count)
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, sum, count = start, 0, 0
+			}
+			sum += float64(c.vs[i])
+			count++
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, sum/float64(count)
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
 type integerFloatCountBatchCursor struct {
 	tsdb.FloatBatchCursor
 }
@@ -206,6 +356,196 @@ func (c *integerFloatCountBatchCursor) Next() (key []int64, value []int64) {
 	}
 }
 
+type integerFloatWindowCountBatchCursor struct {
+	tsdb.FloatBatchCursor
+	every, offset int64
+	ks            []int64
+	t             []int64
+	v             []int64
+}
+
+func newIntegerFloatWindowCountBatchCursor(cur tsdb.FloatBatchCursor, every, offset int64) *integerFloatWindowCountBatchCursor {
+	return &integerFloatWindowCountBatchCursor{
+		FloatBatchCursor: cur,
+		every:            every,
+		offset:           offset,
+		t:                make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                make([]int64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *integerFloatWindowCountBatchCursor) Next() (key []int64, value []int64) {
+	var (
+		pos  int
+		acc  int64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, _ = c.FloatBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks = c.ks[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc++
+		}
+		c.ks = nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+// floatSelectorFunc returns true if the point at time t with value v
+// should replace the currently selected point at time st with value sv.
+type floatSelectorFunc func(t int64, v float64, st int64, sv float64) bool
+
+func floatSelectFirst(t int64, _ float64, st int64, _ float64) bool { return t < st }
+func floatSelectLast(t int64, _ float64, st int64, _ float64) bool  { return t > st }
+
+func floatSelectMin(t int64, v float64, st int64, sv float64) bool {
+	return v < sv || (v == sv && t < st)
+}
+
+func floatSelectMax(t int64, v float64, st int64, sv float64) bool {
+	return v > sv || (v == sv && t < st)
+}
+
+// floatSelectorFor returns the selector for the aggregate type or
+// nil if the aggregate is not a selector supported by float values.
+func floatSelectorFor(typ Aggregate_AggregateType) floatSelectorFunc {
+	switch typ {
+	case AggregateTypeFirst:
+		return floatSelectFirst
+	case AggregateTypeLast:
+		return floatSelectLast
+	case AggregateTypeMin:
+		return floatSelectMin
+	case AggregateTypeMax:
+		return floatSelectMax
+	default:
+		return nil
+	}
+}
+
+type floatSelectorBatchCursor struct {
+	tsdb.FloatBatchCursor
+	sel floatSelectorFunc
+}
+
+func (c *floatSelectorBatchCursor) Next() (key []int64, value []float64) {
+	ks, vs := c.FloatBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	st, sv := ks[0], vs[0]
+	for {
+		for i, t := range ks {
+			if c.sel(t, vs[i], st, sv) {
+				st, sv = t, vs[i]
+			}
+		}
+		ks, vs = c.FloatBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{st}, []float64{sv}
+		}
+	}
+}
+
+type floatWindowSelectorBatchCursor struct {
+	tsdb.FloatBatchCursor
+	sel           floatSelectorFunc
+	every, offset int64
+	ks            []int64
+	vs            []float64
+	t             []int64
+	v             []float64
+}
+
+func newFloatWindowSelectorBatchCursor(cur tsdb.FloatBatchCursor, sel floatSelectorFunc, every, offset int64) *floatWindowSelectorBatchCursor {
+	return &floatWindowSelectorBatchCursor{
+		FloatBatchCursor: cur,
+		sel:              sel,
+		every:            every,
+		offset:           offset,
+		t:                make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                make([]float64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowSelectorBatchCursor) Next() (key []int64, value []float64) {
+	var (
+		pos  int
+		st   int64
+		sv   float64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.FloatBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if start != ws {
+				c.t[pos], c.v[pos] = st, sv
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws = start
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if c.sel(t, c.vs[i], st, sv) {
+				st, sv = t, c.vs[i]
+			}
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = st, sv
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
 type floatEmptyBatchCursor struct{}
 
 var FloatEmptyBatchCursor tsdb.FloatBatchCursor = &floatEmptyBatchCursor{}
@@ -387,6 +727,156 @@ func (c *integerSumBatchCursor) Next() (key []int64, value []int64) {
 	}
 }
 
+type integerWindowSumBatchCursor struct {
+	tsdb.IntegerBatchCursor
+	every, offset int64
+	ks            []int64
+	vs            []int64
+	t             []int64
+	v             []int64
+}
+
+func newIntegerWindowSumBatchCursor(cur tsdb.IntegerBatchCursor, every, offset int64) *integerWindowSumBatchCursor {
+	return &integerWindowSumBatchCursor{
+		IntegerBatchCursor: cur,
+		every:              every,
+		offset:             offset,
+		t:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowSumBatchCursor) Next() (key []int64, value []int64) {
+	var (
+		pos  int
+		acc  int64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.IntegerBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc += c.vs[i]
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+type floatIntegerMeanBatchCursor struct {
+	tsdb.IntegerBatchCursor
+}
+
+func (c *floatIntegerMeanBatchCursor) Next() (key []int64, value []float64) {
+	ks, vs := c.IntegerBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	ts := ks[0]
+	var sum float64
+	var count int64
+
+	for {
+		for _, v := range vs {
+			sum += float64(v)
+		}
+		count += int64(len(vs))
+		ks, vs = c.IntegerBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{ts}, []float64{sum / float64(count)}
+		}
+	}
+}
+
+type floatIntegerWindowMeanBatchCursor struct {
+	tsdb.IntegerBatchCursor
+	every, offset int64
+	ks            []int64
+	vs            []int64
+	t             []int64
+	v             []float64
+}
+
+func newFloatIntegerWindowMeanBatchCursor(cur tsdb.IntegerBatchCursor, every, offset int64) *floatIntegerWindowMeanBatchCursor {
+	return &floatIntegerWindowMeanBatchCursor{
+		IntegerBatchCursor: cur,
+		every:              every,
+		offset:             offset,
+		t:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                  make([]float64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *floatIntegerWindowMeanBatchCursor) Next() (key []int64, value []float64) {
+	var (
+		pos   int
+		sum   float64
+		count int64
+		ws    int64
+		open  bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.IntegerBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, sum/float64(count)
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, sum, count = start, 0, 0
+			}
+			sum += float64(c.vs[i])
+			count++
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, sum/float64(count)
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
 type integerIntegerCountBatchCursor struct {
 	tsdb.IntegerBatchCursor
 }
@@ -408,6 +898,196 @@ func (c *integerIntegerCountBatchCursor) Next() (key []int64, value []int64) {
 	}
 }
 
+type integerIntegerWindowCountBatchCursor struct {
+	tsdb.IntegerBatchCursor
+	every, offset int64
+	ks            []int64
+	t             []int64
+	v             []int64
+}
+
+func newIntegerIntegerWindowCountBatchCursor(cur tsdb.IntegerBatchCursor, every, offset int64) *integerIntegerWindowCountBatchCursor {
+	return &integerIntegerWindowCountBatchCursor{
+		IntegerBatchCursor: cur,
+		every:              every,
+		offset:             offset,
+		t:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *integerIntegerWindowCountBatchCursor) Next() (key []int64, value []int64) {
+	var (
+		pos  int
+		acc  int64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, _ = c.IntegerBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks = c.ks[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc++
+		}
+		c.ks = nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+// integerSelectorFunc returns true if the point at time t with value v
+// should replace the currently selected point at time st with value sv.
+type integerSelectorFunc func(t int64, v int64, st int64, sv int64) bool
+
+func integerSelectFirst(t int64, _ int64, st int64, _ int64) bool { return t < st }
+func integerSelectLast(t int64, _ int64, st int64, _ int64) bool  { return t > st }
+
+func integerSelectMin(t int64, v int64, st int64, sv int64) bool {
+	return v < sv || (v == sv && t < st)
+}
+
+func integerSelectMax(t int64, v int64, st int64, sv int64) bool {
+	return v > sv || (v == sv && t < st)
+}
+
+// integerSelectorFor returns the selector for the aggregate type or
+// nil if the aggregate is not a selector supported by integer values.
+func integerSelectorFor(typ Aggregate_AggregateType) integerSelectorFunc {
+	switch typ {
+	case AggregateTypeFirst:
+		return integerSelectFirst
+	case AggregateTypeLast:
+		return integerSelectLast
+	case AggregateTypeMin:
+		return integerSelectMin
+	case AggregateTypeMax:
+		return integerSelectMax
+	default:
+		return nil
+	}
+}
+
+type integerSelectorBatchCursor struct {
+	tsdb.IntegerBatchCursor
+	sel integerSelectorFunc
+}
+
+func (c *integerSelectorBatchCursor) Next() (key []int64, value []int64) {
+	ks, vs := c.IntegerBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	st, sv := ks[0], vs[0]
+	for {
+		for i, t := range ks {
+			if c.sel(t, vs[i], st, sv) {
+				st, sv = t, vs[i]
+			}
+		}
+		ks, vs = c.IntegerBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{st}, []int64{sv}
+		}
+	}
+}
+
+type integerWindowSelectorBatchCursor struct {
+	tsdb.IntegerBatchCursor
+	sel           integerSelectorFunc
+	every, offset int64
+	ks            []int64
+	vs            []int64
+	t             []int64
+	v             []int64
+}
+
+func newIntegerWindowSelectorBatchCursor(cur tsdb.IntegerBatchCursor, sel integerSelectorFunc, every, offset int64) *integerWindowSelectorBatchCursor {
+	return &integerWindowSelectorBatchCursor{
+		IntegerBatchCursor: cur,
+		sel:                sel,
+		every:              every,
+		offset:             offset,
+		t:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowSelectorBatchCursor) Next() (key []int64, value []int64) {
+	var (
+		pos  int
+		st   int64
+		sv   int64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.IntegerBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if start != ws {
+				c.t[pos], c.v[pos] = st, sv
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws = start
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if c.sel(t, c.vs[i], st, sv) {
+				st, sv = t, c.vs[i]
+			}
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = st, sv
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
 type integerEmptyBatchCursor struct{}
 
 var IntegerEmptyBatchCursor tsdb.IntegerBatchCursor = &integerEmptyBatchCursor{}
@@ -502,112 +1182,452 @@ func newUnsignedMultiShardBatchCursor(ctx context.Context, cur tsdb.UnsignedBatc
 		limit:               rr.limit,
 	}
 
-	return c
+	return c
+}
+
+func (c *unsignedMultiShardBatchCursor) Err() error        { return c.err }
+func (c *unsignedMultiShardBatchCursor) SeriesKey() string { return c.req.Series }
+
+func (c *unsignedMultiShardBatchCursor) Next() (key []int64, value []uint64) {
+	for {
+		ks, vs := c.UnsignedBatchCursor.Next()
+		if len(ks) == 0 {
+			if c.nextBatchCursor() {
+				continue
+			}
+		}
+		c.count += uint64(len(ks))
+		if c.count > c.limit {
+			diff := c.count - c.limit
+			c.count -= diff
+			rem := uint64(len(ks)) - diff
+			ks = ks[:rem]
+			vs = vs[:rem]
+		}
+		return ks, vs
+	}
+}
+
+func (c *unsignedMultiShardBatchCursor) nextBatchCursor() bool {
+	if len(c.shards) == 0 {
+		return false
+	}
+
+	c.UnsignedBatchCursor.Close()
+
+	var shard *tsdb.Shard
+	var cur tsdb.Cursor
+	for cur == nil && len(c.shards) > 0 {
+		shard, c.shards = c.shards[0], c.shards[1:]
+		cur, _ = shard.CreateCursor(c.ctx, c.req)
+	}
+
+	var ok bool
+	if cur != nil {
+		var next tsdb.UnsignedBatchCursor
+		next, ok = cur.(tsdb.UnsignedBatchCursor)
+		if !ok {
+			cur.Close()
+			next = UnsignedEmptyBatchCursor
+			c.shards = nil
+			c.err = errors.New("expected unsigned cursor")
+		} else {
+			if c.filter != nil {
+				c.filter.reset(next)
+				next = c.filter
+			}
+		}
+		c.UnsignedBatchCursor = next
+	} else {
+		c.UnsignedBatchCursor = UnsignedEmptyBatchCursor
+	}
+
+	return ok
+}
+
+type unsignedSumBatchCursor struct {
+	tsdb.UnsignedBatchCursor
+}
+
+func (c *unsignedSumBatchCursor) Next() (key []int64, value []uint64) {
+	ks, vs := c.UnsignedBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	ts := ks[0]
+	var acc uint64
+
+	for {
+		for _, v := range vs {
+			acc += v
+		}
+		ks, vs = c.UnsignedBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{ts}, []uint64{acc}
+		}
+	}
+}
+
+type unsignedWindowSumBatchCursor struct {
+	tsdb.UnsignedBatchCursor
+	every, offset int64
+	ks            []int64
+	vs            []uint64
+	t             []int64
+	v             []uint64
+}
+
+func newUnsignedWindowSumBatchCursor(cur tsdb.UnsignedBatchCursor, every, offset int64) *unsignedWindowSumBatchCursor {
+	return &unsignedWindowSumBatchCursor{
+		UnsignedBatchCursor: cur,
+		every:               every,
+		offset:              offset,
+		t:                   make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                   make([]uint64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowSumBatchCursor) Next() (key []int64, value []uint64) {
+	var (
+		pos  int
+		acc  uint64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.UnsignedBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc += c.vs[i]
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+type floatUnsignedMeanBatchCursor struct {
+	tsdb.UnsignedBatchCursor
+}
+
+func (c *floatUnsignedMeanBatchCursor) Next() (key []int64, value []float64) {
+	ks, vs := c.UnsignedBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	ts := ks[0]
+	var sum float64
+	var count int64
+
+	for {
+		for _, v := range vs {
+			sum += float64(v)
+		}
+		count += int64(len(vs))
+		ks, vs = c.UnsignedBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{ts}, []float64{sum / float64(count)}
+		}
+	}
+}
+
+type floatUnsignedWindowMeanBatchCursor struct {
+	tsdb.UnsignedBatchCursor
+	every, offset int64
+	ks            []int64
+	vs            []uint64
+	t             []int64
+	v             []float64
+}
+
+func newFloatUnsignedWindowMeanBatchCursor(cur tsdb.UnsignedBatchCursor, every, offset int64) *floatUnsignedWindowMeanBatchCursor {
+	return &floatUnsignedWindowMeanBatchCursor{
+		UnsignedBatchCursor: cur,
+		every:               every,
+		offset:              offset,
+		t:                   make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                   make([]float64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *floatUnsignedWindowMeanBatchCursor) Next() (key []int64, value []float64) {
+	var (
+		pos   int
+		sum   float64
+		count int64
+		ws    int64
+		open  bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.UnsignedBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, sum/float64(count)
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, sum, count = start, 0, 0
+			}
+			sum += float64(c.vs[i])
+			count++
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, sum/float64(count)
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+type integerUnsignedCountBatchCursor struct {
+	tsdb.UnsignedBatchCursor
+}
+
+func (c *integerUnsignedCountBatchCursor) Next() (key []int64, value []int64) {
+	ks, _ := c.UnsignedBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	ts := ks[0]
+	var acc int64
+	for {
+		acc += int64(len(ks))
+		ks, _ = c.UnsignedBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{ts}, []int64{acc}
+		}
+	}
+}
+
+type integerUnsignedWindowCountBatchCursor struct {
+	tsdb.UnsignedBatchCursor
+	every, offset int64
+	ks            []int64
+	t             []int64
+	v             []int64
 }
 
-func (c *unsignedMultiShardBatchCursor) Err() error        { return c.err }
-func (c *unsignedMultiShardBatchCursor) SeriesKey() string { return c.req.Series }
+func newIntegerUnsignedWindowCountBatchCursor(cur tsdb.UnsignedBatchCursor, every, offset int64) *integerUnsignedWindowCountBatchCursor {
+	return &integerUnsignedWindowCountBatchCursor{
+		UnsignedBatchCursor: cur,
+		every:               every,
+		offset:              offset,
+		t:                   make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                   make([]int64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *integerUnsignedWindowCountBatchCursor) Next() (key []int64, value []int64) {
+	var (
+		pos  int
+		acc  int64
+		ws   int64
+		open bool
+	)
 
-func (c *unsignedMultiShardBatchCursor) Next() (key []int64, value []uint64) {
 	for {
-		ks, vs := c.UnsignedBatchCursor.Next()
-		if len(ks) == 0 {
-			if c.nextBatchCursor() {
-				continue
+		if len(c.ks) == 0 {
+			c.ks, _ = c.UnsignedBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
 			}
 		}
-		c.count += uint64(len(ks))
-		if c.count > c.limit {
-			diff := c.count - c.limit
-			c.count -= diff
-			rem := uint64(len(ks)) - diff
-			ks = ks[:rem]
-			vs = vs[:rem]
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks = c.ks[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc++
 		}
-		return ks, vs
+		c.ks = nil
 	}
-}
 
-func (c *unsignedMultiShardBatchCursor) nextBatchCursor() bool {
-	if len(c.shards) == 0 {
-		return false
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
 	}
 
-	c.UnsignedBatchCursor.Close()
+	return c.t[:pos], c.v[:pos]
+}
 
-	var shard *tsdb.Shard
-	var cur tsdb.Cursor
-	for cur == nil && len(c.shards) > 0 {
-		shard, c.shards = c.shards[0], c.shards[1:]
-		cur, _ = shard.CreateCursor(c.ctx, c.req)
-	}
+// unsignedSelectorFunc returns true if the point at time t with value v
+// should replace the currently selected point at time st with value sv.
+type unsignedSelectorFunc func(t int64, v uint64, st int64, sv uint64) bool
 
-	var ok bool
-	if cur != nil {
-		var next tsdb.UnsignedBatchCursor
-		next, ok = cur.(tsdb.UnsignedBatchCursor)
-		if !ok {
-			cur.Close()
-			next = UnsignedEmptyBatchCursor
-			c.shards = nil
-			c.err = errors.New("expected unsigned cursor")
-		} else {
-			if c.filter != nil {
-				c.filter.reset(next)
-				next = c.filter
-			}
-		}
-		c.UnsignedBatchCursor = next
-	} else {
-		c.UnsignedBatchCursor = UnsignedEmptyBatchCursor
-	}
+func unsignedSelectFirst(t int64, _ uint64, st int64, _ uint64) bool { return t < st }
+func unsignedSelectLast(t int64, _ uint64, st int64, _ uint64) bool  { return t > st }
 
-	return ok
+func unsignedSelectMin(t int64, v uint64, st int64, sv uint64) bool {
+	return v < sv || (v == sv && t < st)
 }
 
-type unsignedSumBatchCursor struct {
+func unsignedSelectMax(t int64, v uint64, st int64, sv uint64) bool {
+	return v > sv || (v == sv && t < st)
+}
+
+// unsignedSelectorFor returns the selector for the aggregate type or
+// nil if the aggregate is not a selector supported by unsigned values.
+func unsignedSelectorFor(typ Aggregate_AggregateType) unsignedSelectorFunc {
+	switch typ {
+	case AggregateTypeFirst:
+		return unsignedSelectFirst
+	case AggregateTypeLast:
+		return unsignedSelectLast
+	case AggregateTypeMin:
+		return unsignedSelectMin
+	case AggregateTypeMax:
+		return unsignedSelectMax
+	default:
+		return nil
+	}
+}
+
+type unsignedSelectorBatchCursor struct {
 	tsdb.UnsignedBatchCursor
+	sel unsignedSelectorFunc
 }
 
-func (c *unsignedSumBatchCursor) Next() (key []int64, value []uint64) {
+func (c *unsignedSelectorBatchCursor) Next() (key []int64, value []uint64) {
 	ks, vs := c.UnsignedBatchCursor.Next()
 	if len(ks) == 0 {
 		return nil, nil
 	}
 
-	ts := ks[0]
-	var acc uint64
-
+	st, sv := ks[0], vs[0]
 	for {
-		for _, v := range vs {
-			acc += v
+		for i, t := range ks {
+			if c.sel(t, vs[i], st, sv) {
+				st, sv = t, vs[i]
+			}
 		}
 		ks, vs = c.UnsignedBatchCursor.Next()
 		if len(ks) == 0 {
-			return []int64{ts}, []uint64{acc}
+			return []int64{st}, []uint64{sv}
 		}
 	}
 }
 
-type integerUnsignedCountBatchCursor struct {
+type unsignedWindowSelectorBatchCursor struct {
 	tsdb.UnsignedBatchCursor
+	sel           unsignedSelectorFunc
+	every, offset int64
+	ks            []int64
+	vs            []uint64
+	t             []int64
+	v             []uint64
 }
 
-func (c *integerUnsignedCountBatchCursor) Next() (key []int64, value []int64) {
-	ks, _ := c.UnsignedBatchCursor.Next()
-	if len(ks) == 0 {
-		return nil, nil
+func newUnsignedWindowSelectorBatchCursor(cur tsdb.UnsignedBatchCursor, sel unsignedSelectorFunc, every, offset int64) *unsignedWindowSelectorBatchCursor {
+	return &unsignedWindowSelectorBatchCursor{
+		UnsignedBatchCursor: cur,
+		sel:                 sel,
+		every:               every,
+		offset:              offset,
+		t:                   make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                   make([]uint64, tsdb.DefaultMaxPointsPerBlock),
 	}
+}
+
+func (c *unsignedWindowSelectorBatchCursor) Next() (key []int64, value []uint64) {
+	var (
+		pos  int
+		st   int64
+		sv   uint64
+		ws   int64
+		open bool
+	)
 
-	ts := ks[0]
-	var acc int64
 	for {
-		acc += int64(len(ks))
-		ks, _ = c.UnsignedBatchCursor.Next()
-		if len(ks) == 0 {
-			return []int64{ts}, []int64{acc}
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.UnsignedBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if start != ws {
+				c.t[pos], c.v[pos] = st, sv
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws = start
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if c.sel(t, c.vs[i], st, sv) {
+				st, sv = t, c.vs[i]
+			}
 		}
+		c.ks, c.vs = nil, nil
 	}
+
+	if open {
+		c.t[pos], c.v[pos] = st, sv
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
 }
 
 type unsignedEmptyBatchCursor struct{}
@@ -788,6 +1808,184 @@ func (c *integerStringCountBatchCursor) Next() (key []int64, value []int64) {
 	}
 }
 
+type integerStringWindowCountBatchCursor struct {
+	tsdb.StringBatchCursor
+	every, offset int64
+	ks            []int64
+	t             []int64
+	v             []int64
+}
+
+func newIntegerStringWindowCountBatchCursor(cur tsdb.StringBatchCursor, every, offset int64) *integerStringWindowCountBatchCursor {
+	return &integerStringWindowCountBatchCursor{
+		StringBatchCursor: cur,
+		every:             every,
+		offset:            offset,
+		t:                 make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                 make([]int64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *integerStringWindowCountBatchCursor) Next() (key []int64, value []int64) {
+	var (
+		pos  int
+		acc  int64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, _ = c.StringBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks = c.ks[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc++
+		}
+		c.ks = nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+// stringSelectorFunc returns true if the point at time t with value v
+// should replace the currently selected point at time st with value sv.
+type stringSelectorFunc func(t int64, v string, st int64, sv string) bool
+
+func stringSelectFirst(t int64, _ string, st int64, _ string) bool { return t < st }
+func stringSelectLast(t int64, _ string, st int64, _ string) bool  { return t > st }
+
+// stringSelectorFor returns the selector for the aggregate type or
+// nil if the aggregate is not a selector supported by string values.
+func stringSelectorFor(typ Aggregate_AggregateType) stringSelectorFunc {
+	switch typ {
+	case AggregateTypeFirst:
+		return stringSelectFirst
+	case AggregateTypeLast:
+		return stringSelectLast
+	default:
+		return nil
+	}
+}
+
+type stringSelectorBatchCursor struct {
+	tsdb.StringBatchCursor
+	sel stringSelectorFunc
+}
+
+func (c *stringSelectorBatchCursor) Next() (key []int64, value []string) {
+	ks, vs := c.StringBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	st, sv := ks[0], vs[0]
+	for {
+		for i, t := range ks {
+			if c.sel(t, vs[i], st, sv) {
+				st, sv = t, vs[i]
+			}
+		}
+		ks, vs = c.StringBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{st}, []string{sv}
+		}
+	}
+}
+
+type stringWindowSelectorBatchCursor struct {
+	tsdb.StringBatchCursor
+	sel           stringSelectorFunc
+	every, offset int64
+	ks            []int64
+	vs            []string
+	t             []int64
+	v             []string
+}
+
+func newStringWindowSelectorBatchCursor(cur tsdb.StringBatchCursor, sel stringSelectorFunc, every, offset int64) *stringWindowSelectorBatchCursor {
+	return &stringWindowSelectorBatchCursor{
+		StringBatchCursor: cur,
+		sel:               sel,
+		every:             every,
+		offset:            offset,
+		t:                 make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                 make([]string, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *stringWindowSelectorBatchCursor) Next() (key []int64, value []string) {
+	var (
+		pos  int
+		st   int64
+		sv   string
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.StringBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if start != ws {
+				c.t[pos], c.v[pos] = st, sv
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws = start
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if c.sel(t, c.vs[i], st, sv) {
+				st, sv = t, c.vs[i]
+			}
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = st, sv
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
 type stringEmptyBatchCursor struct{}
 
 var StringEmptyBatchCursor tsdb.StringBatchCursor = &stringEmptyBatchCursor{}
@@ -966,6 +2164,196 @@ func (c *integerBooleanCountBatchCursor) Next() (key []int64, value []int64) {
 	}
 }
 
+type integerBooleanWindowCountBatchCursor struct {
+	tsdb.BooleanBatchCursor
+	every, offset int64
+	ks            []int64
+	t             []int64
+	v             []int64
+}
+
+func newIntegerBooleanWindowCountBatchCursor(cur tsdb.BooleanBatchCursor, every, offset int64) *integerBooleanWindowCountBatchCursor {
+	return &integerBooleanWindowCountBatchCursor{
+		BooleanBatchCursor: cur,
+		every:              every,
+		offset:             offset,
+		t:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *integerBooleanWindowCountBatchCursor) Next() (key []int64, value []int64) {
+	var (
+		pos  int
+		acc  int64
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, _ = c.BooleanBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+			} else if start != ws {
+				c.t[pos], c.v[pos] = ws, acc
+				pos++
+				if pos >= len(c.t) {
+					c.ks = c.ks[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws, acc = start, 0
+			}
+			acc++
+		}
+		c.ks = nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = ws, acc
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
+// booleanSelectorFunc returns true if the point at time t with value v
+// should replace the currently selected point at time st with value sv.
+type booleanSelectorFunc func(t int64, v bool, st int64, sv bool) bool
+
+func booleanSelectFirst(t int64, _ bool, st int64, _ bool) bool { return t < st }
+func booleanSelectLast(t int64, _ bool, st int64, _ bool) bool  { return t > st }
+
+func booleanSelectMin(t int64, v bool, st int64, sv bool) bool {
+	return (!v && sv) || (v == sv && t < st)
+}
+
+func booleanSelectMax(t int64, v bool, st int64, sv bool) bool {
+	return (v && !sv) || (v == sv && t < st)
+}
+
+// booleanSelectorFor returns the selector for the aggregate type or
+// nil if the aggregate is not a selector supported by boolean values.
+func booleanSelectorFor(typ Aggregate_AggregateType) booleanSelectorFunc {
+	switch typ {
+	case AggregateTypeFirst:
+		return booleanSelectFirst
+	case AggregateTypeLast:
+		return booleanSelectLast
+	case AggregateTypeMin:
+		return booleanSelectMin
+	case AggregateTypeMax:
+		return booleanSelectMax
+	default:
+		return nil
+	}
+}
+
+type booleanSelectorBatchCursor struct {
+	tsdb.BooleanBatchCursor
+	sel booleanSelectorFunc
+}
+
+func (c *booleanSelectorBatchCursor) Next() (key []int64, value []bool) {
+	ks, vs := c.BooleanBatchCursor.Next()
+	if len(ks) == 0 {
+		return nil, nil
+	}
+
+	st, sv := ks[0], vs[0]
+	for {
+		for i, t := range ks {
+			if c.sel(t, vs[i], st, sv) {
+				st, sv = t, vs[i]
+			}
+		}
+		ks, vs = c.BooleanBatchCursor.Next()
+		if len(ks) == 0 {
+			return []int64{st}, []bool{sv}
+		}
+	}
+}
+
+type booleanWindowSelectorBatchCursor struct {
+	tsdb.BooleanBatchCursor
+	sel           booleanSelectorFunc
+	every, offset int64
+	ks            []int64
+	vs            []bool
+	t             []int64
+	v             []bool
+}
+
+func newBooleanWindowSelectorBatchCursor(cur tsdb.BooleanBatchCursor, sel booleanSelectorFunc, every, offset int64) *booleanWindowSelectorBatchCursor {
+	return &booleanWindowSelectorBatchCursor{
+		BooleanBatchCursor: cur,
+		sel:                sel,
+		every:              every,
+		offset:             offset,
+		t:                  make([]int64, tsdb.DefaultMaxPointsPerBlock),
+		v:                  make([]bool, tsdb.DefaultMaxPointsPerBlock),
+	}
+}
+
+func (c *booleanWindowSelectorBatchCursor) Next() (key []int64, value []bool) {
+	var (
+		pos  int
+		st   int64
+		sv   bool
+		ws   int64
+		open bool
+	)
+
+	for {
+		if len(c.ks) == 0 {
+			c.ks, c.vs = c.BooleanBatchCursor.Next()
+			if len(c.ks) == 0 {
+				break
+			}
+		}
+
+		for i, t := range c.ks {
+			start := windowStart(t, c.every, c.offset)
+			if !open {
+				ws, open = start, true
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if start != ws {
+				c.t[pos], c.v[pos] = st, sv
+				pos++
+				if pos >= len(c.t) {
+					c.ks, c.vs = c.ks[i:], c.vs[i:]
+					return c.t[:pos], c.v[:pos]
+				}
+				ws = start
+				st, sv = t, c.vs[i]
+				continue
+			}
+
+			if c.sel(t, c.vs[i], st, sv) {
+				st, sv = t, c.vs[i]
+			}
+		}
+		c.ks, c.vs = nil, nil
+	}
+
+	if open {
+		c.t[pos], c.v[pos] = st, sv
+		pos++
+	}
+
+	return c.t[:pos], c.v[:pos]
+}
+
 type booleanEmptyBatchCursor struct{}
 
 var BooleanEmptyBatchCursor tsdb.BooleanBatchCursor = &booleanEmptyBatchCursor{}
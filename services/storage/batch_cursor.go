@@ -25,12 +25,45 @@ func newAggregateBatchCursor(ctx context.Context, agg *Aggregate, cursor tsdb.Cu
 		return newSumBatchCursor(cursor)
 	case AggregateTypeCount:
 		return newCountBatchCursor(cursor)
+	case AggregateTypeMin, AggregateTypeMax, AggregateTypeFirst, AggregateTypeLast:
+		return newSelectorBatchCursor(cursor, agg.Type)
+	case AggregateTypeMean:
+		return newMeanBatchCursor(cursor)
 	default:
 		// TODO(sgc): should be validated higher up
 		panic("invalid aggregate")
 	}
 }
 
+func newWindowAggregateBatchCursor(ctx context.Context, agg *Aggregate, every, offset int64, cursor tsdb.Cursor) tsdb.Cursor {
+	if cursor == nil {
+		return nil
+	}
+
+	switch agg.Type {
+	case AggregateTypeSum:
+		return newWindowSumBatchCursor(cursor, every, offset)
+	case AggregateTypeCount:
+		return newWindowCountBatchCursor(cursor, every, offset)
+	case AggregateTypeMin, AggregateTypeMax, AggregateTypeFirst, AggregateTypeLast:
+		return newWindowSelectorBatchCursor(cursor, agg.Type, every, offset)
+	case AggregateTypeMean:
+		return newWindowMeanBatchCursor(cursor, every, offset)
+	default:
+		// TODO(sgc): should be validated higher up
+		panic("invalid aggregate")
+	}
+}
+
+// windowStart returns the start time of the window of the given duration that contains t.
+func windowStart(t, every, offset int64) int64 {
+	d := (t - offset) % every
+	if d < 0 {
+		d += every
+	}
+	return t - d
+}
+
 func newSumBatchCursor(cur tsdb.Cursor) tsdb.Cursor {
 	switch cur := cur.(type) {
 	case tsdb.FloatBatchCursor:
@@ -62,6 +95,129 @@ func newCountBatchCursor(cur tsdb.Cursor) tsdb.Cursor {
 	}
 }
 
+func newWindowSumBatchCursor(cur tsdb.Cursor, every, offset int64) tsdb.Cursor {
+	switch cur := cur.(type) {
+	case tsdb.FloatBatchCursor:
+		return newFloatWindowSumBatchCursor(cur, every, offset)
+	case tsdb.IntegerBatchCursor:
+		return newIntegerWindowSumBatchCursor(cur, every, offset)
+	case tsdb.UnsignedBatchCursor:
+		return newUnsignedWindowSumBatchCursor(cur, every, offset)
+	default:
+		// TODO(sgc): propagate an error instead?
+		return nil
+	}
+}
+
+func newWindowCountBatchCursor(cur tsdb.Cursor, every, offset int64) tsdb.Cursor {
+	switch cur := cur.(type) {
+	case tsdb.FloatBatchCursor:
+		return newIntegerFloatWindowCountBatchCursor(cur, every, offset)
+	case tsdb.IntegerBatchCursor:
+		return newIntegerIntegerWindowCountBatchCursor(cur, every, offset)
+	case tsdb.UnsignedBatchCursor:
+		return newIntegerUnsignedWindowCountBatchCursor(cur, every, offset)
+	case tsdb.StringBatchCursor:
+		return newIntegerStringWindowCountBatchCursor(cur, every, offset)
+	case tsdb.BooleanBatchCursor:
+		return newIntegerBooleanWindowCountBatchCursor(cur, every, offset)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+// newSelectorBatchCursor returns a cursor which selects a single point from cur
+// using the selector identified by typ. The timestamp of the selected point is preserved.
+func newSelectorBatchCursor(cur tsdb.Cursor, typ Aggregate_AggregateType) tsdb.Cursor {
+	switch cur := cur.(type) {
+	case tsdb.FloatBatchCursor:
+		if sel := floatSelectorFor(typ); sel != nil {
+			return &floatSelectorBatchCursor{FloatBatchCursor: cur, sel: sel}
+		}
+	case tsdb.IntegerBatchCursor:
+		if sel := integerSelectorFor(typ); sel != nil {
+			return &integerSelectorBatchCursor{IntegerBatchCursor: cur, sel: sel}
+		}
+	case tsdb.UnsignedBatchCursor:
+		if sel := unsignedSelectorFor(typ); sel != nil {
+			return &unsignedSelectorBatchCursor{UnsignedBatchCursor: cur, sel: sel}
+		}
+	case tsdb.StringBatchCursor:
+		if sel := stringSelectorFor(typ); sel != nil {
+			return &stringSelectorBatchCursor{StringBatchCursor: cur, sel: sel}
+		}
+	case tsdb.BooleanBatchCursor:
+		if sel := booleanSelectorFor(typ); sel != nil {
+			return &booleanSelectorBatchCursor{BooleanBatchCursor: cur, sel: sel}
+		}
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+
+	// selector is not supported for the data type
+	cur.Close()
+	return nil
+}
+
+func newWindowSelectorBatchCursor(cur tsdb.Cursor, typ Aggregate_AggregateType, every, offset int64) tsdb.Cursor {
+	switch cur := cur.(type) {
+	case tsdb.FloatBatchCursor:
+		if sel := floatSelectorFor(typ); sel != nil {
+			return newFloatWindowSelectorBatchCursor(cur, sel, every, offset)
+		}
+	case tsdb.IntegerBatchCursor:
+		if sel := integerSelectorFor(typ); sel != nil {
+			return newIntegerWindowSelectorBatchCursor(cur, sel, every, offset)
+		}
+	case tsdb.UnsignedBatchCursor:
+		if sel := unsignedSelectorFor(typ); sel != nil {
+			return newUnsignedWindowSelectorBatchCursor(cur, sel, every, offset)
+		}
+	case tsdb.StringBatchCursor:
+		if sel := stringSelectorFor(typ); sel != nil {
+			return newStringWindowSelectorBatchCursor(cur, sel, every, offset)
+		}
+	case tsdb.BooleanBatchCursor:
+		if sel := booleanSelectorFor(typ); sel != nil {
+			return newBooleanWindowSelectorBatchCursor(cur, sel, every, offset)
+		}
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+
+	// selector is not supported for the data type
+	cur.Close()
+	return nil
+}
+
+func newMeanBatchCursor(cur tsdb.Cursor) tsdb.Cursor {
+	switch cur := cur.(type) {
+	case tsdb.FloatBatchCursor:
+		return &floatFloatMeanBatchCursor{FloatBatchCursor: cur}
+	case tsdb.IntegerBatchCursor:
+		return &floatIntegerMeanBatchCursor{IntegerBatchCursor: cur}
+	case tsdb.UnsignedBatchCursor:
+		return &floatUnsignedMeanBatchCursor{UnsignedBatchCursor: cur}
+	default:
+		// TODO(sgc): propagate an error instead?
+		return nil
+	}
+}
+
+func newWindowMeanBatchCursor(cur tsdb.Cursor, every, offset int64) tsdb.Cursor {
+	switch cur := cur.(type) {
+	case tsdb.FloatBatchCursor:
+		return newFloatFloatWindowMeanBatchCursor(cur, every, offset)
+	case tsdb.IntegerBatchCursor:
+		return newFloatIntegerWindowMeanBatchCursor(cur, every, offset)
+	case tsdb.UnsignedBatchCursor:
+		return newFloatUnsignedWindowMeanBatchCursor(cur, every, offset)
+	default:
+		// TODO(sgc): propagate an error instead?
+		return nil
+	}
+}
+
 func newMultiShardBatchCursor(ctx context.Context, row seriesRow, rr *readRequest) tsdb.Cursor {
 	req := &tsdb.CursorRequest{
 		Measurement: row.measurement,
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+type floatBatch struct {
+	ks []int64
+	vs []float64
+}
+
+type floatSliceBatchCursor struct {
+	batches []floatBatch
+}
+
+func (c *floatSliceBatchCursor) Close()            {}
+func (c *floatSliceBatchCursor) Err() error        { return nil }
+func (c *floatSliceBatchCursor) SeriesKey() string { return "" }
+
+func (c *floatSliceBatchCursor) Next() (keys []int64, values []float64) {
+	if len(c.batches) == 0 {
+		return nil, nil
+	}
+	b := c.batches[0]
+	c.batches = c.batches[1:]
+	return b.ks, b.vs
+}
+
+func TestWindowStart(t *testing.T) {
+	tests := []struct {
+		t, every, offset, exp int64
+	}{
+		{t: 0, every: 10, exp: 0},
+		{t: 9, every: 10, exp: 0},
+		{t: 10, every: 10, exp: 10},
+		{t: -1, every: 10, exp: -10},
+		{t: 12, every: 10, offset: 5, exp: 5},
+		{t: 4, every: 10, offset: 5, exp: -5},
+		{t: 12, every: 10, offset: -5, exp: 5},
+	}
+
+	for _, tt := range tests {
+		if got := windowStart(tt.t, tt.every, tt.offset); got != tt.exp {
+			t.Errorf("windowStart(%d, %d, %d) = %d, expected %d", tt.t, tt.every, tt.offset, got, tt.exp)
+		}
+	}
+}
+
+func TestFloatWindowSumBatchCursor(t *testing.T) {
+	cur := &floatSliceBatchCursor{batches: []floatBatch{
+		{ks: []int64{0, 1, 5, 10}, vs: []float64{1, 2, 3, 4}},
+		{ks: []int64{11, 25}, vs: []float64{5, 6}},
+	}}
+
+	c := newFloatWindowSumBatchCursor(cur, 10, 0)
+
+	var ks []int64
+	var vs []float64
+	for {
+		k, v := c.Next()
+		if len(k) == 0 {
+			break
+		}
+		ks = append(ks, k...)
+		vs = append(vs, v...)
+	}
+
+	if exp := []int64{0, 10, 20}; !cmp.Equal(ks, exp) {
+		t.Errorf("unexpected timestamps; -got/+exp\n%s", cmp.Diff(ks, exp))
+	}
+	if exp := []float64{6, 9, 6}; !cmp.Equal(vs, exp) {
+		t.Errorf("unexpected values; -got/+exp\n%s", cmp.Diff(vs, exp))
+	}
+}
+
+func TestIntegerFloatWindowCountBatchCursor(t *testing.T) {
+	cur := &floatSliceBatchCursor{batches: []floatBatch{
+		{ks: []int64{0, 1, 5, 10}, vs: []float64{1, 2, 3, 4}},
+		{ks: []int64{11, 25}, vs: []float64{5, 6}},
+	}}
+
+	c := newIntegerFloatWindowCountBatchCursor(cur, 10, 5)
+
+	var ks, vs []int64
+	for {
+		k, v := c.Next()
+		if len(k) == 0 {
+			break
+		}
+		ks = append(ks, k...)
+		vs = append(vs, v...)
+	}
+
+	if exp := []int64{-5, 5, 25}; !cmp.Equal(ks, exp) {
+		t.Errorf("unexpected timestamps; -got/+exp\n%s", cmp.Diff(ks, exp))
+	}
+	if exp := []int64{2, 3, 1}; !cmp.Equal(vs, exp) {
+		t.Errorf("unexpected values; -got/+exp\n%s", cmp.Diff(vs, exp))
+	}
+}
+
+func TestFloatSelectorBatchCursor(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  Aggregate_AggregateType
+		asc  bool
+		expK int64
+		expV float64
+	}{
+		{name: "min", typ: AggregateTypeMin, asc: true, expK: 5, expV: 1},
+		{name: "max", typ: AggregateTypeMax, asc: true, expK: 1, expV: 4},
+		{name: "first", typ: AggregateTypeFirst, asc: true, expK: 0, expV: 3},
+		{name: "last", typ: AggregateTypeLast, asc: true, expK: 25, expV: 2},
+		{name: "min desc", typ: AggregateTypeMin, expK: 5, expV: 1},
+		{name: "max desc", typ: AggregateTypeMax, expK: 1, expV: 4},
+		{name: "first desc", typ: AggregateTypeFirst, expK: 0, expV: 3},
+		{name: "last desc", typ: AggregateTypeLast, expK: 25, expV: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := []floatBatch{
+				{ks: []int64{0, 1, 5}, vs: []float64{3, 4, 1}},
+				{ks: []int64{10, 11, 25}, vs: []float64{4, 1, 2}},
+			}
+			if !tt.asc {
+				batches = []floatBatch{
+					{ks: []int64{25, 11, 10}, vs: []float64{2, 1, 4}},
+					{ks: []int64{5, 1, 0}, vs: []float64{1, 4, 3}},
+				}
+			}
+
+			c := newSelectorBatchCursor(&floatSliceBatchCursor{batches: batches}, tt.typ).(tsdb.FloatBatchCursor)
+			ks, vs := c.Next()
+			if !cmp.Equal(ks, []int64{tt.expK}) || !cmp.Equal(vs, []float64{tt.expV}) {
+				t.Errorf("unexpected point; got (%v, %v), expected (%d, %v)", ks, vs, tt.expK, tt.expV)
+			}
+			if ks, _ := c.Next(); len(ks) != 0 {
+				t.Errorf("expected EOF")
+			}
+		})
+	}
+}
+
+func TestFloatWindowSelectorBatchCursor(t *testing.T) {
+	cur := &floatSliceBatchCursor{batches: []floatBatch{
+		{ks: []int64{0, 1, 5, 10}, vs: []float64{1, 3, 2, 4}},
+		{ks: []int64{11, 25}, vs: []float64{5, 6}},
+	}}
+
+	c := newWindowSelectorBatchCursor(cur, AggregateTypeMax, 10, 0).(tsdb.FloatBatchCursor)
+
+	var ks []int64
+	var vs []float64
+	for {
+		k, v := c.Next()
+		if len(k) == 0 {
+			break
+		}
+		ks = append(ks, k...)
+		vs = append(vs, v...)
+	}
+
+	if exp := []int64{1, 11, 25}; !cmp.Equal(ks, exp) {
+		t.Errorf("unexpected timestamps; -got/+exp\n%s", cmp.Diff(ks, exp))
+	}
+	if exp := []float64{3, 5, 6}; !cmp.Equal(vs, exp) {
+		t.Errorf("unexpected values; -got/+exp\n%s", cmp.Diff(vs, exp))
+	}
+}
+
+func TestFloatWindowMeanBatchCursor(t *testing.T) {
+	cur := &floatSliceBatchCursor{batches: []floatBatch{
+		{ks: []int64{0, 1, 5, 10}, vs: []float64{1, 3, 2, 4}},
+		{ks: []int64{11, 25}, vs: []float64{5, 6}},
+	}}
+
+	c := newFloatFloatWindowMeanBatchCursor(cur, 10, 0)
+
+	var ks []int64
+	var vs []float64
+	for {
+		k, v := c.Next()
+		if len(k) == 0 {
+			break
+		}
+		ks = append(ks, k...)
+		vs = append(vs, v...)
+	}
+
+	if exp := []int64{0, 10, 20}; !cmp.Equal(ks, exp) {
+		t.Errorf("unexpected timestamps; -got/+exp\n%s", cmp.Diff(ks, exp))
+	}
+	if exp := []float64{2, 4.5, 6}; !cmp.Equal(vs, exp) {
+		t.Errorf("unexpected values; -got/+exp\n%s", cmp.Diff(vs, exp))
+	}
+}
+
+func TestSelectorBatchCursor_Unsupported(t *testing.T) {
+	cur := &stringSliceBatchCursor{}
+	if c := newSelectorBatchCursor(cur, AggregateTypeMin); c != nil {
+		t.Errorf("expected nil cursor, got %T", c)
+	}
+	if !cur.closed {
+		t.Errorf("expected cursor to be closed")
+	}
+}
+
+type stringSliceBatchCursor struct {
+	closed bool
+}
+
+func (c *stringSliceBatchCursor) Close()                                { c.closed = true }
+func (c *stringSliceBatchCursor) Err() error                            { return nil }
+func (c *stringSliceBatchCursor) SeriesKey() string                     { return "" }
+func (c *stringSliceBatchCursor) Next() (keys []int64, values []string) { return nil, nil }
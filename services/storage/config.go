@@ -14,14 +14,23 @@ type Config struct {
 	Enabled     bool   `toml:"enabled"`
 	LogEnabled  bool   `toml:"log-enabled"` // verbose logging
 	BindAddress string `toml:"bind-address"`
+
+	// WriteEnabled enables the Write RPC, which is disabled by default.
+	WriteEnabled bool `toml:"write-enabled"`
+
+	// AuthEnabled requires the Write RPC to be given the credentials of a
+	// user with write privilege on the database.
+	AuthEnabled bool `toml:"auth-enabled"`
 }
 
 // NewConfig returns a new Config with default settings.
 func NewConfig() Config {
 	return Config{
-		Enabled:     false,
-		LogEnabled:  true,
-		BindAddress: DefaultBindAddress,
+		Enabled:      false,
+		LogEnabled:   true,
+		BindAddress:  DefaultBindAddress,
+		WriteEnabled: false,
+		AuthEnabled:  false,
 	}
 }
 
@@ -34,8 +43,10 @@ func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 	}
 
 	return diagnostics.RowFromMap(map[string]interface{}{
-		"enabled":      true,
-		"log-enabled":  c.LogEnabled,
-		"bind-address": c.BindAddress,
+		"enabled":       true,
+		"log-enabled":   c.LogEnabled,
+		"bind-address":  c.BindAddress,
+		"write-enabled": c.WriteEnabled,
+		"auth-enabled":  c.AuthEnabled,
 	}), nil
 }
@@ -13,6 +13,8 @@ type readRequest struct {
 	asc        bool
 	limit      uint64
 	aggregate  *Aggregate
+	every      int64
+	offset     int64
 }
 
 type ResultSet struct {
@@ -40,7 +42,11 @@ func (r *ResultSet) Next() bool {
 func (r *ResultSet) Cursor() tsdb.Cursor {
 	cur := newMultiShardBatchCursor(r.req.ctx, r.row, &r.req)
 	if r.req.aggregate != nil {
-		cur = newAggregateBatchCursor(r.req.ctx, r.req.aggregate, cur)
+		if r.req.every > 0 {
+			cur = newWindowAggregateBatchCursor(r.req.ctx, r.req.aggregate, r.req.every, r.req.offset, cur)
+		} else {
+			cur = newAggregateBatchCursor(r.req.ctx, r.req.aggregate, cur)
+		}
 	}
 	return cur
 }
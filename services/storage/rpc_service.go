@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"strings"
 
@@ -41,6 +42,52 @@ func (r *rpcService) Hints(context.Context, *types.Empty) (*HintsResponse, error
 	return nil, errors.New("not implemented")
 }
 
+func (r *rpcService) Explain(ctx context.Context, req *ExplainRequest) (*ExplainResponse, error) {
+	if req.ReadRequest == nil {
+		return nil, errors.New("missing read request")
+	}
+	rr := req.ReadRequest
+
+	var wire opentracing.SpanContext
+	if len(rr.Trace) > 0 {
+		wire, _ = opentracing.GlobalTracer().Extract(opentracing.TextMap, opentracing.TextMapCarrier(rr.Trace))
+	}
+
+	span := opentracing.StartSpan("storage.explain", ext.RPCServerOption(wire))
+	defer span.Finish()
+
+	ext.DBInstance.Set(span, rr.Database)
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	pred := truncateString(PredicateToExprString(rr.Predicate))
+	span.
+		SetTag("predicate", pred).
+		SetTag("start", rr.TimestampRange.Start).
+		SetTag("end", rr.TimestampRange.End)
+
+	if r.loggingEnabled {
+		r.Logger.Info("explain",
+			zap.String("database", rr.Database),
+			zap.String("predicate", pred),
+			zap.Int64("start", rr.TimestampRange.Start),
+			zap.Int64("end", rr.TimestampRange.End),
+		)
+	}
+
+	res, err := r.Store.Explain(ctx, rr)
+	if err != nil {
+		r.Logger.Error("Store.Explain failed", zap.Error(err))
+		return nil, err
+	}
+
+	span.
+		SetTag("num_shards", res.Total.NumShards).
+		SetTag("num_series", res.Total.NumSeries).
+		SetTag("blocks_read", res.Total.BlocksRead)
+
+	return res, nil
+}
+
 func (r *rpcService) Read(req *ReadRequest, stream Storage_ReadServer) error {
 	// TODO(sgc): implement frameWriter that handles the details of streaming frames
 	var err error
@@ -77,7 +124,9 @@ func (r *rpcService) Read(req *ReadRequest, stream Storage_ReadServer) error {
 		SetTag("end", req.TimestampRange.End).
 		SetTag("desc", req.Descending).
 		SetTag("group_keys", groupKeys).
-		SetTag("aggregate", agg.String())
+		SetTag("aggregate", agg.String()).
+		SetTag("window_every", req.WindowEvery).
+		SetTag("window_offset", req.WindowOffset)
 
 	if r.loggingEnabled {
 		r.Logger.Info("request",
@@ -91,6 +140,8 @@ func (r *rpcService) Read(req *ReadRequest, stream Storage_ReadServer) error {
 			zap.Bool("desc", req.Descending),
 			zap.String("group_keys", groupKeys),
 			zap.String("aggregate", agg.String()),
+			zap.Int64("window_every", req.WindowEvery),
+			zap.Int64("window_offset", req.WindowOffset),
 		)
 	}
 
@@ -157,3 +208,159 @@ func (r *rpcService) Read(req *ReadRequest, stream Storage_ReadServer) error {
 
 	return nil
 }
+
+func (r *rpcService) TagKeys(req *TagKeysRequest, stream Storage_TagKeysServer) error {
+	span := opentracing.StartSpan("storage.tag_keys")
+	defer span.Finish()
+
+	ext.DBInstance.Set(span, req.Database)
+
+	// TODO(sgc): use yarpc stream.Context() once implemented
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+
+	pred := truncateString(PredicateToExprString(req.Predicate))
+	span.
+		SetTag("predicate", pred).
+		SetTag("start", req.TimestampRange.Start).
+		SetTag("end", req.TimestampRange.End)
+
+	if r.loggingEnabled {
+		r.Logger.Info("tag_keys",
+			zap.String("database", req.Database),
+			zap.String("predicate", pred),
+			zap.Int64("start", req.TimestampRange.Start),
+			zap.Int64("end", req.TimestampRange.End),
+		)
+	}
+
+	itr, err := r.Store.TagKeys(ctx, req)
+	if err != nil {
+		r.Logger.Error("Store.TagKeys failed", zap.Error(err))
+		return err
+	} else if itr == nil {
+		return nil
+	}
+	defer itr.Close()
+
+	return streamStringValues(stream, itr.Next)
+}
+
+func (r *rpcService) TagValues(req *TagValuesRequest, stream Storage_TagValuesServer) error {
+	span := opentracing.StartSpan("storage.tag_values")
+	defer span.Finish()
+
+	ext.DBInstance.Set(span, req.Database)
+
+	// TODO(sgc): use yarpc stream.Context() once implemented
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+
+	pred := truncateString(PredicateToExprString(req.Predicate))
+	span.
+		SetTag("predicate", pred).
+		SetTag("tag_key", req.TagKey).
+		SetTag("start", req.TimestampRange.Start).
+		SetTag("end", req.TimestampRange.End)
+
+	if r.loggingEnabled {
+		r.Logger.Info("tag_values",
+			zap.String("database", req.Database),
+			zap.String("predicate", pred),
+			zap.String("tag_key", req.TagKey),
+			zap.Int64("start", req.TimestampRange.Start),
+			zap.Int64("end", req.TimestampRange.End),
+		)
+	}
+
+	itr, err := r.Store.TagValues(ctx, req)
+	if err != nil {
+		r.Logger.Error("Store.TagValues failed", zap.Error(err))
+		return err
+	} else if itr == nil {
+		return nil
+	}
+	defer itr.Close()
+
+	return streamStringValues(stream, itr.Next)
+}
+
+// streamStringValues sends the values returned by next to stream, batching
+// up to batchSize values per response.
+func streamStringValues(stream interface {
+	Send(*StringValuesResponse) error
+}, next func() ([]byte, error)) error {
+	res := &StringValuesResponse{Values: make([][]byte, 0, batchSize)}
+	for {
+		v, err := next()
+		if err != nil {
+			return err
+		} else if v == nil {
+			break
+		}
+
+		res.Values = append(res.Values, v)
+		if len(res.Values) == batchSize {
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+			res.Values = res.Values[:0]
+		}
+	}
+
+	if len(res.Values) > 0 {
+		return stream.Send(res)
+	}
+	return nil
+}
+
+// Write receives batches of series data from stream until the client closes
+// its side of the stream, acknowledging each batch once it has been written.
+func (r *rpcService) Write(stream Storage_WriteServer) error {
+	span := opentracing.StartSpan("storage.write")
+	defer span.Finish()
+
+	// TODO(sgc): use yarpc stream.Context() once implemented
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+
+	var written, dropped uint64
+	defer func() {
+		span.
+			SetTag("points_written", written).
+			SetTag("points_dropped", dropped)
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		res := r.Store.Write(ctx, req)
+		written += res.PointsWritten
+		dropped += res.PointsDropped
+
+		if r.loggingEnabled {
+			r.Logger.Info("write",
+				zap.String("database", req.Database),
+				zap.Uint64("sequence", req.Sequence),
+				zap.Int("series", len(req.Series)),
+				zap.Uint64("points_written", res.PointsWritten),
+				zap.Uint64("points_dropped", res.PointsDropped),
+			)
+		}
+
+		if res.Error != "" {
+			r.Logger.Info("Store.Write dropped points",
+				zap.String("database", req.Database),
+				zap.Uint64("sequence", req.Sequence),
+				zap.Uint64("points_dropped", res.PointsDropped),
+				zap.String("reason", res.Error),
+			)
+		}
+
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
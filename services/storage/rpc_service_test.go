@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRPCService_Explain(t *testing.T) {
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustWritePoints(1,
+		`cpu,host=a value=1,idle=2 1000000000`,
+		`mem,host=a free=3 1000000000`,
+	)
+	s.MustSnapshot(1)
+
+	r := &rpcService{Store: s.Store, Logger: zap.NewNop()}
+
+	t.Run("read request", func(t *testing.T) {
+		res, err := r.Explain(context.Background(), &ExplainRequest{ReadRequest: &ReadRequest{Database: "db0"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got, exp := len(res.Shards), 1; got != exp {
+			t.Fatalf("unexpected number of shards; got=%d, exp=%d", got, exp)
+		}
+
+		exp := ReadCost{NumShards: 1, NumSeries: 2, NumFiles: 1, BlocksRead: 3}
+		if !equalReadCost(res.Total, exp) {
+			t.Errorf("unexpected total cost; got=%+v, exp=%+v", res.Total, exp)
+		} else if res.Total.BlockSize == 0 {
+			t.Error("expected block size")
+		}
+	})
+
+	t.Run("missing read request", func(t *testing.T) {
+		if _, err := r.Explain(context.Background(), &ExplainRequest{}); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
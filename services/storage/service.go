@@ -3,6 +3,7 @@ package storage
 import (
 	"time"
 
+	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/tsdb"
 	"go.uber.org/zap"
@@ -11,6 +12,18 @@ import (
 type StorageMetaClient interface {
 	Database(name string) *meta.DatabaseInfo
 	ShardGroupsByTimeRange(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	AdminUserExists() bool
+	Authenticate(username, password string) (meta.User, error)
+}
+
+// PointsWriter writes the points received by the Write RPC.
+type PointsWriter interface {
+	WritePointsPrivileged(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error
+}
+
+// WriteAuthorizer authorizes the users of the Write RPC.
+type WriteAuthorizer interface {
+	AuthorizeWrite(username, database string) error
 }
 
 // Service manages the listener and handler for an HTTP endpoint.
@@ -18,11 +31,15 @@ type Service struct {
 	addr           string
 	yarpc          *yarpcServer
 	loggingEnabled bool
+	writeEnabled   bool
+	authEnabled    bool
 	logger         *zap.Logger
 
-	Store      *Store
-	TSDBStore  *tsdb.Store
-	MetaClient StorageMetaClient
+	Store           *Store
+	TSDBStore       *tsdb.Store
+	MetaClient      StorageMetaClient
+	PointsWriter    PointsWriter
+	WriteAuthorizer WriteAuthorizer
 }
 
 // NewService returns a new instance of Service.
@@ -30,6 +47,8 @@ func NewService(c Config) *Service {
 	s := &Service{
 		addr:           c.BindAddress,
 		loggingEnabled: c.LogEnabled,
+		writeEnabled:   c.WriteEnabled,
+		authEnabled:    c.AuthEnabled,
 		logger:         zap.NewNop(),
 	}
 
@@ -48,6 +67,10 @@ func (s *Service) Open() error {
 	store := NewStore()
 	store.TSDBStore = s.TSDBStore
 	store.MetaClient = s.MetaClient
+	store.PointsWriter = s.PointsWriter
+	store.WriteAuthorizer = s.WriteAuthorizer
+	store.WriteEnabled = s.writeEnabled
+	store.AuthEnabled = s.authEnabled
 	store.Logger = s.logger
 
 	yarpc := &yarpcServer{
@@ -16,6 +16,14 @@ It has these top-level messages:
 	CapabilitiesResponse
 	HintsResponse
 	TimestampRange
+	ExplainRequest
+	ExplainResponse
+	ReadCost
+	TagKeysRequest
+	TagValuesRequest
+	StringValuesResponse
+	WriteRequest
+	WriteResponse
 	Node
 	Predicate
 */
@@ -55,6 +63,14 @@ type StorageClient interface {
 	// Capabilities returns a map of keys and values identifying the capabilities supported by the storage engine
 	Capabilities(ctx context.Context, in *google_protobuf1.Empty) (*CapabilitiesResponse, error)
 	Hints(ctx context.Context, in *google_protobuf1.Empty) (*HintsResponse, error)
+	// Explain describes the costs associated with executing a given Read request
+	Explain(ctx context.Context, in *ExplainRequest) (*ExplainResponse, error)
+	// TagKeys performs a read operation for tag keys
+	TagKeys(ctx context.Context, in *TagKeysRequest) (Storage_TagKeysClient, error)
+	// TagValues performs a read operation for tag values
+	TagValues(ctx context.Context, in *TagValuesRequest) (Storage_TagValuesClient, error)
+	// Write writes batches of series data, acknowledging each batch once it has been written
+	Write(ctx context.Context) (Storage_WriteClient, error)
 }
 
 type storageClient struct {
@@ -112,6 +128,104 @@ func (c *storageClient) Hints(ctx context.Context, in *google_protobuf1.Empty) (
 	return out, nil
 }
 
+func (c *storageClient) Explain(ctx context.Context, in *ExplainRequest) (*ExplainResponse, error) {
+	out := new(ExplainResponse)
+	err := yarpc.Invoke(ctx, 0x0003, in, out, c.cc)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) TagKeys(ctx context.Context, in *TagKeysRequest) (Storage_TagKeysClient, error) {
+	stream, err := yarpc.NewClientStream(ctx, &_Storage_serviceDesc.Streams[1], c.cc, 0x0004)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageTagKeysClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_TagKeysClient interface {
+	Recv() (*StringValuesResponse, error)
+	yarpc.ClientStream
+}
+
+type storageTagKeysClient struct {
+	yarpc.ClientStream
+}
+
+func (x *storageTagKeysClient) Recv() (*StringValuesResponse, error) {
+	m := new(StringValuesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) TagValues(ctx context.Context, in *TagValuesRequest) (Storage_TagValuesClient, error) {
+	stream, err := yarpc.NewClientStream(ctx, &_Storage_serviceDesc.Streams[2], c.cc, 0x0005)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageTagValuesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_TagValuesClient interface {
+	Recv() (*StringValuesResponse, error)
+	yarpc.ClientStream
+}
+
+type storageTagValuesClient struct {
+	yarpc.ClientStream
+}
+
+func (x *storageTagValuesClient) Recv() (*StringValuesResponse, error) {
+	m := new(StringValuesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) Write(ctx context.Context) (Storage_WriteClient, error) {
+	stream, err := yarpc.NewClientStream(ctx, &_Storage_serviceDesc.Streams[3], c.cc, 0x0006)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageWriteClient{stream}
+	return x, nil
+}
+
+type Storage_WriteClient interface {
+	Send(*WriteRequest) error
+	Recv() (*WriteResponse, error)
+	yarpc.ClientStream
+}
+
+type storageWriteClient struct {
+	yarpc.ClientStream
+}
+
+func (x *storageWriteClient) Send(m *WriteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storageWriteClient) Recv() (*WriteResponse, error) {
+	m := new(WriteResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Storage service
 
 type StorageServer interface {
@@ -120,6 +234,14 @@ type StorageServer interface {
 	// Capabilities returns a map of keys and values identifying the capabilities supported by the storage engine
 	Capabilities(context.Context, *google_protobuf1.Empty) (*CapabilitiesResponse, error)
 	Hints(context.Context, *google_protobuf1.Empty) (*HintsResponse, error)
+	// Explain describes the costs associated with executing a given Read request
+	Explain(context.Context, *ExplainRequest) (*ExplainResponse, error)
+	// TagKeys performs a read operation for tag keys
+	TagKeys(*TagKeysRequest, Storage_TagKeysServer) error
+	// TagValues performs a read operation for tag values
+	TagValues(*TagValuesRequest, Storage_TagValuesServer) error
+	// Write writes batches of series data, acknowledging each batch once it has been written
+	Write(Storage_WriteServer) error
 }
 
 func RegisterStorageServer(s *yarpc.Server, srv StorageServer) {
@@ -163,6 +285,82 @@ func _Storage_Hints_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return srv.(StorageServer).Hints(ctx, in)
 }
 
+func _Storage_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return srv.(StorageServer).Explain(ctx, in)
+}
+
+func _Storage_TagKeys_Handler(srv interface{}, stream yarpc.ServerStream) error {
+	m := new(TagKeysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).TagKeys(m, &storageTagKeysServer{stream})
+}
+
+type Storage_TagKeysServer interface {
+	Send(*StringValuesResponse) error
+	yarpc.ServerStream
+}
+
+type storageTagKeysServer struct {
+	yarpc.ServerStream
+}
+
+func (x *storageTagKeysServer) Send(m *StringValuesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_TagValues_Handler(srv interface{}, stream yarpc.ServerStream) error {
+	m := new(TagValuesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).TagValues(m, &storageTagValuesServer{stream})
+}
+
+type Storage_TagValuesServer interface {
+	Send(*StringValuesResponse) error
+	yarpc.ServerStream
+}
+
+type storageTagValuesServer struct {
+	yarpc.ServerStream
+}
+
+func (x *storageTagValuesServer) Send(m *StringValuesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_Write_Handler(srv interface{}, stream yarpc.ServerStream) error {
+	return srv.(StorageServer).Write(&storageWriteServer{stream})
+}
+
+type Storage_WriteServer interface {
+	Send(*WriteResponse) error
+	Recv() (*WriteRequest, error)
+	yarpc.ServerStream
+}
+
+type storageWriteServer struct {
+	yarpc.ServerStream
+}
+
+func (x *storageWriteServer) Send(m *WriteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storageWriteServer) Recv() (*WriteRequest, error) {
+	m := new(WriteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Storage_serviceDesc = yarpc.ServiceDesc{
 	ServiceName: "storage.Storage",
 	Index:       0,
@@ -178,6 +376,11 @@ var _Storage_serviceDesc = yarpc.ServiceDesc{
 			Index:      2,
 			Handler:    _Storage_Hints_Handler,
 		},
+		{
+			MethodName: "Explain",
+			Index:      3,
+			Handler:    _Storage_Explain_Handler,
+		},
 	},
 	Streams: []yarpc.StreamDesc{
 		{
@@ -186,6 +389,25 @@ var _Storage_serviceDesc = yarpc.ServiceDesc{
 			Handler:       _Storage_Read_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "TagKeys",
+			Index:         4,
+			Handler:       _Storage_TagKeys_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TagValues",
+			Index:         5,
+			Handler:       _Storage_TagValues_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Write",
+			Index:         6,
+			Handler:       _Storage_Write_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "storage.proto",
 }
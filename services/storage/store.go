@@ -3,20 +3,33 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/escape"
+	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
 	"go.uber.org/zap"
 )
 
 type Store struct {
-	TSDBStore  *tsdb.Store
-	MetaClient StorageMetaClient
-	Logger     *zap.Logger
+	TSDBStore       *tsdb.Store
+	MetaClient      StorageMetaClient
+	PointsWriter    PointsWriter
+	WriteAuthorizer WriteAuthorizer
+	Logger          *zap.Logger
+
+	// WriteEnabled specifies whether Write accepts points.
+	WriteEnabled bool
+
+	// AuthEnabled specifies whether Write requires the credentials of a user
+	// with write privilege on the database.
+	AuthEnabled bool
 }
 
 func NewStore() *Store {
@@ -29,8 +42,192 @@ func (s *Store) WithLogger(log *zap.Logger) {
 }
 
 func (s *Store) Read(ctx context.Context, req *ReadRequest) (*ResultSet, error) {
-	database, rp := req.Database, ""
+	if err := validateReadRequest(req); err != nil {
+		return nil, err
+	}
+
+	start, end := timestampRange(req.TimestampRange)
+	shardIDs, err := s.findShardIDs(req.Database, req.Descending, start, end)
+	if err != nil {
+		return nil, err
+	} else if len(shardIDs) == 0 {
+		return nil, nil
+	}
+
+	var cur seriesCursor
+	if ic, err := newIndexSeriesCursor(ctx, req, s.TSDBStore.Shards(shardIDs)); err != nil {
+		return nil, err
+	} else if ic == nil {
+		return nil, nil
+	} else {
+		cur = ic
+	}
+
+	if len(req.Grouping) > 0 {
+		cur = newGroupSeriesCursor(ctx, cur, req.Grouping)
+	}
+
+	if req.SeriesLimit > 0 || req.SeriesOffset > 0 {
+		cur = newLimitSeriesCursor(ctx, cur, req.SeriesLimit, req.SeriesOffset)
+	}
+
+	return &ResultSet{
+		req: readRequest{
+			ctx:       ctx,
+			start:     start,
+			end:       end,
+			asc:       !req.Descending,
+			limit:     req.PointsLimit,
+			aggregate: req.Aggregate,
+			every:     req.WindowEvery,
+			offset:    req.WindowOffset,
+		},
+		cur: cur,
+	}, nil
+}
+
+// Explain estimates the cost of executing req, reporting the cost of each
+// shard accessed by the request as well as the combined total.
+func (s *Store) Explain(ctx context.Context, req *ReadRequest) (*ExplainResponse, error) {
+	if err := validateReadRequest(req); err != nil {
+		return nil, err
+	}
+
+	res := &ExplainResponse{}
+
+	start, end := timestampRange(req.TimestampRange)
+	shardIDs, err := s.findShardIDs(req.Database, req.Descending, start, end)
+	if err != nil {
+		return nil, err
+	} else if len(shardIDs) == 0 {
+		return res, nil
+	}
+
+	shards := s.TSDBStore.Shards(shardIDs)
+
+	// Determine the fields read for each measurement matching the predicate.
+	ic, err := newIndexSeriesCursor(ctx, req, shards)
+	if err != nil {
+		return nil, err
+	} else if ic == nil {
+		return res, nil
+	}
+
+	fields := make(map[string]map[string]struct{})
+	for row := ic.Next(); row != nil; row = ic.Next() {
+		m := fields[row.measurement]
+		if m == nil {
+			m = make(map[string]struct{})
+			fields[row.measurement] = m
+		}
+		m[row.field] = struct{}{}
+	}
+	ic.Close()
+
+	if err := ic.Err(); err != nil {
+		return nil, err
+	}
+
+	var cond influxql.Expr
+	if root := req.Predicate.GetRoot(); root != nil {
+		if cond, err = NodeToExpr(root); err != nil {
+			return nil, err
+		}
+
+		cond = influxql.Reduce(RewriteExprRemoveFieldKeyAndValue(cond), nil)
+		if isBooleanLiteral(cond) {
+			cond = nil
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	opts := make([]query.IteratorOptions, len(names))
+	for i, name := range names {
+		aux := make([]influxql.VarRef, 0, len(fields[name]))
+		for f := range fields[name] {
+			aux = append(aux, influxql.VarRef{Val: f})
+		}
+		sort.Sort(influxql.VarRefs(aux))
+
+		opts[i] = query.IteratorOptions{
+			Condition: cond,
+			Aux:       aux,
+			StartTime: start,
+			EndTime:   end,
+			Ascending: !req.Descending,
+		}
+	}
+
+	res.Shards = make([]ExplainResponse_ShardCost, 0, len(shards))
+	for _, sh := range shards {
+		// the measurements share the files of the shard, which are only counted once
+		var cost query.IteratorCost
+		files := make(map[string]struct{})
+		for i, name := range names {
+			c, err := sh.IteratorCostFiles(name, opts[i], files)
+			if err != nil {
+				return nil, err
+			}
+			cost = cost.Combine(c)
+		}
+
+		if cost.NumShards == 0 {
+			// none of the measurements exist in this shard
+			continue
+		}
+
+		// each measurement reports the shard, but it is only accessed once
+		cost.NumShards = 1
+
+		rc := newReadCost(cost)
+		res.Shards = append(res.Shards, ExplainResponse_ShardCost{ShardID: sh.ID(), Cost: rc})
+		res.Total = res.Total.combine(rc)
+	}
+
+	return res, nil
+}
+
+// validateReadRequest returns an error if req contains invalid aggregate or window options.
+func validateReadRequest(req *ReadRequest) error {
+	if req.Aggregate != nil {
+		if _, ok := Aggregate_AggregateType_name[int32(req.Aggregate.Type)]; !ok {
+			return errors.New("invalid aggregate")
+		}
+	}
+
+	if req.WindowEvery < 0 {
+		return errors.New("invalid window")
+	} else if req.WindowEvery > 0 && (req.Aggregate == nil || req.Aggregate.Type == AggregateTypeNone) {
+		return errors.New("window requires an aggregate")
+	}
+
+	return nil
+}
 
+// timestampRange returns the bounds of tr, substituting the minimum and
+// maximum timestamps for unspecified bounds.
+func timestampRange(tr TimestampRange) (start, end int64) {
+	start, end = models.MinNanoTime, models.MaxNanoTime
+	if tr.Start > 0 {
+		start = tr.Start
+	}
+
+	if tr.End > 0 {
+		end = tr.End
+	}
+	return start, end
+}
+
+// findShardIDs returns the IDs of the shards for the database and time range,
+// ordered by time. The database may be qualified with a retention policy
+// using the form "db/rp"; otherwise, the default retention policy is used.
+func (s *Store) findShardIDs(database string, desc bool, start, end int64) ([]uint64, error) {
+	rp := ""
 	if p := strings.IndexByte(database, '/'); p > -1 {
 		database, rp = database[:p], database[p+1:]
 	}
@@ -49,15 +246,6 @@ func (s *Store) Read(ctx context.Context, req *ReadRequest) (*ResultSet, error)
 		return nil, errors.New("invalid retention policy")
 	}
 
-	var start, end = models.MinNanoTime, models.MaxNanoTime
-	if req.TimestampRange.Start > 0 {
-		start = req.TimestampRange.Start
-	}
-
-	if req.TimestampRange.End > 0 {
-		end = req.TimestampRange.End
-	}
-
 	groups, err := s.MetaClient.ShardGroupsByTimeRange(database, rp, time.Unix(0, start), time.Unix(0, end))
 	if err != nil {
 		return nil, err
@@ -67,7 +255,7 @@ func (s *Store) Read(ctx context.Context, req *ReadRequest) (*ResultSet, error)
 		return nil, nil
 	}
 
-	if req.Descending {
+	if desc {
 		sort.Sort(sort.Reverse(meta.ShardGroupInfos(groups)))
 	} else {
 		sort.Sort(meta.ShardGroupInfos(groups))
@@ -79,33 +267,288 @@ func (s *Store) Read(ctx context.Context, req *ReadRequest) (*ResultSet, error)
 			shardIDs = append(shardIDs, si.ID)
 		}
 	}
+	return shardIDs, nil
+}
 
-	var cur seriesCursor
-	if ic, err := newIndexSeriesCursor(ctx, req, s.TSDBStore.Shards(shardIDs)); err != nil {
+func newReadCost(c query.IteratorCost) ReadCost {
+	return ReadCost{
+		NumShards:    c.NumShards,
+		NumSeries:    c.NumSeries,
+		CachedValues: c.CachedValues,
+		NumFiles:     c.NumFiles,
+		BlocksRead:   c.BlocksRead,
+		BlockSize:    c.BlockSize,
+	}
+}
+
+func (c ReadCost) combine(other ReadCost) ReadCost {
+	return ReadCost{
+		NumShards:    c.NumShards + other.NumShards,
+		NumSeries:    c.NumSeries + other.NumSeries,
+		CachedValues: c.CachedValues + other.CachedValues,
+		NumFiles:     c.NumFiles + other.NumFiles,
+		BlocksRead:   c.BlocksRead + other.BlocksRead,
+		BlockSize:    c.BlockSize + other.BlockSize,
+	}
+}
+
+// TagKeys returns an iterator over the sorted tag keys of the series matching
+// the predicate of req. As the series of a Read response are identified by the
+// _measurement and _field tags, these keys are included in the results. If req
+// specifies a time range, only series with values in the range are included.
+// The time range of a series is determined from the time range of its blocks,
+// so a series with a block spanning the range is included.
+func (s *Store) TagKeys(ctx context.Context, req *TagKeysRequest) (tsdb.TagKeyIterator, error) {
+	start, end := timestampRange(req.TimestampRange)
+	shardIDs, err := s.findShardIDs(req.Database, false, start, end)
+	if err != nil {
 		return nil, err
-	} else if ic == nil {
+	} else if len(shardIDs) == 0 {
+		return nil, nil
+	}
+
+	cond, ok, err := tagPredicateExpr(req.Predicate)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	// only report the _measurement and _field keys if there are matching series
+	if names, err := s.TSDBStore.TagValueIterator(shardIDs, "_name", cond, start, end); err != nil {
+		return nil, err
+	} else if names == nil {
 		return nil, nil
 	} else {
-		cur = ic
+		name, err := names.Next()
+		names.Close()
+		if err != nil || name == nil {
+			return nil, err
+		}
 	}
 
-	if len(req.Grouping) > 0 {
-		cur = newGroupSeriesCursor(ctx, cur, req.Grouping)
+	itr, err := s.TSDBStore.TagKeyIterator(shardIDs, cond, start, end)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.SeriesLimit > 0 || req.SeriesOffset > 0 {
-		cur = newLimitSeriesCursor(ctx, cur, req.SeriesLimit, req.SeriesOffset)
+	keys := tsdb.NewTagKeySliceIterator([][]byte{fieldKey, measurementKey})
+	if itr == nil {
+		return keys, nil
 	}
+	return tsdb.MergeTagKeyIterators(keys, itr), nil
+}
 
-	return &ResultSet{
-		req: readRequest{
-			ctx:       ctx,
-			start:     start,
-			end:       end,
-			asc:       !req.Descending,
-			limit:     req.PointsLimit,
-			aggregate: req.Aggregate,
-		},
-		cur: cur,
-	}, nil
+// TagValues returns an iterator over the sorted values of the tag key of req
+// for the series matching the predicate of req. The values of the _measurement
+// and _field keys are the names of the matching measurements and their fields.
+// If req specifies a time range, only series with values in the range are
+// included, as determined by TagKeys.
+func (s *Store) TagValues(ctx context.Context, req *TagValuesRequest) (tsdb.TagValueIterator, error) {
+	if req.TagKey == "" {
+		return nil, errors.New("missing tag key")
+	}
+
+	start, end := timestampRange(req.TimestampRange)
+	shardIDs, err := s.findShardIDs(req.Database, false, start, end)
+	if err != nil {
+		return nil, err
+	} else if len(shardIDs) == 0 {
+		return nil, nil
+	}
+
+	cond, ok, err := tagPredicateExpr(req.Predicate)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	switch req.TagKey {
+	case string(measurementKey):
+		return s.TSDBStore.TagValueIterator(shardIDs, "_name", cond, start, end)
+	case string(fieldKey):
+		return s.TSDBStore.FieldKeyIterator(shardIDs, cond, start, end)
+	default:
+		return s.TSDBStore.TagValueIterator(shardIDs, req.TagKey, cond, start, end)
+	}
+}
+
+// tagPredicateExpr converts the predicate to an expression which may be
+// evaluated against the index. Field keys and values are not indexed, so
+// comparisons against them are removed. ok is false if the predicate
+// cannot match any series.
+func tagPredicateExpr(pred *Predicate) (expr influxql.Expr, ok bool, err error) {
+	root := pred.GetRoot()
+	if root == nil {
+		return nil, true, nil
+	}
+
+	if expr, err = NodeToExpr(root); err != nil {
+		return nil, false, err
+	}
+
+	expr = influxql.Reduce(RewriteExprRemoveFieldKeyAndValue(expr), nil)
+	if lit, isLit := expr.(*influxql.BooleanLiteral); isLit {
+		return nil, lit.Val, nil
+	}
+	return expr, true, nil
+}
+
+// Write writes the series batches of req using the PointsWriter. Batches which
+// cannot be converted to points are dropped; the response reports the number
+// of points written and dropped along with the reason for any dropped points.
+func (s *Store) Write(ctx context.Context, req *WriteRequest) *WriteResponse {
+	res := &WriteResponse{Sequence: req.Sequence}
+
+	database, rp := req.Database, ""
+	if p := strings.IndexByte(database, '/'); p > -1 {
+		database, rp = database[:p], database[p+1:]
+	}
+
+	if err := s.authorizeWrite(req, database); err != nil {
+		res.PointsDropped = uint64(seriesBatchesLen(req.Series))
+		res.Error = err.Error()
+		return res
+	}
+
+	var reason string
+	points := make([]models.Point, 0, seriesBatchesLen(req.Series))
+	for i := range req.Series {
+		b := &req.Series[i]
+		pts, err := pointsFromSeriesBatch(b)
+		if err != nil {
+			res.PointsDropped += uint64(len(b.Timestamps))
+			if reason == "" {
+				reason = err.Error()
+			}
+			continue
+		}
+		points = append(points, pts...)
+	}
+
+	if len(points) > 0 {
+		err := s.PointsWriter.WritePointsPrivileged(database, rp, models.ConsistencyLevelAny, points)
+		if pwErr, ok := err.(tsdb.PartialWriteError); ok {
+			res.PointsWritten = uint64(len(points) - pwErr.Dropped)
+			res.PointsDropped += uint64(pwErr.Dropped)
+			reason = pwErr.Error()
+		} else if err != nil {
+			res.PointsDropped += uint64(len(points))
+			reason = err.Error()
+		} else {
+			res.PointsWritten = uint64(len(points))
+		}
+	}
+
+	res.Error = reason
+	return res
+}
+
+// authorizeWrite returns an error if writes are disabled or, when
+// authentication is enabled, the credentials of req do not belong to a user
+// with write privilege on database. Like the HTTP write endpoint, no
+// credentials are checked until an admin user exists, but a user is still
+// required.
+func (s *Store) authorizeWrite(req *WriteRequest, database string) error {
+	if !s.WriteEnabled {
+		return errors.New("write is disabled")
+	} else if !s.AuthEnabled {
+		return nil
+	}
+
+	var user meta.User
+	if s.MetaClient.AdminUserExists() {
+		if req.Username == "" {
+			return errors.New("username required")
+		}
+
+		u, err := s.MetaClient.Authenticate(req.Username, req.Password)
+		if err != nil {
+			return errors.New("authorization failed")
+		}
+		user = u
+	}
+
+	if user == nil {
+		return fmt.Errorf("user is required to write to database %q", database)
+	}
+
+	if err := s.WriteAuthorizer.AuthorizeWrite(user.ID(), database); err != nil {
+		return fmt.Errorf("%q user is not authorized to write to database %q", user.ID(), database)
+	}
+	return nil
+}
+
+// seriesBatchesLen returns the total number of values in batches.
+func seriesBatchesLen(batches []WriteRequest_SeriesBatch) int {
+	n := 0
+	for i := range batches {
+		n += len(batches[i].Timestamps)
+	}
+	return n
+}
+
+// pointsFromSeriesBatch converts b to a point per timestamp. An error is
+// returned if the key or field are invalid or the number of values of the
+// batch's data type does not match the number of timestamps.
+func pointsFromSeriesBatch(b *WriteRequest_SeriesBatch) ([]models.Point, error) {
+	if len(b.Key) == 0 {
+		return nil, errors.New("missing series key")
+	} else if len(b.Field) == 0 {
+		return nil, fmt.Errorf("missing field: series=%q", b.Key)
+	}
+
+	var n int
+	switch b.DataType {
+	case DataTypeFloat:
+		n = len(b.FloatValues)
+	case DataTypeInteger:
+		n = len(b.IntegerValues)
+	case DataTypeUnsigned:
+		n = len(b.UnsignedValues)
+	case DataTypeBoolean:
+		n = len(b.BooleanValues)
+	case DataTypeString:
+		n = len(b.StringValues)
+	default:
+		return nil, fmt.Errorf("invalid data type: series=%q type=%d", b.Key, b.DataType)
+	}
+
+	if n != len(b.Timestamps) {
+		return nil, fmt.Errorf("number of values does not match number of timestamps: series=%q field=%q values=%d timestamps=%d",
+			b.Key, b.Field, n, len(b.Timestamps))
+	}
+
+	name, tags := models.ParseKeyBytes(b.Key)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("missing measurement: series=%q", b.Key)
+	}
+
+	// series keys are escaped, whereas NewPoint expects unescaped tags
+	utags := make(models.Tags, len(tags))
+	for i, t := range tags {
+		utags[i] = models.NewTag(escape.Unescape(t.Key), escape.Unescape(t.Value))
+	}
+
+	field := string(b.Field)
+	points := make([]models.Point, len(b.Timestamps))
+	for i, ts := range b.Timestamps {
+		var v interface{}
+		switch b.DataType {
+		case DataTypeFloat:
+			v = b.FloatValues[i]
+		case DataTypeInteger:
+			v = b.IntegerValues[i]
+		case DataTypeUnsigned:
+			v = b.UnsignedValues[i]
+		case DataTypeBoolean:
+			v = b.BooleanValues[i]
+		case DataTypeString:
+			v = b.StringValues[i]
+		}
+
+		pt, err := models.NewPoint(string(name), utags, models.Fields{field: v}, time.Unix(0, ts))
+		if err != nil {
+			return nil, fmt.Errorf("invalid point: series=%q field=%q: %v", b.Key, b.Field, err)
+		}
+		points[i] = pt
+	}
+	return points, nil
 }
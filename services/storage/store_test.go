@@ -0,0 +1,630 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestPointsFromSeriesBatch(t *testing.T) {
+	b := &WriteRequest_SeriesBatch{
+		Key:         []byte(`cpu\ load,host=a\,b,region=west`),
+		Field:       []byte("value"),
+		DataType:    DataTypeFloat,
+		Timestamps:  []int64{10, 20},
+		FloatValues: []float64{1.5, 2.5},
+	}
+
+	points, err := pointsFromSeriesBatch(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, p := range points {
+		got = append(got, p.String())
+	}
+	exp := []string{
+		`cpu\ load,host=a\,b,region=west value=1.5 10`,
+		`cpu\ load,host=a\,b,region=west value=2.5 20`,
+	}
+	if !cmp.Equal(got, exp) {
+		t.Errorf("unexpected points; -got/+exp\n%s", cmp.Diff(got, exp))
+	}
+}
+
+func TestPointsFromSeriesBatch_DataTypes(t *testing.T) {
+	cases := []struct {
+		n   string
+		b   WriteRequest_SeriesBatch
+		exp interface{}
+	}{
+		{
+			n:   "integer",
+			b:   WriteRequest_SeriesBatch{DataType: DataTypeInteger, IntegerValues: []int64{-5}},
+			exp: int64(-5),
+		},
+		{
+			n:   "unsigned",
+			b:   WriteRequest_SeriesBatch{DataType: DataTypeUnsigned, UnsignedValues: []uint64{5}},
+			exp: uint64(5),
+		},
+		{
+			n:   "boolean",
+			b:   WriteRequest_SeriesBatch{DataType: DataTypeBoolean, BooleanValues: []bool{true}},
+			exp: true,
+		},
+		{
+			n:   "string",
+			b:   WriteRequest_SeriesBatch{DataType: DataTypeString, StringValues: []string{"foo"}},
+			exp: "foo",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			tc.b.Key = []byte("m0,tag0=val0")
+			tc.b.Field = []byte("f0")
+			tc.b.Timestamps = []int64{100}
+
+			points, err := pointsFromSeriesBatch(&tc.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if len(points) != 1 {
+				t.Fatalf("unexpected number of points; got=%d, exp=1", len(points))
+			}
+
+			fields, err := points[0].Fields()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := fields["f0"]; got != tc.exp {
+				t.Errorf("unexpected value; got=%#v, exp=%#v", got, tc.exp)
+			}
+			if got, exp := points[0].Tags(), models.NewTags(map[string]string{"tag0": "val0"}); !got.Equal(exp) {
+				t.Errorf("unexpected tags; got=%v, exp=%v", got, exp)
+			}
+		})
+	}
+}
+
+func TestPointsFromSeriesBatch_Invalid(t *testing.T) {
+	cases := []struct {
+		n string
+		b WriteRequest_SeriesBatch
+	}{
+		{
+			n: "missing key",
+			b: WriteRequest_SeriesBatch{Field: []byte("f0"), Timestamps: []int64{1}, FloatValues: []float64{1}},
+		},
+		{
+			n: "missing field",
+			b: WriteRequest_SeriesBatch{Key: []byte("m0"), Timestamps: []int64{1}, FloatValues: []float64{1}},
+		},
+		{
+			n: "mismatched values",
+			b: WriteRequest_SeriesBatch{Key: []byte("m0"), Field: []byte("f0"), Timestamps: []int64{1, 2}, FloatValues: []float64{1}},
+		},
+		{
+			n: "values in wrong column",
+			b: WriteRequest_SeriesBatch{Key: []byte("m0"), Field: []byte("f0"), DataType: DataTypeInteger, Timestamps: []int64{1}, FloatValues: []float64{1}},
+		},
+		{
+			n: "invalid data type",
+			b: WriteRequest_SeriesBatch{Key: []byte("m0"), Field: []byte("f0"), DataType: 10},
+		},
+		{
+			n: "invalid time",
+			b: WriteRequest_SeriesBatch{Key: []byte("m0"), Field: []byte("f0"), Timestamps: []int64{models.MaxNanoTime + 1}, FloatValues: []float64{1}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			if _, err := pointsFromSeriesBatch(&tc.b); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestStore_Write_Authorize(t *testing.T) {
+	cases := []struct {
+		n            string
+		writeEnabled bool
+		authEnabled  bool
+		adminExists  bool
+		username     string
+		password     string
+		err          string
+	}{
+		{
+			n:   "write disabled",
+			err: "write is disabled",
+		},
+		{
+			n:            "auth disabled",
+			writeEnabled: true,
+		},
+		{
+			n:            "no admin user",
+			writeEnabled: true,
+			authEnabled:  true,
+			err:          `user is required to write to database "db0"`,
+		},
+		{
+			n:            "no username",
+			writeEnabled: true,
+			authEnabled:  true,
+			adminExists:  true,
+			err:          "username required",
+		},
+		{
+			n:            "wrong password",
+			writeEnabled: true,
+			authEnabled:  true,
+			adminExists:  true,
+			username:     "writer",
+			password:     "bad",
+			err:          "authorization failed",
+		},
+		{
+			n:            "no write privilege",
+			writeEnabled: true,
+			authEnabled:  true,
+			adminExists:  true,
+			username:     "reader",
+			password:     "pass",
+			err:          `"reader" user is not authorized to write to database "db0"`,
+		},
+		{
+			n:            "write privilege",
+			writeEnabled: true,
+			authEnabled:  true,
+			adminExists:  true,
+			username:     "writer",
+			password:     "pass",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			var written int
+			s := NewStore()
+			s.WriteEnabled, s.AuthEnabled = tc.writeEnabled, tc.authEnabled
+			s.PointsWriter = &pointsWriterMock{
+				WritePointsPrivilegedFn: func(database, rp string, _ models.ConsistencyLevel, points []models.Point) error {
+					written += len(points)
+					return nil
+				},
+			}
+			s.MetaClient = &internal.MetaClientMock{
+				AdminUserExistsFn: func() bool { return tc.adminExists },
+				AuthenticateFn: func(username, password string) (meta.User, error) {
+					if password != "pass" {
+						return nil, meta.ErrAuthenticate
+					}
+					return &meta.UserInfo{Name: username}, nil
+				},
+			}
+			s.WriteAuthorizer = writeAuthorizerFunc(func(username, database string) error {
+				if username != "writer" {
+					return &meta.ErrAuthorize{Database: database}
+				}
+				return nil
+			})
+
+			req := &WriteRequest{
+				Database: "db0",
+				Username: tc.username,
+				Password: tc.password,
+				Series: []WriteRequest_SeriesBatch{
+					{Key: []byte("m0"), Field: []byte("f0"), Timestamps: []int64{1, 2}, FloatValues: []float64{1, 2}},
+				},
+			}
+			res := s.Write(context.Background(), req)
+			if res.Error != tc.err {
+				t.Fatalf("unexpected error; got %q, exp %q", res.Error, tc.err)
+			}
+
+			exp := 2
+			if tc.err != "" {
+				exp = 0
+			}
+			if written != exp || res.PointsWritten != uint64(exp) || res.PointsDropped != uint64(2-exp) {
+				t.Fatalf("unexpected points; written=%d response=%+v", written, res)
+			}
+		})
+	}
+}
+
+func TestStore_Explain(t *testing.T) {
+	s := MustOpenStore()
+	defer s.Close()
+
+	// shard 1 holds the blocks of all series in a single TSM file
+	s.MustWritePoints(1,
+		`cpu,host=a value=1,idle=2 1000000000`,
+		`cpu,host=b value=3,idle=4 2000000000`,
+		`mem,host=a free=5 1000000000`,
+	)
+	s.MustSnapshot(1)
+	s.MustWritePoints(1, `cpu,host=b value=6 3000000000`)
+
+	s.MustWritePoints(2, `cpu,host=a value=7,idle=8 12000000000`)
+	s.MustSnapshot(2)
+
+	t.Run("all shards", func(t *testing.T) {
+		res, err := s.Explain(context.Background(), &ReadRequest{Database: "db0"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got, exp := len(res.Shards), 2; got != exp {
+			t.Fatalf("unexpected number of shards; got=%d, exp=%d", got, exp)
+		}
+
+		// the blocks of both measurements are read from the same file of shard 1
+		exp := ReadCost{NumShards: 1, NumSeries: 3, CachedValues: 1, NumFiles: 1, BlocksRead: 5}
+		if got := res.Shards[0]; got.ShardID != 1 || !equalReadCost(got.Cost, exp) {
+			t.Errorf("unexpected cost of shard 1; got=%+v, exp=%+v", got.Cost, exp)
+		}
+
+		exp = ReadCost{NumShards: 1, NumSeries: 1, NumFiles: 1, BlocksRead: 2}
+		if got := res.Shards[1]; got.ShardID != 2 || !equalReadCost(got.Cost, exp) {
+			t.Errorf("unexpected cost of shard 2; got=%+v, exp=%+v", got.Cost, exp)
+		}
+
+		exp = ReadCost{NumShards: 2, NumSeries: 4, CachedValues: 1, NumFiles: 2, BlocksRead: 7}
+		if !equalReadCost(res.Total, exp) {
+			t.Errorf("unexpected total cost; got=%+v, exp=%+v", res.Total, exp)
+		} else if got, exp := res.Total.BlockSize, res.Shards[0].Cost.BlockSize+res.Shards[1].Cost.BlockSize; got != exp {
+			t.Errorf("unexpected total block size; got=%d, exp=%d", got, exp)
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		res, err := s.Explain(context.Background(), &ReadRequest{
+			Database:       "db0",
+			TimestampRange: TimestampRange{Start: 10000000000, End: 20000000000},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got, exp := len(res.Shards), 1; got != exp {
+			t.Fatalf("unexpected number of shards; got=%d, exp=%d", got, exp)
+		} else if got, exp := res.Shards[0].ShardID, uint64(2); got != exp {
+			t.Errorf("unexpected shard; got=%d, exp=%d", got, exp)
+		}
+
+		exp := ReadCost{NumShards: 1, NumSeries: 1, NumFiles: 1, BlocksRead: 2}
+		if !equalReadCost(res.Total, exp) {
+			t.Errorf("unexpected total cost; got=%+v, exp=%+v", res.Total, exp)
+		}
+	})
+
+	t.Run("no database", func(t *testing.T) {
+		if _, err := s.Explain(context.Background(), &ReadRequest{Database: "db1"}); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestStore_TagKeys(t *testing.T) {
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustWritePoints(1,
+		`cpu,host=a,region=west value=1 1000000000`,
+		`cpu,host=b value=2,idle=3 5000000000`,
+		`mem,host=c free=4 1000000000`,
+	)
+	s.MustWritePoints(2,
+		`cpu,host=d value=5 12000000000`,
+		`disk,dc=east used=6 15000000000`,
+	)
+
+	cases := []struct {
+		name string
+		req  TagKeysRequest
+		exp  []string
+	}{
+		{
+			name: "all series",
+			req:  TagKeysRequest{Database: "db0"},
+			exp:  []string{"_field", "_measurement", "dc", "host", "region"},
+		},
+		{
+			name: "predicate",
+			req:  TagKeysRequest{Database: "db0", Predicate: tagEqualPredicate("host", "a")},
+			exp:  []string{"_field", "_measurement", "host", "region"},
+		},
+		{
+			name: "shard time range",
+			req:  TagKeysRequest{Database: "db0", TimestampRange: TimestampRange{Start: 10000000000, End: 20000000000}},
+			exp:  []string{"_field", "_measurement", "dc", "host"},
+		},
+		{
+			name: "series time range",
+			req:  TagKeysRequest{Database: "db0", TimestampRange: TimestampRange{Start: 4000000000, End: 6000000000}},
+			exp:  []string{"_field", "_measurement", "host"},
+		},
+		{
+			name: "no series in time range",
+			req:  TagKeysRequest{Database: "db0", TimestampRange: TimestampRange{Start: 7000000000, End: 8000000000}},
+			exp:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			itr, err := s.TagKeys(context.Background(), &tc.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			if itr != nil {
+				defer itr.Close()
+				for {
+					key, err := itr.Next()
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					} else if key == nil {
+						break
+					}
+					got = append(got, string(key))
+				}
+			}
+
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("unexpected tag keys; -got/+exp\n%s", cmp.Diff(got, tc.exp))
+			}
+		})
+	}
+}
+
+func TestStore_TagValues(t *testing.T) {
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustWritePoints(1,
+		`cpu,host=a,region=west value=1 1000000000`,
+		`cpu,host=b value=2,idle=3 5000000000`,
+		`mem,host=c free=4 1000000000`,
+	)
+	s.MustWritePoints(2,
+		`cpu,host=d value=5 12000000000`,
+		`disk,dc=east used=6 15000000000`,
+	)
+
+	shardRange := TimestampRange{Start: 10000000000, End: 20000000000}
+	seriesRange := TimestampRange{Start: 4000000000, End: 6000000000}
+
+	cases := []struct {
+		name string
+		req  TagValuesRequest
+		exp  []string
+	}{
+		{
+			name: "measurements",
+			req:  TagValuesRequest{Database: "db0", TagKey: "_measurement"},
+			exp:  []string{"cpu", "disk", "mem"},
+		},
+		{
+			name: "measurements in shard time range",
+			req:  TagValuesRequest{Database: "db0", TagKey: "_measurement", TimestampRange: shardRange},
+			exp:  []string{"cpu", "disk"},
+		},
+		{
+			name: "measurements in series time range",
+			req:  TagValuesRequest{Database: "db0", TagKey: "_measurement", TimestampRange: seriesRange},
+			exp:  []string{"cpu"},
+		},
+		{
+			name: "fields",
+			req:  TagValuesRequest{Database: "db0", TagKey: "_field"},
+			exp:  []string{"free", "idle", "used", "value"},
+		},
+		{
+			name: "fields with predicate",
+			req:  TagValuesRequest{Database: "db0", TagKey: "_field", Predicate: tagEqualPredicate("host", "c")},
+			exp:  []string{"free"},
+		},
+		{
+			name: "fields in series time range",
+			req:  TagValuesRequest{Database: "db0", TagKey: "_field", TimestampRange: seriesRange},
+			exp:  []string{"idle", "value"},
+		},
+		{
+			name: "fields with predicate in time range",
+			req: TagValuesRequest{
+				Database:       "db0",
+				TagKey:         "_field",
+				TimestampRange: TimestampRange{Start: 1, End: 9000000000},
+				Predicate:      tagEqualPredicate("host", "a"),
+			},
+			exp: []string{"value"},
+		},
+		{
+			name: "tag",
+			req:  TagValuesRequest{Database: "db0", TagKey: "host"},
+			exp:  []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "tag in series time range",
+			req:  TagValuesRequest{Database: "db0", TagKey: "host", TimestampRange: seriesRange},
+			exp:  []string{"b"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			itr, err := s.TagValues(context.Background(), &tc.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			if itr != nil {
+				defer itr.Close()
+				for {
+					value, err := itr.Next()
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					} else if value == nil {
+						break
+					}
+					got = append(got, string(value))
+				}
+			}
+
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("unexpected tag values; -got/+exp\n%s", cmp.Diff(got, tc.exp))
+			}
+		})
+	}
+
+	t.Run("missing tag key", func(t *testing.T) {
+		if _, err := s.TagValues(context.Background(), &TagValuesRequest{Database: "db0"}); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+// tagEqualPredicate returns a predicate matching series where the tag key
+// equals value.
+func tagEqualPredicate(key, value string) *Predicate {
+	return &Predicate{
+		Root: &Node{
+			NodeType: NodeTypeComparisonExpression,
+			Value:    &Node_Comparison_{Comparison: ComparisonEqual},
+			Children: []*Node{
+				{NodeType: NodeTypeTagRef, Value: &Node_TagRefValue{TagRefValue: key}},
+				{NodeType: NodeTypeLiteral, Value: &Node_StringValue{StringValue: value}},
+			},
+		},
+	}
+}
+
+// equalReadCost compares the costs, ignoring the block sizes which depend
+// on the encoding of the blocks.
+func equalReadCost(got, exp ReadCost) bool {
+	got.BlockSize, exp.BlockSize = 0, 0
+	return got == exp
+}
+
+// testStore is a Store backed by a tsdb.Store with the shard groups of the
+// db0.rp0 retention policy.
+type testStore struct {
+	*Store
+	path   string
+	groups []meta.ShardGroupInfo
+}
+
+// MustOpenStore returns a new, open Store with two shard groups, holding
+// shard 1 from 0s to 10s and shard 2 from 10s to 20s.
+func MustOpenStore() *testStore {
+	path, err := ioutil.TempDir("", "influxdb-storage-")
+	if err != nil {
+		panic(err)
+	}
+
+	ts := &testStore{
+		Store: NewStore(),
+		path:  path,
+		groups: []meta.ShardGroupInfo{
+			{ID: 1, StartTime: time.Unix(0, 0), EndTime: time.Unix(10, 0), Shards: []meta.ShardInfo{{ID: 1}}},
+			{ID: 2, StartTime: time.Unix(10, 0), EndTime: time.Unix(20, 0), Shards: []meta.ShardInfo{{ID: 2}}},
+		},
+	}
+
+	ts.TSDBStore = tsdb.NewStore(filepath.Join(path, "data"))
+	ts.TSDBStore.EngineOptions.Config.WALDir = filepath.Join(path, "wal")
+	if err := ts.TSDBStore.Open(); err != nil {
+		panic(err)
+	}
+
+	ts.MetaClient = &internal.MetaClientMock{
+		DatabaseFn: func(name string) *meta.DatabaseInfo {
+			if name != "db0" {
+				return nil
+			}
+			return &meta.DatabaseInfo{
+				Name:                   "db0",
+				DefaultRetentionPolicy: "rp0",
+				RetentionPolicies:      []meta.RetentionPolicyInfo{{Name: "rp0"}},
+			}
+		},
+		ShardGroupsByTimeRangeFn: func(database, policy string, min, max time.Time) ([]meta.ShardGroupInfo, error) {
+			var a []meta.ShardGroupInfo
+			for _, g := range ts.groups {
+				if g.Overlaps(min, max) {
+					a = append(a, g)
+				}
+			}
+			return a, nil
+		},
+	}
+
+	for _, g := range ts.groups {
+		for _, si := range g.Shards {
+			if err := ts.TSDBStore.CreateShard("db0", "rp0", si.ID, true); err != nil {
+				panic(err)
+			}
+		}
+	}
+	return ts
+}
+
+// MustWritePoints parses the points and writes them to the shard.
+func (s *testStore) MustWritePoints(shardID uint64, points ...string) {
+	a, err := models.ParsePointsString(strings.Join(points, "\n"))
+	if err != nil {
+		panic(err)
+	}
+
+	if err := s.TSDBStore.WriteToShard(shardID, a); err != nil {
+		panic(err)
+	}
+}
+
+// MustSnapshot writes the cache of the shard to a TSM file.
+func (s *testStore) MustSnapshot(shardID uint64) {
+	path, err := s.TSDBStore.CreateShardSnapshot(shardID)
+	if err != nil {
+		panic(err)
+	}
+	os.RemoveAll(path)
+}
+
+// Close closes the store and removes its data.
+func (s *testStore) Close() error {
+	defer os.RemoveAll(s.path)
+	return s.TSDBStore.Close()
+}
+
+type pointsWriterMock struct {
+	WritePointsPrivilegedFn func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error
+}
+
+func (w *pointsWriterMock) WritePointsPrivileged(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+	return w.WritePointsPrivilegedFn(database, retentionPolicy, consistencyLevel, points)
+}
+
+type writeAuthorizerFunc func(username, database string) error
+
+func (fn writeAuthorizerFunc) AuthorizeWrite(username, database string) error {
+	return fn(username, database)
+}
@@ -0,0 +1,153 @@
+package tsdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/pkg/limiter"
+)
+
+const (
+	// compactionThrottleInterval is how often adaptive compaction throttling
+	// adjusts the throughput limits.
+	compactionThrottleInterval = 5 * time.Second
+
+	// minCompactionThrottleFactor and maxCompactionThrottleFactor bound the
+	// factor that adaptive throttling applies to the configured limits.
+	minCompactionThrottleFactor = 1.0 / 16
+	maxCompactionThrottleFactor = 4.0
+)
+
+// CompactionThrottle limits the disk throughput of level and full compactions
+// across all shards of a store.  In adaptive mode, the configured limits are
+// scaled down while queries or WAL fsyncs are slow and scaled up while the
+// node is idle.
+type CompactionThrottle struct {
+	// Read and Write limit the reads and writes of compactions.
+	Read  *limiter.AdjustableRate
+	Write *limiter.AdjustableRate
+
+	mu         sync.Mutex
+	readLimit  int     // configured read limit
+	writeLimit int     // configured write limit
+	factor     float64 // factor applied to the configured limits
+
+	adaptive     bool
+	queryLatency time.Duration // target average query duration
+	fsyncLatency time.Duration // target average WAL fsync duration
+
+	queries latencySum // queries finished since the last adjustment
+	fsyncs  latencySum // WAL fsyncs since the last adjustment
+}
+
+// NewCompactionThrottle returns a CompactionThrottle configured by c.
+func NewCompactionThrottle(c Config) *CompactionThrottle {
+	return &CompactionThrottle{
+		Read:         limiter.NewAdjustableRate(int(c.CompactReadThroughput), int(c.CompactThroughputBurst)),
+		Write:        limiter.NewAdjustableRate(int(c.CompactThroughput), int(c.CompactThroughputBurst)),
+		readLimit:    int(c.CompactReadThroughput),
+		writeLimit:   int(c.CompactThroughput),
+		factor:       1,
+		adaptive:     c.CompactThroughputAdaptive,
+		queryLatency: time.Duration(c.CompactAdaptiveQueryLatency),
+		fsyncLatency: time.Duration(c.CompactAdaptiveFsyncLatency),
+	}
+}
+
+// Adaptive returns true if the limits are adjusted to the load of the node.
+func (t *CompactionThrottle) Adaptive() bool { return t.adaptive }
+
+// SetThroughput changes the configured read and write limits in bytes per
+// second.  A limit of 0 disables it.  In adaptive mode, the limits are
+// scaled by the current adaptive factor.
+func (t *CompactionThrottle) SetThroughput(read, write int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.readLimit, t.writeLimit = read, write
+	t.apply()
+}
+
+// Throughput returns the read and write limits currently in effect.
+func (t *CompactionThrottle) Throughput() (read, write int) {
+	return t.Read.Limit(), t.Write.Limit()
+}
+
+// ObserveQueryLatency records the duration of a finished query.
+func (t *CompactionThrottle) ObserveQueryLatency(d time.Duration) {
+	t.mu.Lock()
+	t.queries.add(d)
+	t.mu.Unlock()
+}
+
+// ObserveFsyncLatency records the duration of a WAL fsync.
+func (t *CompactionThrottle) ObserveFsyncLatency(d time.Duration) {
+	t.mu.Lock()
+	t.fsyncs.add(d)
+	t.mu.Unlock()
+}
+
+// adjust scales the limits to the latencies observed since the last call.
+// Compactions are slowed down by half while the average query or WAL fsync
+// duration is above its target and sped up while no queries or fsyncs were
+// observed.  Otherwise a factor below 1 recovers gradually.
+func (t *CompactionThrottle) adjust() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	queries, fsyncs := t.queries, t.fsyncs
+	t.queries, t.fsyncs = latencySum{}, latencySum{}
+
+	switch {
+	case queries.avg() > t.queryLatency || fsyncs.avg() > t.fsyncLatency:
+		t.factor /= 2
+	case queries.n == 0 && fsyncs.n == 0:
+		t.factor *= 2
+	case t.factor < 1:
+		t.factor *= 1.25
+		if t.factor > 1 {
+			t.factor = 1
+		}
+	}
+
+	if t.factor < minCompactionThrottleFactor {
+		t.factor = minCompactionThrottleFactor
+	} else if t.factor > maxCompactionThrottleFactor {
+		t.factor = maxCompactionThrottleFactor
+	}
+	t.apply()
+}
+
+// apply sets the limits of the rates.  Callers must hold t.mu.
+func (t *CompactionThrottle) apply() {
+	t.Read.SetLimit(scaleLimit(t.readLimit, t.factor))
+	t.Write.SetLimit(scaleLimit(t.writeLimit, t.factor))
+}
+
+// scaleLimit returns limit scaled by factor.  A disabled limit stays disabled.
+func scaleLimit(limit int, factor float64) int {
+	if limit <= 0 {
+		return 0
+	}
+	if n := int(float64(limit) * factor); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// latencySum accumulates durations to compute their average.
+type latencySum struct {
+	n   int
+	sum time.Duration
+}
+
+func (l *latencySum) add(d time.Duration) {
+	l.n++
+	l.sum += d
+}
+
+func (l latencySum) avg() time.Duration {
+	if l.n == 0 {
+		return 0
+	}
+	return l.sum / time.Duration(l.n)
+}
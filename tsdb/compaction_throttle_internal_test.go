@@ -0,0 +1,75 @@
+package tsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+)
+
+func TestCompactionThrottle_Adjust(t *testing.T) {
+	c := NewConfig()
+	c.CompactThroughput = toml.Size(1000)
+	c.CompactReadThroughput = toml.Size(2000)
+	c.CompactThroughputAdaptive = true
+	c.CompactAdaptiveQueryLatency = toml.Duration(time.Second)
+	c.CompactAdaptiveFsyncLatency = toml.Duration(100 * time.Millisecond)
+
+	throttle := NewCompactionThrottle(c)
+	checkThroughput := func(expRead, expWrite int) {
+		t.Helper()
+		if read, write := throttle.Throughput(); read != expRead || write != expWrite {
+			t.Fatalf("throughput mismatch: exp %d/%d, got %d/%d", expRead, expWrite, read, write)
+		}
+	}
+	checkThroughput(2000, 1000)
+
+	// Slow queries halve the limits.
+	throttle.ObserveQueryLatency(3 * time.Second)
+	throttle.ObserveQueryLatency(time.Second)
+	throttle.adjust()
+	checkThroughput(1000, 500)
+
+	// Slow fsyncs halve them again, even if queries are fast.
+	throttle.ObserveQueryLatency(time.Millisecond)
+	throttle.ObserveFsyncLatency(200 * time.Millisecond)
+	throttle.adjust()
+	checkThroughput(500, 250)
+
+	// A healthy, busy node recovers gradually.
+	throttle.ObserveQueryLatency(time.Millisecond)
+	throttle.adjust()
+	checkThroughput(625, 312)
+
+	// An idle node speeds up past the configured limits, up to the maximum.
+	for i := 0; i < 10; i++ {
+		throttle.adjust()
+	}
+	checkThroughput(8000, 4000)
+
+	// A healthy, busy node keeps the increased limits.
+	throttle.ObserveFsyncLatency(time.Millisecond)
+	throttle.adjust()
+	checkThroughput(8000, 4000)
+
+	// Changing the limits at runtime keeps the adaptive factor.
+	throttle.SetThroughput(0, 100)
+	checkThroughput(0, 400)
+}
+
+func TestCompactionThrottle_NotAdaptive(t *testing.T) {
+	c := NewConfig()
+	throttle := NewCompactionThrottle(c)
+	if throttle.Adaptive() {
+		t.Fatal("expected throttle not to be adaptive")
+	}
+
+	if read, write := throttle.Throughput(); read != 0 || write != DefaultCompactThroughput {
+		t.Fatalf("throughput mismatch: exp 0/%d, got %d/%d", DefaultCompactThroughput, read, write)
+	}
+
+	throttle.SetThroughput(1024, 0)
+	if read, write := throttle.Throughput(); read != 1024 || write != 0 {
+		t.Fatalf("throughput mismatch: exp 1024/0, got %d/%d", read, write)
+	}
+}
@@ -37,6 +37,37 @@ const (
 	// will compact all TSM files in a shard if it hasn't received a write or delete
 	DefaultCompactFullWriteColdDuration = time.Duration(4 * time.Hour)
 
+	// DefaultCompactTombstonePurgeThreshold is the size of the tombstones of
+	// a TSM file above which the deleted data is purged from the file.
+	DefaultCompactTombstonePurgeThreshold = 4 * 1024 * 1024
+
+	// DefaultBulkLoadBufferSize is the size of the bulk loaded values a shard
+	// buffers before writing them to TSM files.
+	DefaultBulkLoadBufferSize = 64 * 1024 * 1024 // 64MB
+
+	// DefaultWALCompression is the compression format of WAL segments.
+	DefaultWALCompression = "snappy"
+
+	// DefaultCompactThroughput is the rate limit in bytes per second that we
+	// will allow TSM compactions to write to disk.
+	DefaultCompactThroughput = 48 * 1024 * 1024
+
+	// DefaultCompactThroughputBurst is the rate limit in bytes per second that
+	// we will allow TSM compactions to write to disk in short bursts.
+	DefaultCompactThroughputBurst = 48 * 1024 * 1024
+
+	// DefaultCompactAdaptiveQueryLatency is the average query duration above
+	// which adaptive compaction throttling slows compactions down.
+	DefaultCompactAdaptiveQueryLatency = time.Duration(time.Second)
+
+	// DefaultCompactAdaptiveFsyncLatency is the average WAL fsync duration
+	// above which adaptive compaction throttling slows compactions down.
+	DefaultCompactAdaptiveFsyncLatency = time.Duration(100 * time.Millisecond)
+
+	// DefaultTSMStringCodec is the codec used to compress the values of
+	// string blocks in TSM files.
+	DefaultTSMStringCodec = "snappy"
+
 	// DefaultMaxPointsPerBlock is the maximum number of points in an encoded
 	// block in a TSM file
 	DefaultMaxPointsPerBlock = 1000
@@ -48,6 +79,23 @@ const (
 	// DefaultMaxValuesPerTag is the maximum number of values a tag can have within a measurement.
 	DefaultMaxValuesPerTag = 100000
 
+	// DefaultSeriesIdleCheckInterval is the interval at which series idle for
+	// longer than the series-idle-ttl of their retention policy are dropped.
+	DefaultSeriesIdleCheckInterval = time.Duration(30 * time.Minute)
+
+	// DefaultSeriesFileCompactThreshold is the fraction of the series entries
+	// of a series file partition that must belong to deleted series before
+	// its segments are compacted.
+	DefaultSeriesFileCompactThreshold = 0.5
+
+	// DefaultSeriesFileCompactCheckInterval is the interval at which series
+	// files are checked for deleted series to compact.
+	DefaultSeriesFileCompactCheckInterval = time.Duration(time.Hour)
+
+	// DefaultTSITrigramIndexThreshold is the number of values a tag key must
+	// have before its tsi1 tag blocks carry a trigram index.
+	DefaultTSITrigramIndexThreshold = 1000
+
 	// DefaultMaxConcurrentCompactions is the maximum number of concurrent full and level compactions
 	// that can run at one time.  A value of 0 results in 50% of runtime.GOMAXPROCS(0) used at runtime.
 	DefaultMaxConcurrentCompactions = 0
@@ -59,6 +107,40 @@ type Config struct {
 	Engine string `toml:"-"`
 	Index  string `toml:"index-version"`
 
+	// ColdDir is the directory that shards are moved to once they are older
+	// than the cold age of their retention policy.  Shards in the cold
+	// directory remain queryable, but are unavailable while each is closed to
+	// swap its directory and reopened.
+	ColdDir string `toml:"cold-dir"`
+
+	// ColdShardAges maps databases and their retention policies to the age
+	// after the end of a shard group at which its shards are moved to ColdDir,
+	// once all of them are fully compacted.  Shards of retention policies without an age are not
+	// moved.
+	ColdShardAges map[string]map[string]toml.Duration `toml:"cold-shard-ages"`
+
+	// SeriesIdleTTLs maps databases and their retention policies to the time
+	// after which a series with no newer values in any shard of the retention
+	// policy is dropped with its remaining values, as with DROP SERIES.
+	// Series no longer in any shard are removed from the series file.  Series
+	// of retention policies without a TTL are only removed by DROP SERIES or
+	// when their shards expire.
+	SeriesIdleTTLs map[string]map[string]toml.Duration `toml:"series-idle-ttl"`
+
+	// SeriesIdleCheckInterval is the interval at which idle series are
+	// checked.
+	SeriesIdleCheckInterval toml.Duration `toml:"series-idle-check-interval"`
+
+	// SeriesFileCompactThreshold is the fraction of the series entries of a
+	// series file partition that must belong to deleted series before its
+	// segments are rewritten without them.  A value of 0 disables series file
+	// compaction.
+	SeriesFileCompactThreshold float64 `toml:"series-file-compact-threshold"`
+
+	// SeriesFileCompactCheckInterval is the interval at which series files
+	// are checked against SeriesFileCompactThreshold.
+	SeriesFileCompactCheckInterval toml.Duration `toml:"series-file-compact-check-interval"`
+
 	// General WAL configuration options
 	WALDir string `toml:"wal-dir"`
 
@@ -67,6 +149,12 @@ type Config struct {
 	// disks or when WAL write contention is seen.  A value of 0 fsyncs every write to the WAL.
 	WALFsyncDelay toml.Duration `toml:"wal-fsync-delay"`
 
+	// WALCompression is the compression format of new WAL segments.  "snappy"
+	// compresses each entry individually.  "zstd" compresses the entries
+	// written between fsyncs together in checksummed frames.  Segments of
+	// either format can be read regardless of this setting.
+	WALCompression string `toml:"wal-compression"`
+
 	// Query logging
 	QueryLogEnabled bool `toml:"query-log-enabled"`
 
@@ -76,6 +164,77 @@ type Config struct {
 	CacheSnapshotWriteColdDuration toml.Duration `toml:"cache-snapshot-write-cold-duration"`
 	CompactFullWriteColdDuration   toml.Duration `toml:"compact-full-write-cold-duration"`
 
+	// CompactTombstonePurgeThreshold is the size of the tombstones of a TSM
+	// file above which the file is rewritten without the deleted data.  A
+	// value of 0 disables tombstone purges.
+	CompactTombstonePurgeThreshold toml.Size `toml:"compact-tombstone-purge-threshold"`
+
+	// CacheSpillEnabled makes writes that would exceed CacheMaxMemorySize
+	// force a snapshot and spill the least written parts of the cache to
+	// temporary files instead of failing.
+	CacheSpillEnabled bool `toml:"cache-spill-enabled"`
+
+	// BulkLoadBufferSize is the size of the values bulk loaded into a shard
+	// that are buffered before they are written together to new TSM files.
+	// Buffered values are written once the shard has not been bulk loaded for
+	// CacheSnapshotWriteColdDuration and are not visible to queries until
+	// then.  They are logged in the WAL directory of the shard, so they are
+	// written when it is reopened after a crash.  A value of 0 writes every
+	// bulk load at once.
+	BulkLoadBufferSize toml.Size `toml:"bulk-load-buffer-size"`
+
+	// CompactThroughput is the rate limit in bytes per second for the writes
+	// of level and full compactions across all shards.  Snapshot compactions
+	// are not limited.  A value of 0 disables the limit.
+	CompactThroughput toml.Size `toml:"compact-throughput"`
+
+	// CompactThroughputBurst is the number of bytes that compaction reads and
+	// writes may burst to above their rate limits.
+	CompactThroughputBurst toml.Size `toml:"compact-throughput-burst"`
+
+	// CompactReadThroughput is the rate limit in bytes per second for the
+	// reads of level and full compactions across all shards.  A value of 0
+	// disables the limit.
+	CompactReadThroughput toml.Size `toml:"compact-read-throughput"`
+
+	// CompactThroughputAdaptive enables adjusting the compaction throughput
+	// limits to the load of the node.  Compactions are slowed down while the
+	// average query or WAL fsync duration is above its target and sped up,
+	// up to four times the configured limits, while the node is idle.
+	CompactThroughputAdaptive bool `toml:"compact-throughput-adaptive"`
+
+	// CompactAdaptiveQueryLatency and CompactAdaptiveFsyncLatency are the
+	// target average query and WAL fsync durations of adaptive throttling.
+	CompactAdaptiveQueryLatency toml.Duration `toml:"compact-adaptive-query-latency"`
+	CompactAdaptiveFsyncLatency toml.Duration `toml:"compact-adaptive-fsync-latency"`
+
+	// TSMBlockStatistics enables writing the count, min, max and sum of the
+	// values of each block to new TSM files.  While enabled, the statistics are
+	// used to answer aggregate queries without decoding blocks.  TSM files
+	// written with statistics cannot be read by versions that do not support
+	// them.
+	TSMBlockStatistics bool `toml:"tsm-block-statistics"`
+
+	// TSMStringCodec is the codec used to compress the values of string blocks
+	// written to TSM files.  Valid values are "snappy" and "zstd".  Existing
+	// files are recompressed with the codec when they are compacted.
+	TSMStringCodec string `toml:"tsm-string-codec"`
+
+	// TSMDatabaseStringCodecs overrides TSMStringCodec for individual databases.
+	TSMDatabaseStringCodecs map[string]string `toml:"tsm-database-string-codecs"`
+
+	// TSMVerifyChecksums enables verifying the checksum of each TSM block
+	// read by queries.  Corrupt blocks are excluded from results and their
+	// files are quarantined: they are no longer compacted until repaired with
+	// influx_inspect verify.
+	TSMVerifyChecksums bool `toml:"tsm-verify-checksums"`
+
+	// TSITrigramIndexThreshold is the number of values a tag key must have
+	// before the tag blocks of tsi1 index files carry a trigram index used to
+	// evaluate regular expressions against its values.  A value of 0 disables
+	// trigram indexes.  This only applies when using the "tsi1" index.
+	TSITrigramIndexThreshold int `toml:"tsi-trigram-index-threshold"`
+
 	// Limits
 
 	// MaxSeriesPerDatabase is the maximum number of series a node can hold per database.
@@ -83,6 +242,18 @@ type Config struct {
 	// A value of 0 disables the limit. This limit only applies when using the "inmem" index.
 	MaxSeriesPerDatabase int `toml:"max-series-per-database"`
 
+	// MaxSeriesPerMeasurement is the maximum number of series a measurement can hold
+	// per database.  When this limit is exceeded, writes creating new series of the
+	// measurement return a partial write error naming it.  A value of 0 disables the
+	// limit.  This limit only applies when using the "tsi1" index.
+	MaxSeriesPerMeasurement int `toml:"max-series-per-measurement"`
+
+	// SeriesLimits maps databases to the series limits of their measurements,
+	// overriding MaxSeriesPerMeasurement.  The "*" key limits all the series of the
+	// database.  A value of 0 disables a limit.  These limits only apply when using
+	// the "tsi1" index.
+	SeriesLimits map[string]map[string]int `toml:"series-limits"`
+
 	// MaxValuesPerTag is the maximum number of tag values a single tag key can have within
 	// a measurement.  When the limit is execeeded, writes return an error.
 	// A value of 0 disables the limit.
@@ -104,11 +275,23 @@ func NewConfig() Config {
 		Index:  DefaultIndex,
 
 		QueryLogEnabled: true,
+		WALCompression:  DefaultWALCompression,
 
 		CacheMaxMemorySize:             toml.Size(DefaultCacheMaxMemorySize),
 		CacheSnapshotMemorySize:        toml.Size(DefaultCacheSnapshotMemorySize),
 		CacheSnapshotWriteColdDuration: toml.Duration(DefaultCacheSnapshotWriteColdDuration),
 		CompactFullWriteColdDuration:   toml.Duration(DefaultCompactFullWriteColdDuration),
+		SeriesIdleCheckInterval:        toml.Duration(DefaultSeriesIdleCheckInterval),
+		SeriesFileCompactThreshold:     DefaultSeriesFileCompactThreshold,
+		SeriesFileCompactCheckInterval: toml.Duration(DefaultSeriesFileCompactCheckInterval),
+		CompactTombstonePurgeThreshold: toml.Size(DefaultCompactTombstonePurgeThreshold),
+		BulkLoadBufferSize:             toml.Size(DefaultBulkLoadBufferSize),
+		CompactThroughput:              toml.Size(DefaultCompactThroughput),
+		CompactThroughputBurst:         toml.Size(DefaultCompactThroughputBurst),
+		CompactAdaptiveQueryLatency:    toml.Duration(DefaultCompactAdaptiveQueryLatency),
+		CompactAdaptiveFsyncLatency:    toml.Duration(DefaultCompactAdaptiveFsyncLatency),
+		TSMStringCodec:                 DefaultTSMStringCodec,
+		TSITrigramIndexThreshold:       DefaultTSITrigramIndexThreshold,
 
 		MaxSeriesPerDatabase:     DefaultMaxSeriesPerDatabase,
 		MaxValuesPerTag:          DefaultMaxValuesPerTag,
@@ -126,10 +309,78 @@ func (c *Config) Validate() error {
 		return errors.New("Data.WALDir must be specified")
 	}
 
+	for db, rps := range c.ColdShardAges {
+		for rp, age := range rps {
+			if age < 0 {
+				return fmt.Errorf("cold-shard-ages age for retention policy %s.%s must not be negative", db, rp)
+			} else if age > 0 && c.ColdDir == "" {
+				return errors.New("cold-dir must be specified to use cold-shard-ages")
+			}
+		}
+	}
+
+	for db, rps := range c.SeriesIdleTTLs {
+		for rp, ttl := range rps {
+			if ttl < 0 {
+				return fmt.Errorf("series-idle-ttl for retention policy %s.%s must not be negative", db, rp)
+			} else if ttl > 0 && c.SeriesIdleCheckInterval <= 0 {
+				return errors.New("series-idle-check-interval must be greater than 0 to use series-idle-ttl")
+			}
+		}
+	}
+
+	if c.SeriesFileCompactThreshold < 0 || c.SeriesFileCompactThreshold > 1 {
+		return errors.New("series-file-compact-threshold must be between 0 and 1")
+	} else if c.SeriesFileCompactThreshold > 0 && c.SeriesFileCompactCheckInterval <= 0 {
+		return errors.New("series-file-compact-check-interval must be greater than 0 to use series-file-compact-threshold")
+	}
+
+	if c.TSITrigramIndexThreshold < 0 {
+		return errors.New("tsi-trigram-index-threshold must not be negative")
+	}
+
+	if c.MaxSeriesPerMeasurement < 0 {
+		return errors.New("max-series-per-measurement must not be negative")
+	}
+	for db, limits := range c.SeriesLimits {
+		for name, limit := range limits {
+			if limit < 0 {
+				return fmt.Errorf("series-limits limit for %s in database %s must not be negative", name, db)
+			}
+		}
+	}
+
 	if c.MaxConcurrentCompactions < 0 {
 		return errors.New("max-concurrent-compactions must be greater than 0")
 	}
 
+	if (c.CompactThroughput > 0 || c.CompactReadThroughput > 0) && c.CompactThroughputBurst == 0 {
+		return errors.New("compact-throughput-burst must be greater than 0")
+	}
+
+	if c.CompactThroughputAdaptive {
+		if c.CompactAdaptiveQueryLatency <= 0 {
+			return errors.New("compact-adaptive-query-latency must be greater than 0")
+		} else if c.CompactAdaptiveFsyncLatency <= 0 {
+			return errors.New("compact-adaptive-fsync-latency must be greater than 0")
+		}
+	}
+
+	switch c.WALCompression {
+	case "", "snappy", "zstd":
+	default:
+		return fmt.Errorf("unrecognized wal-compression %s", c.WALCompression)
+	}
+
+	if !validStringCodec(c.TSMStringCodec) {
+		return fmt.Errorf("unrecognized tsm-string-codec %s", c.TSMStringCodec)
+	}
+	for db, codec := range c.TSMDatabaseStringCodecs {
+		if !validStringCodec(codec) {
+			return fmt.Errorf("unrecognized tsm-database-string-codecs codec %s for database %s", codec, db)
+		}
+	}
+
 	valid := false
 	for _, e := range RegisteredEngines() {
 		if e == c.Engine {
@@ -155,17 +406,93 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ColdShardAge returns the age after the end of a shard group at which the
+// shards of the retention policy are moved to the cold directory.  Returns
+// zero if the shards are not moved.
+func (c Config) ColdShardAge(database, retentionPolicy string) time.Duration {
+	if c.ColdDir == "" {
+		return 0
+	}
+	return time.Duration(c.ColdShardAges[database][retentionPolicy])
+}
+
+// SeriesLimit returns the maximum number of series of the measurement in
+// database, or of the whole database if measurement is empty.  Returns zero
+// if the series are not limited.
+func (c Config) SeriesLimit(database, measurement string) int {
+	if measurement == "" {
+		return c.SeriesLimits[database][SeriesLimitsDatabaseKey]
+	}
+	if limit, ok := c.SeriesLimits[database][measurement]; ok {
+		return limit
+	}
+	return c.MaxSeriesPerMeasurement
+}
+
+// SeriesLimited returns true if any series limit applies to database.
+func (c Config) SeriesLimited(database string) bool {
+	if c.MaxSeriesPerMeasurement > 0 {
+		return true
+	}
+	for _, limit := range c.SeriesLimits[database] {
+		if limit > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SeriesIdleTTL returns the time without newer values after which the series
+// of the retention policy are dropped.  Returns zero if idle series are not
+// dropped.
+func (c Config) SeriesIdleTTL(database, retentionPolicy string) time.Duration {
+	return time.Duration(c.SeriesIdleTTLs[database][retentionPolicy])
+}
+
+// StringCodec returns the codec used to compress string blocks of database.
+func (c Config) StringCodec(database string) string {
+	if codec, ok := c.TSMDatabaseStringCodecs[database]; ok {
+		return codec
+	}
+	return c.TSMStringCodec
+}
+
+func validStringCodec(codec string) bool {
+	switch codec {
+	case "", "snappy", "zstd":
+		return true
+	}
+	return false
+}
+
 // Diagnostics returns a diagnostics representation of a subset of the Config.
 func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 	return diagnostics.RowFromMap(map[string]interface{}{
 		"dir":                                c.Dir,
 		"wal-dir":                            c.WALDir,
+		"cold-dir":                           c.ColdDir,
+		"series-idle-check-interval":         c.SeriesIdleCheckInterval,
+		"series-file-compact-threshold":      c.SeriesFileCompactThreshold,
+		"series-file-compact-check-interval": c.SeriesFileCompactCheckInterval,
 		"wal-fsync-delay":                    c.WALFsyncDelay,
+		"wal-compression":                    c.WALCompression,
 		"cache-max-memory-size":              c.CacheMaxMemorySize,
 		"cache-snapshot-memory-size":         c.CacheSnapshotMemorySize,
 		"cache-snapshot-write-cold-duration": c.CacheSnapshotWriteColdDuration,
+		"cache-spill-enabled":                c.CacheSpillEnabled,
+		"bulk-load-buffer-size":              c.BulkLoadBufferSize,
 		"compact-full-write-cold-duration":   c.CompactFullWriteColdDuration,
+		"compact-tombstone-purge-threshold":  c.CompactTombstonePurgeThreshold,
+		"compact-throughput":                 c.CompactThroughput,
+		"compact-throughput-burst":           c.CompactThroughputBurst,
+		"compact-read-throughput":            c.CompactReadThroughput,
+		"compact-throughput-adaptive":        c.CompactThroughputAdaptive,
+		"tsm-block-statistics":               c.TSMBlockStatistics,
+		"tsm-string-codec":                   c.TSMStringCodec,
+		"tsm-verify-checksums":               c.TSMVerifyChecksums,
+		"tsi-trigram-index-threshold":        c.TSITrigramIndexThreshold,
 		"max-series-per-database":            c.MaxSeriesPerDatabase,
+		"max-series-per-measurement":         c.MaxSeriesPerMeasurement,
 		"max-values-per-tag":                 c.MaxValuesPerTag,
 		"max-concurrent-compactions":         c.MaxConcurrentCompactions,
 	}), nil
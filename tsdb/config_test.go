@@ -15,6 +15,10 @@ func TestConfig_Parse(t *testing.T) {
 dir = "/var/lib/influxdb/data"
 wal-dir = "/var/lib/influxdb/wal"
 wal-fsync-delay = "10s"
+tsm-string-codec = "snappy"
+tsm-database-string-codecs = { logs = "zstd" }
+max-series-per-measurement = 1000
+series-limits = { db0 = { "*" = 100000, cpu = 0 } }
 `, &c); err != nil {
 		t.Fatal(err)
 	}
@@ -32,6 +36,21 @@ wal-fsync-delay = "10s"
 	if got, exp := c.WALFsyncDelay, time.Duration(10*time.Second); time.Duration(got).Nanoseconds() != exp.Nanoseconds() {
 		t.Errorf("unexpected wal-fsync-delay:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
 	}
+	if got, exp := c.StringCodec("db0"), "snappy"; got != exp {
+		t.Errorf("unexpected string codec:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.StringCodec("logs"), "zstd"; got != exp {
+		t.Errorf("unexpected string codec for logs:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.SeriesLimit("db0", ""), 100000; got != exp {
+		t.Errorf("unexpected series limit:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.SeriesLimit("db0", "cpu"), 0; got != exp {
+		t.Errorf("unexpected series limit for cpu:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.SeriesLimit("db1", "cpu"), 1000; got != exp {
+		t.Errorf("unexpected series limit for cpu in db1:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
 }
 
 func TestConfig_Validate_Error(t *testing.T) {
@@ -66,6 +85,36 @@ func TestConfig_Validate_Error(t *testing.T) {
 	if err := c.Validate(); err != nil {
 		t.Error(err)
 	}
+
+	c.WALCompression = "gzip"
+	if err := c.Validate(); err == nil || err.Error() != "unrecognized wal-compression gzip" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.WALCompression = "zstd"
+	c.TSMStringCodec = "lz4"
+	if err := c.Validate(); err == nil || err.Error() != "unrecognized tsm-string-codec lz4" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.TSMStringCodec = "zstd"
+	c.TSMDatabaseStringCodecs = map[string]string{"db0": "lz4"}
+	if err := c.Validate(); err == nil || err.Error() != "unrecognized tsm-database-string-codecs codec lz4 for database db0" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.TSMDatabaseStringCodecs = nil
+	c.CompactThroughputBurst = 0
+	if err := c.Validate(); err == nil || err.Error() != "compact-throughput-burst must be greater than 0" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.CompactThroughputBurst = tsdb.DefaultCompactThroughputBurst
+	c.CompactThroughputAdaptive = true
+	c.CompactAdaptiveFsyncLatency = 0
+	if err := c.Validate(); err == nil || err.Error() != "compact-adaptive-fsync-latency must be greater than 0" {
+		t.Errorf("unexpected error: %s", err)
+	}
 }
 
 func TestConfig_ByteSizes(t *testing.T) {
@@ -40,6 +40,10 @@ type Engine interface {
 
 	LoadMetadataIndex(shardID uint64, index Index) error
 
+	BuildIndex(index Index, progress func(seriesN int) error) error
+	StopBuildingIndex() error
+	SetIndex(index Index)
+
 	CreateSnapshot() (string, error)
 	Backup(w io.Writer, basePath string, since time.Time) error
 	Export(w io.Writer, basePath string, start time.Time, end time.Time) error
@@ -51,6 +55,8 @@ type Engine interface {
 	CreateCursor(ctx context.Context, r *CursorRequest) (Cursor, error)
 	IteratorCost(measurement string, opt query.IteratorOptions) (query.IteratorCost, error)
 	WritePoints(points []models.Point) error
+	BulkLoadPoints(points []models.Point) error
+	IngestTSMFiles(paths []string, minTime, maxTime int64) error
 
 	CreateSeriesIfNotExists(key, name []byte, tags models.Tags) error
 	CreateSeriesListIfNotExists(keys, names [][]byte, tags []models.Tags) error
@@ -75,6 +81,7 @@ type Engine interface {
 	// Statistics will return statistics relevant to this engine.
 	Statistics(tags map[string]string) []models.Statistic
 	LastModified() time.Time
+	MaxTime() int64
 	DiskSize() int64
 	IsIdle() bool
 	Free() error
@@ -82,6 +89,20 @@ type Engine interface {
 	io.WriterTo
 }
 
+// IteratorFileCoster is implemented by engines which can report the files
+// read by iterators. IteratorCostFiles only counts the files that are not in
+// files and adds their paths to it, and only counts the series with values in
+// the time range of the iterator.
+type IteratorFileCoster interface {
+	IteratorCostFiles(measurement string, opt query.IteratorOptions, files map[string]struct{}) (query.IteratorCost, error)
+}
+
+// FieldValueChecker is implemented by engines which can determine whether a
+// field of a series has values within a time range without reading them.
+type FieldValueChecker interface {
+	HasFieldValues(seriesKey []byte, field string, min, max int64) bool
+}
+
 // SeriesIDSets provides access to the total set of series IDs
 type SeriesIDSets interface {
 	ForEach(f func(ids *SeriesIDSet)) error
@@ -151,11 +172,20 @@ type EngineOptions struct {
 	EngineVersion string
 	IndexVersion  string
 	ShardID       uint64
-	InmemIndex    interface{} // shared in-memory index
+	InmemIndex    interface{}    // shared in-memory index
+	SeriesLimiter *SeriesLimiter // shared series limits of the database, if any
+
+	CompactionLimiter               limiter.Fixed
+	CompactionThroughputLimiter     limiter.Rate
+	CompactionReadThroughputLimiter limiter.Rate
+	WALEnabled                      bool
+
+	// WALSyncObserver, if set, is called with the duration of every WAL fsync.
+	WALSyncObserver func(time.Duration)
 
-	CompactionLimiter           limiter.Fixed
-	CompactionThroughputLimiter limiter.Rate
-	WALEnabled                  bool
+	// BulkLoadObserver, if set, is called with the time range of the values
+	// bulk loaded into a shard once they are written to TSM files.
+	BulkLoadObserver func(shardID uint64, min, max int64)
 
 	Config       Config
 	SeriesIDSets SeriesIDSets
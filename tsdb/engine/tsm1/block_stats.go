@@ -0,0 +1,271 @@
+package tsm1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Size in bytes of the statistics of a block stored in a TSM file.
+const blockStatsEntrySize = 36
+
+// BlockStats holds statistics of the values stored in a block.
+type BlockStats struct {
+	// Count is the number of values in the block.
+	Count uint32
+
+	// Min, Max and Sum are the minimum, maximum and sum of the values in
+	// the block stored as the bits of a float64, int64 or uint64 depending
+	// on the type of the block.  They are zero for string and boolean blocks.
+	Min, Max, Sum uint64
+}
+
+// FloatMin returns the minimum value of a float block.
+func (s BlockStats) FloatMin() float64 { return math.Float64frombits(s.Min) }
+
+// FloatMax returns the maximum value of a float block.
+func (s BlockStats) FloatMax() float64 { return math.Float64frombits(s.Max) }
+
+// FloatSum returns the sum of the values of a float block.
+func (s BlockStats) FloatSum() float64 { return math.Float64frombits(s.Sum) }
+
+// IntegerMin returns the minimum value of an integer block.
+func (s BlockStats) IntegerMin() int64 { return int64(s.Min) }
+
+// IntegerMax returns the maximum value of an integer block.
+func (s BlockStats) IntegerMax() int64 { return int64(s.Max) }
+
+// IntegerSum returns the sum of the values of an integer block.
+func (s BlockStats) IntegerSum() int64 { return int64(s.Sum) }
+
+// blockStatsWriter encodes the statistics of the blocks written to a TSM file.
+type blockStatsWriter struct {
+	buf []byte
+
+	// Buffers used to decode blocks written without their values.
+	floats    []FloatValue
+	integers  []IntegerValue
+	unsigneds []UnsignedValue
+}
+
+// add appends the statistics of the block at offset.  Blocks must be added in
+// the order they are written.
+func (w *blockStatsWriter) add(offset int64, stats BlockStats) {
+	var b [blockStatsEntrySize]byte
+	binary.BigEndian.PutUint64(b[0:8], uint64(offset))
+	binary.BigEndian.PutUint32(b[8:12], stats.Count)
+	binary.BigEndian.PutUint64(b[12:20], stats.Min)
+	binary.BigEndian.PutUint64(b[20:28], stats.Max)
+	binary.BigEndian.PutUint64(b[28:36], stats.Sum)
+	w.buf = append(w.buf, b[:]...)
+}
+
+// decode returns the statistics of an encoded block.
+func (w *blockStatsWriter) decode(block []byte) (BlockStats, error) {
+	typ, err := BlockType(block)
+	if err != nil {
+		return BlockStats{}, err
+	}
+
+	var stats BlockStats
+	switch typ {
+	case BlockFloat64:
+		if w.floats, err = DecodeFloatBlock(block, &w.floats); err != nil {
+			return BlockStats{}, err
+		}
+		stats = newFloatBlockStats(w.floats)
+	case BlockInteger:
+		if w.integers, err = DecodeIntegerBlock(block, &w.integers); err != nil {
+			return BlockStats{}, err
+		}
+		stats = newIntegerBlockStats(w.integers)
+	case BlockUnsigned:
+		if w.unsigneds, err = DecodeUnsignedBlock(block, &w.unsigneds); err != nil {
+			return BlockStats{}, err
+		}
+		stats = newUnsignedBlockStats(w.unsigneds)
+	case BlockString, BlockBoolean:
+		stats.Count = uint32(BlockCount(block))
+	default:
+		return BlockStats{}, fmt.Errorf("unknown block type: %d", typ)
+	}
+	return stats, nil
+}
+
+func newFloatBlockStats(values []FloatValue) BlockStats {
+	stats := BlockStats{Count: uint32(len(values))}
+	if len(values) == 0 {
+		return stats
+	}
+
+	min, max, sum := math.Inf(1), math.Inf(-1), 0.0
+	for _, v := range values {
+		if v.value < min {
+			min = v.value
+		}
+		if v.value > max {
+			max = v.value
+		}
+		sum += v.value
+	}
+	stats.Min, stats.Max, stats.Sum = math.Float64bits(min), math.Float64bits(max), math.Float64bits(sum)
+	return stats
+}
+
+func newIntegerBlockStats(values []IntegerValue) BlockStats {
+	stats := BlockStats{Count: uint32(len(values))}
+	if len(values) == 0 {
+		return stats
+	}
+
+	min, max, sum := int64(math.MaxInt64), int64(math.MinInt64), int64(0)
+	for _, v := range values {
+		if v.value < min {
+			min = v.value
+		}
+		if v.value > max {
+			max = v.value
+		}
+		sum += v.value
+	}
+	stats.Min, stats.Max, stats.Sum = uint64(min), uint64(max), uint64(sum)
+	return stats
+}
+
+func newUnsignedBlockStats(values []UnsignedValue) BlockStats {
+	stats := BlockStats{Count: uint32(len(values))}
+	if len(values) == 0 {
+		return stats
+	}
+
+	min, max, sum := uint64(math.MaxUint64), uint64(0), uint64(0)
+	for _, v := range values {
+		if v.value < min {
+			min = v.value
+		}
+		if v.value > max {
+			max = v.value
+		}
+		sum += v.value
+	}
+	stats.Min, stats.Max, stats.Sum = min, max, sum
+	return stats
+}
+
+// findBlockStats returns the statistics of the block at offset from the
+// encoded statistics of a file.
+func findBlockStats(b []byte, offset int64) (BlockStats, bool) {
+	n := len(b) / blockStatsEntrySize
+	i := sort.Search(n, func(i int) bool {
+		return int64(binary.BigEndian.Uint64(b[i*blockStatsEntrySize:])) >= offset
+	})
+	if i >= n {
+		return BlockStats{}, false
+	}
+
+	entry := b[i*blockStatsEntrySize : (i+1)*blockStatsEntrySize]
+	if int64(binary.BigEndian.Uint64(entry[0:8])) != offset {
+		return BlockStats{}, false
+	}
+	return BlockStats{
+		Count: binary.BigEndian.Uint32(entry[8:12]),
+		Min:   binary.BigEndian.Uint64(entry[12:20]),
+		Max:   binary.BigEndian.Uint64(entry[20:28]),
+		Sum:   binary.BigEndian.Uint64(entry[28:36]),
+	}, true
+}
+
+// blockAggregate holds the statistics of a block that is aggregated without
+// reading its values.
+type blockAggregate struct {
+	minTime, maxTime int64
+	stats            BlockStats
+}
+
+// aggregateBlocks removes the blocks that can be aggregated using their
+// statistics from the cursor and returns their statistics.  A block can be
+// aggregated if its file stores statistics, it has not been read, it does not
+// overlap another block or a tombstone, and fn returns true for it.  The
+// cursor is positioned at seek afterwards, so the removed blocks are not read
+// again even if every block was aggregated.
+func (c *KeyCursor) aggregateBlocks(seek int64, fn func(entry *IndexEntry) bool) []blockAggregate {
+	if len(c.seeks) == 0 {
+		return nil
+	}
+
+	// Find the blocks overlapping another block by sorting them by their
+	// min time.  A block overlaps an earlier block if its min time is before
+	// the greatest max time of the earlier blocks.
+	order := make([]int, len(c.seeks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return c.seeks[order[i]].entry.MinTime < c.seeks[order[j]].entry.MinTime
+	})
+	overlaps := make([]bool, len(c.seeks))
+	maxTime := int64(math.MinInt64)
+	for _, idx := range order {
+		entry := &c.seeks[idx].entry
+		if entry.MinTime <= maxTime {
+			overlaps[idx] = true
+		}
+		if entry.MaxTime > maxTime {
+			maxTime = entry.MaxTime
+		}
+	}
+
+	// Likewise, a block overlaps a later block if its max time is after the
+	// least min time of the later blocks.
+	minTime := int64(math.MaxInt64)
+	for i := len(order) - 1; i >= 0; i-- {
+		entry := &c.seeks[order[i]].entry
+		if entry.MaxTime >= minTime {
+			overlaps[order[i]] = true
+		}
+		if entry.MinTime < minTime {
+			minTime = entry.MinTime
+		}
+	}
+
+	var aggs []blockAggregate
+	seeks := make([]*location, 0, len(c.seeks))
+	for i, loc := range c.seeks {
+		if overlaps[i] || loc.entry.OverlapsTimeRange(loc.readMin, loc.readMax) || !fn(&loc.entry) {
+			seeks = append(seeks, loc)
+			continue
+		}
+
+		tombstoned := false
+		for _, t := range loc.r.TombstoneRange(c.key) {
+			if loc.entry.OverlapsTimeRange(t.Min, t.Max) {
+				tombstoned = true
+				break
+			}
+		}
+		if tombstoned {
+			seeks = append(seeks, loc)
+			continue
+		}
+
+		stats, ok := loc.r.BlockStats(&loc.entry)
+		if !ok {
+			seeks = append(seeks, loc)
+			continue
+		}
+
+		aggs = append(aggs, blockAggregate{
+			minTime: loc.entry.MinTime,
+			maxTime: loc.entry.MaxTime,
+			stats:   stats,
+		})
+		loc.r.Unref()
+	}
+
+	c.seeks = seeks
+	c.seek(seek)
+
+	sort.Slice(aggs, func(i, j int) bool { return aggs[i].minTime < aggs[j].minTime })
+	return aggs
+}
@@ -29,7 +29,15 @@ var (
 // ErrCacheMemorySizeLimitExceeded returns an error indicating an operation
 // could not be completed due to exceeding the cache-max-memory-size setting.
 func ErrCacheMemorySizeLimitExceeded(n, limit uint64) error {
-	return fmt.Errorf("cache-max-memory-size exceeded: (%d/%d)", n, limit)
+	return errCacheMemorySizeLimitExceeded{n: n, limit: limit}
+}
+
+type errCacheMemorySizeLimitExceeded struct {
+	n, limit uint64
+}
+
+func (e errCacheMemorySizeLimitExceeded) Error() string {
+	return fmt.Sprintf("cache-max-memory-size exceeded: (%d/%d)", e.n, e.limit)
 }
 
 // entry is a set of values and some metadata.
@@ -160,6 +168,9 @@ const (
 	statCacheWriteOK      = "writeOk"
 	statCacheWriteErr     = "writeErr"
 	statCacheWriteDropped = "writeDropped"
+
+	statCacheSpills       = "spillCount"   // level: Number of files holding entries spilled to disk
+	statCacheSpilledBytes = "spilledBytes" // counter: Total number of bytes spilled to disk
 )
 
 // storer is the interface that descibes a cache's store.
@@ -194,6 +205,13 @@ type Cache struct {
 	snapshot     *Cache
 	snapshotting bool
 
+	// spills hold entries moved out of memory to temporary files in path to
+	// keep the cache under its maximum size.  They are moved to the snapshot
+	// along with the store.
+	path     string
+	spills   []*cacheSpill
+	spillSeq int
+
 	// This number is the number of pending or failed WriteSnaphot attempts since the last successful one.
 	snapshotAttempts int
 
@@ -211,6 +229,7 @@ type Cache struct {
 func NewCache(maxSize uint64, path string) *Cache {
 	c := &Cache{
 		maxSize:      maxSize,
+		path:         path,
 		store:        emptyStore{},
 		stats:        &CacheStatistics{},
 		lastSnapshot: time.Now(),
@@ -235,6 +254,8 @@ type CacheStatistics struct {
 	WriteOK             int64
 	WriteErr            int64
 	WriteDropped        int64
+	SpillCount          int64
+	SpilledBytes        int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -252,6 +273,8 @@ func (c *Cache) Statistics(tags map[string]string) []models.Statistic {
 			statCacheWriteOK:        atomic.LoadInt64(&c.stats.WriteOK),
 			statCacheWriteErr:       atomic.LoadInt64(&c.stats.WriteErr),
 			statCacheWriteDropped:   atomic.LoadInt64(&c.stats.WriteDropped),
+			statCacheSpills:         atomic.LoadInt64(&c.stats.SpillCount),
+			statCacheSpilledBytes:   atomic.LoadInt64(&c.stats.SpilledBytes),
 		},
 	}}
 }
@@ -395,11 +418,12 @@ func (c *Cache) Snapshot() (*Cache, error) {
 
 	// Did a prior snapshot exist that failed?  If so, return the existing
 	// snapshot to retry.
-	if c.snapshot.Size() > 0 {
+	if c.snapshot.Size() > 0 || len(c.snapshot.spills) > 0 {
 		return c.snapshot, nil
 	}
 
 	c.snapshot.store, c.store = c.store, c.snapshot.store
+	c.snapshot.spills, c.spills = c.spills, nil
 	snapshotSize := c.Size()
 
 	// Save the size of the snapshot on the snapshot cache
@@ -437,11 +461,15 @@ func (c *Cache) ClearSnapshot(success bool) {
 
 	c.mu.RLock()
 	snapStore := c.snapshot.store
+	snapSpills := c.snapshot.spills
 	c.mu.RUnlock()
 
-	// reset the snapshot store outside of the write lock
+	// reset the snapshot store and remove its spills outside of the write lock
 	if success {
 		snapStore.reset()
+		for _, s := range snapSpills {
+			s.close()
+		}
 	}
 
 	c.mu.Lock()
@@ -460,6 +488,7 @@ func (c *Cache) ClearSnapshot(success bool) {
 
 		atomic.StoreUint64(&c.snapshotSize, 0)
 		c.updateSnapshots()
+		c.updateSpills()
 	}
 }
 
@@ -494,9 +523,9 @@ func (c *Cache) Count() int {
 // Keys returns a sorted slice of all keys under management by the cache.
 func (c *Cache) Keys() [][]byte {
 	c.mu.RLock()
-	store := c.store
+	store, spills := c.store, c.spills
 	c.mu.RUnlock()
-	return store.keys(true)
+	return spillKeys(store.keys(true), spills)
 }
 
 func (c *Cache) Split(n int) []*Cache {
@@ -517,14 +546,21 @@ func (c *Cache) Split(n int) []*Cache {
 // Values returns a copy of all values, deduped and sorted, for the given key.
 func (c *Cache) Values(key []byte) Values {
 	var snapshotEntries *entry
+	var snapshotSpills []*cacheSpill
 
 	c.mu.RLock()
 	e := c.store.entry(key)
 	if c.snapshot != nil {
 		snapshotEntries = c.snapshot.store.entry(key)
+		snapshotSpills = c.snapshot.spills
 	}
+	spills := c.spills
 	c.mu.RUnlock()
 
+	if len(snapshotSpills) > 0 || len(spills) > 0 {
+		return spillValues(key, snapshotSpills, snapshotEntries, spills, e)
+	}
+
 	if e == nil {
 		if snapshotEntries == nil {
 			// No values in hot cache or snapshots.
@@ -587,6 +623,10 @@ func (c *Cache) DeleteRange(keys [][]byte, min, max int64) {
 	defer c.mu.Unlock()
 
 	for _, k := range keys {
+		for _, s := range c.spills {
+			s.deleteRange(k, min, max)
+		}
+
 		// Make sure key exist in the cache, skip if it does not
 		e := c.store.entry(k)
 		if e == nil {
@@ -623,6 +663,10 @@ func (c *Cache) SetMaxSize(size uint64) {
 // It doesn't lock the cache but it does read-lock the entry if there is one for the key.
 // values should only be used in compact.go in the CacheKeyIterator.
 func (c *Cache) values(key []byte) Values {
+	if len(c.spills) > 0 {
+		return spillValues(key, c.spills, c.store.entry(key), nil, nil)
+	}
+
 	e := c.store.entry(key)
 	if e == nil {
 		return nil
@@ -0,0 +1,330 @@
+package tsm1
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/pkg/bytesutil"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// cacheSpillExtension is the extension of the temporary files holding cache
+// entries that were spilled to disk.  The files are removed once the entries
+// are written to TSM files, or at startup since the WAL holds the same data.
+const cacheSpillExtension = "spill"
+
+// cacheSpill holds cache entries that were moved out of memory to keep the
+// cache under its maximum size.  The entries are written to a temporary TSM
+// file and read back from it until the cache snapshot containing the spill is
+// written.
+type cacheSpill struct {
+	mu         sync.RWMutex
+	entries    map[string]*entry      // entries until the file is written
+	r          *TSMReader             // reader of the file once written
+	tombstones map[string][]TimeRange // ranges deleted after the spill
+
+	path string
+	size uint64        // size of the spilled entries
+	done chan struct{} // closed once write returns
+}
+
+// newCacheSpill returns a cacheSpill of entries to be written to path.  The
+// entries must be deduplicated and must not be modified anymore.
+func newCacheSpill(path string, entries map[string]*entry, size uint64) *cacheSpill {
+	return &cacheSpill{
+		entries: entries,
+		path:    path,
+		size:    size,
+		done:    make(chan struct{}),
+	}
+}
+
+// write writes the entries to the spill file and releases them.  If the file
+// cannot be written, the entries are kept in memory.
+func (s *cacheSpill) write() error {
+	defer close(s.done)
+
+	r, err := s.writeFile()
+	if err != nil {
+		os.Remove(s.path)
+		return err
+	}
+
+	s.mu.Lock()
+	s.entries, s.r = nil, r
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *cacheSpill) writeFile() (*TSMReader, error) {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewTSMWriter(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(s.entries))
+	for k := range s.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		e := s.entries[k]
+		e.mu.RLock()
+		values := e.values
+		e.mu.RUnlock()
+
+		for len(values) > 0 {
+			n := len(values)
+			if n > tsdb.DefaultMaxPointsPerBlock {
+				n = tsdb.DefaultMaxPointsPerBlock
+			}
+			if err := w.Write([]byte(k), values[:n]); err != nil {
+				w.Close()
+				return nil, err
+			}
+			values = values[n:]
+		}
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	if f, err = os.Open(s.path); err != nil {
+		return nil, err
+	}
+	r, err := NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// keys returns the unsorted keys that have values in the spill.
+func (s *cacheSpill) keys() [][]byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys [][]byte
+	if s.r != nil {
+		keys = make([][]byte, 0, s.r.KeyCount())
+		for i := 0; i < s.r.KeyCount(); i++ {
+			// The key is copied since it refers to the mapped file.
+			key, _ := s.r.KeyAt(i)
+			keys = append(keys, append([]byte(nil), key...))
+		}
+	} else {
+		keys = make([][]byte, 0, len(s.entries))
+		for k := range s.entries {
+			keys = append(keys, []byte(k))
+		}
+	}
+
+	if len(s.tombstones) == 0 {
+		return keys
+	}
+
+	// Skip the keys whose values were all deleted.
+	n := 0
+	for _, k := range keys {
+		if _, ok := s.tombstones[string(k)]; ok && len(s.valuesLocked(k)) == 0 {
+			continue
+		}
+		keys[n] = k
+		n++
+	}
+	return keys[:n]
+}
+
+// values returns a sorted copy of the values for key in the spill.
+func (s *cacheSpill) values(key []byte) Values {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.valuesLocked(key)
+}
+
+// valuesLocked returns the values for key.  Callers must hold s.mu.  The file
+// was written and read back successfully, so read errors return no values.
+func (s *cacheSpill) valuesLocked(key []byte) Values {
+	var values Values
+	if s.r != nil {
+		values, _ = s.r.ReadAll(key)
+	} else if e := s.entries[string(key)]; e != nil {
+		e.mu.RLock()
+		values = make(Values, len(e.values))
+		copy(values, e.values)
+		e.mu.RUnlock()
+	}
+	return s.filter(key, values)
+}
+
+// filter removes the deleted ranges of key from values.
+func (s *cacheSpill) filter(key []byte, values Values) Values {
+	for _, tr := range s.tombstones[string(key)] {
+		values = values.Exclude(tr.Min, tr.Max)
+	}
+	return values
+}
+
+// deleteRange removes the values for key between min and max inclusive.
+func (s *cacheSpill) deleteRange(key []byte, min, max int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.r != nil {
+		if !s.r.Contains(key) {
+			return
+		}
+	} else if _, ok := s.entries[string(key)]; !ok {
+		return
+	}
+
+	if s.tombstones == nil {
+		s.tombstones = make(map[string][]TimeRange)
+	}
+	s.tombstones[string(key)] = append(s.tombstones[string(key)], TimeRange{Min: min, Max: max})
+}
+
+// close waits for the spill to be written, then closes and removes its file.
+func (s *cacheSpill) close() error {
+	<-s.done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = nil
+	if s.r == nil {
+		return nil
+	}
+
+	r := s.r
+	s.r = nil
+	if err := r.Close(); err != nil {
+		return err
+	}
+	return r.Remove()
+}
+
+// spill detaches the least written partitions of the cache, holding at least
+// n bytes, into a cacheSpill that the caller must write.  The detached entries
+// no longer count towards the size of the cache.  Callers must ensure that no
+// writes to the cache are in progress.  It returns nil if nothing was spilled.
+func (c *Cache) spill(n uint64) *cacheSpill {
+	c.init()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.store.(*ring)
+	if !ok || c.path == "" {
+		return nil
+	}
+
+	entries := make(map[string]*entry)
+	var size uint64
+	for _, p := range r.coldPartitions() {
+		if size >= n {
+			break
+		}
+
+		for k, e := range r.detach(p) {
+			if e.count() == 0 {
+				continue
+			}
+			size += uint64(e.size() + len(k))
+			e.deduplicate()
+			entries[k] = e
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	c.spillSeq++
+	path := filepath.Join(c.path, fmt.Sprintf("%09d.%s", c.spillSeq, cacheSpillExtension))
+	s := newCacheSpill(path, entries, size)
+	c.spills = append(c.spills, s)
+
+	c.decreaseSize(size)
+	c.updateMemSize(-int64(size))
+	atomic.AddInt64(&c.stats.SpilledBytes, int64(size))
+	c.updateSpills()
+	return s
+}
+
+// updateSpills updates the number of spill files.  Callers must hold c.mu.
+func (c *Cache) updateSpills() {
+	n := len(c.spills)
+	if c.snapshot != nil {
+		n += len(c.snapshot.spills)
+	}
+	atomic.StoreInt64(&c.stats.SpillCount, int64(n))
+}
+
+// spilled returns true if the cache holds entries spilled to disk.
+func (c *Cache) spilled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.spills) > 0
+}
+
+// spillKeys returns the sorted and deduplicated union of keys and the keys of
+// the spills.
+func spillKeys(keys [][]byte, spills []*cacheSpill) [][]byte {
+	if len(spills) == 0 {
+		return keys
+	}
+
+	for _, s := range spills {
+		keys = append(keys, s.keys()...)
+	}
+	return bytesutil.SortDedup(keys)
+}
+
+// spillValues returns the deduplicated values for key of the spills and the
+// entries of a snapshot and of a cache.  Values written later take precedence
+// when timestamps are equal, so the spills of the snapshot come first, then
+// its entry, then the spills and the entry of the cache.
+func spillValues(key []byte, snapshotSpills []*cacheSpill, snapshotEntry *entry, spills []*cacheSpill, e *entry) Values {
+	var values Values
+	for _, s := range snapshotSpills {
+		values = append(values, s.values(key)...)
+	}
+	values = appendEntryValues(values, snapshotEntry)
+	for _, s := range spills {
+		values = append(values, s.values(key)...)
+	}
+	values = appendEntryValues(values, e)
+
+	if len(values) == 0 {
+		return nil
+	}
+	return values.Deduplicate()
+}
+
+func appendEntryValues(values Values, e *entry) Values {
+	if e == nil {
+		return values
+	}
+	e.mu.RLock()
+	values = append(values, e.values...)
+	e.mu.RUnlock()
+	return values
+}
@@ -563,6 +563,82 @@ func TestCache_CacheWriteMemoryExceeded(t *testing.T) {
 	}
 }
 
+func TestCache_Spill(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	v0 := NewValue(1, 1.0)
+	v1 := NewValue(2, 2.0)
+	v2 := NewValue(3, 3.0)
+	v3 := NewValue(2, 4.0)
+
+	c := NewCache(0, dir)
+	if err := c.WriteMulti(map[string][]Value{"foo": {v1, v0}, "bar": {v0}}); err != nil {
+		t.Fatalf("failed to write keys foo and bar to cache: %s", err.Error())
+	}
+
+	s := c.spill(math.MaxUint64)
+	if s == nil {
+		t.Fatal("expected the cache to be spilled")
+	}
+	if got := c.Size(); got != 0 {
+		t.Fatalf("cache size incorrect after spill, exp 0, got %d", got)
+	}
+
+	// Values are readable while and after the spill is written.
+	expValues := Values{v0, v1}
+	if deduped := c.Values([]byte("foo")); !reflect.DeepEqual(expValues, deduped) {
+		t.Fatalf("spilled values for foo incorrect, exp: %v, got %v", expValues, deduped)
+	}
+	if err := s.write(); err != nil {
+		t.Fatalf("failed to write spill: %v", err)
+	}
+	if _, err := os.Stat(s.path); err != nil {
+		t.Fatalf("spill file not written: %v", err)
+	}
+	if deduped := c.Values([]byte("foo")); !reflect.DeepEqual(expValues, deduped) {
+		t.Fatalf("spilled values for foo incorrect, exp: %v, got %v", expValues, deduped)
+	}
+
+	// New writes take precedence over spilled values and deletes apply to them.
+	if err := c.Write([]byte("foo"), Values{v3, v2}); err != nil {
+		t.Fatalf("failed to write key foo to cache: %s", err.Error())
+	}
+	c.Delete([][]byte{[]byte("bar")})
+
+	expValues = Values{v0, v3, v2}
+	if deduped := c.Values([]byte("foo")); !reflect.DeepEqual(expValues, deduped) {
+		t.Fatalf("values for foo incorrect, exp: %v, got %v", expValues, deduped)
+	}
+	if deduped := c.Values([]byte("bar")); len(deduped) != 0 {
+		t.Fatalf("values for bar incorrect, exp none, got %v", deduped)
+	}
+	if exp, keys := [][]byte{[]byte("foo")}, c.Keys(); !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("cache keys incorrect, exp %v, got %v", exp, keys)
+	}
+
+	// The snapshot holds the spill until it is cleared.
+	snapshot, err := c.Snapshot()
+	if err != nil {
+		t.Fatalf("failed to snapshot cache: %v", err)
+	}
+	if !snapshot.spilled() {
+		t.Fatal("expected the snapshot to hold the spill")
+	}
+	snapshot.Deduplicate()
+	if deduped := snapshot.values([]byte("foo")); !reflect.DeepEqual(expValues, deduped) {
+		t.Fatalf("snapshotted values for foo incorrect, exp: %v, got %v", expValues, deduped)
+	}
+
+	c.ClearSnapshot(true)
+	if _, err := os.Stat(s.path); !os.IsNotExist(err) {
+		t.Fatalf("spill file not removed: %v", err)
+	}
+	if deduped := c.Values([]byte("foo")); len(deduped) != 0 {
+		t.Fatalf("values for foo incorrect after snapshot, exp none, got %v", deduped)
+	}
+}
+
 func TestCache_Deduplicate_Concurrent(t *testing.T) {
 	if testing.Short() || os.Getenv("GORACE") != "" || os.Getenv("APPVEYOR") != "" {
 		t.Skip("Skipping test in short, race, appveyor mode.")
@@ -802,6 +878,116 @@ func TestCacheLoader_LoadDeleted(t *testing.T) {
 	}
 }
 
+// Ensure the CacheLoader can load segments with zstd frames mixed with snappy
+// entries, and truncates a corrupted frame.
+func TestCacheLoader_LoadZstd(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	p1 := NewValue(1, 1.1)
+	p2 := NewValue(2, int64(2))
+	p3 := NewValue(3, "three")
+	p4 := NewValue(4, 4.4)
+
+	// Write a snappy entry, then reopen the segment with zstd compression.
+	w := NewWAL(dir)
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+	if _, err := w.WriteMulti(map[string][]Value{"foo": {p1}}); err != nil {
+		t.Fatalf("error writing points: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing WAL: %v", err)
+	}
+
+	w = NewWAL(dir)
+	w.compression = WALCompressionZstd
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+	if _, err := w.WriteMulti(map[string][]Value{"bar": {p2}, "baz": {p3}}); err != nil {
+		t.Fatalf("error writing points: %v", err)
+	}
+	if _, err := w.WriteMulti(map[string][]Value{"qux": {p4}}); err != nil {
+		t.Fatalf("error writing points: %v", err)
+	}
+	if _, err := w.Delete([][]byte{[]byte("qux")}); err != nil {
+		t.Fatalf("error deleting: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing WAL: %v", err)
+	}
+
+	files, err := segmentFileNames(dir)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("segment count mismatch: got %v, exp 1", len(files))
+	}
+
+	checkCache := func() {
+		t.Helper()
+		cache := NewCache(1024, "")
+		loader := NewCacheLoader(files)
+		if err := loader.Load(cache); err != nil {
+			t.Fatalf("failed to load cache: %s", err.Error())
+		}
+
+		if values := cache.Values([]byte("foo")); !reflect.DeepEqual(values, Values{p1}) {
+			t.Fatalf("cache key foo not as expected, got %v, exp %v", values, Values{p1})
+		}
+		if values := cache.Values([]byte("bar")); !reflect.DeepEqual(values, Values{p2}) {
+			t.Fatalf("cache key bar not as expected, got %v, exp %v", values, Values{p2})
+		}
+		if values := cache.Values([]byte("baz")); !reflect.DeepEqual(values, Values{p3}) {
+			t.Fatalf("cache key baz not as expected, got %v, exp %v", values, Values{p3})
+		}
+		if values := cache.Values([]byte("qux")); len(values) != 0 {
+			t.Fatalf("cache key qux not as expected, got %v, exp none", values)
+		}
+	}
+	checkCache()
+
+	stat, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Append a frame whose checksum does not match its contents.
+	f, err := os.OpenFile(files[0], os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := (&WriteWALEntry{Values: map[string][]Value{"foo": {p4}}}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := newWALSegmentWriter(f, WALCompressionZstd)
+	if err := sw.writeFrameEntry(WriteWALEntryType, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.close(); err != nil {
+		t.Fatal(err)
+	}
+	corrupt, err := ioutil.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupt[len(corrupt)-1]++
+	if err := ioutil.WriteFile(files[0], corrupt, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	// The corrupt frame is truncated and the valid entries are still loaded.
+	checkCache()
+	if fi, err := os.Stat(files[0]); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() != stat.Size() {
+		t.Fatalf("segment size mismatch: got %v, exp %v", fi.Size(), stat.Size())
+	}
+}
+
 func TestCache_Split(t *testing.T) {
 	v0 := NewValue(1, 1.0)
 	v1 := NewValue(2, 2.0)
@@ -769,7 +769,7 @@ func (k *tsmKeyIterator) combineString(dedup bool) blocks {
 func (k *tsmKeyIterator) chunkString(dst blocks) blocks {
 	if len(k.mergedStringValues) > k.size {
 		values := k.mergedStringValues[:k.size]
-		cb, err := StringValues(values).Encode(nil)
+		cb, err := encodeStringValuesBlockCodec(nil, values, k.codec)
 		if err != nil {
 			k.err = err
 			return nil
@@ -787,7 +787,7 @@ func (k *tsmKeyIterator) chunkString(dst blocks) blocks {
 
 	// Re-encode the remaining values into the last block
 	if len(k.mergedStringValues) > 0 {
-		cb, err := StringValues(k.mergedStringValues).Encode(nil)
+		cb, err := encodeStringValuesBlockCodec(nil, k.mergedStringValues, k.codec)
 		if err != nil {
 			k.err = err
 			return nil
@@ -14,6 +14,7 @@ package tsm1
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"math"
@@ -675,6 +676,19 @@ type Compactor struct {
 	// RateLimit is the limit for disk writes for all concurrent compactions.
 	RateLimit limiter.Rate
 
+	// ReadRateLimit is the limit for disk reads for all concurrent level and
+	// full compactions.
+	ReadRateLimit limiter.Rate
+
+	// BlockStatistics sets whether the statistics of each block are written
+	// to new TSM files.
+	BlockStatistics bool
+
+	// StringCodec is the codec used to compress the values of string blocks.
+	// String blocks using another codec are recompressed by full compactions,
+	// so existing files are migrated as they are fully compacted.
+	StringCodec StringCodec
+
 	mu                 sync.RWMutex
 	snapshotsEnabled   bool
 	compactionsEnabled bool
@@ -797,6 +811,11 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 		throttle = false
 	}
 
+	// Spilled entries may share keys with any part of the cache.
+	if cache.spilled() {
+		concurrency = 1
+	}
+
 	splits := cache.Split(concurrency)
 
 	type res struct {
@@ -807,8 +826,8 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 	resC := make(chan res, concurrency)
 	for i := 0; i < concurrency; i++ {
 		go func(sp *Cache) {
-			iter := NewCacheKeyIterator(sp, tsdb.DefaultMaxPointsPerBlock, intC)
-			files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, iter, throttle)
+			iter := newCacheKeyIterator(sp, tsdb.DefaultMaxPointsPerBlock, c.StringCodec, intC)
+			files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, iter, throttle, true)
 			resC <- res{files: files, err: err}
 
 		}(splits[i])
@@ -841,8 +860,18 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 	return files, err
 }
 
-// compact writes multiple smaller TSM files into 1 or more larger files.
-func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
+// WriteBulkLoad writes the values of a bulk load to new TSM files.  Unlike
+// snapshots, bulk loads are written while snapshots and compactions are
+// disabled and are not aborted by disabling them.
+func (c *Compactor) WriteBulkLoad(cache *Cache) ([]string, error) {
+	iter := newCacheKeyIterator(cache, tsdb.DefaultMaxPointsPerBlock, c.StringCodec, nil)
+	return c.writeNewFiles(c.FileStore.NextGeneration(), 0, iter, true, false)
+}
+
+// compact writes multiple smaller TSM files into 1 or more larger files.  If
+// recode is true, string blocks using another codec than StringCodec are
+// recompressed.
+func (c *Compactor) compact(fast, recode bool, tsmFiles []string) ([]string, error) {
 	size := c.Size
 	if size <= 0 {
 		size = tsdb.DefaultMaxPointsPerBlock
@@ -895,16 +924,39 @@ func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 		return nil, nil
 	}
 
-	tsm, err := NewTSMKeyIterator(size, fast, intC, trs...)
+	codec := c.StringCodec
+	if codec == 0 {
+		codec = StringCodecSnappy
+	}
+
+	tsm, err := newTSMKeyIterator(size, fast, codec, recode, intC, c.ReadRateLimit, trs...)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.writeNewFiles(maxGeneration, maxSequence, tsm, true)
+	return c.writeNewFiles(maxGeneration, maxSequence, tsm, true, true)
 }
 
 // CompactFull writes multiple smaller TSM files into 1 or more larger files.
+// String blocks using another codec than StringCodec are recompressed.
 func (c *Compactor) CompactFull(tsmFiles []string) ([]string, error) {
+	return c.compactFiles(false, true, tsmFiles)
+}
+
+// CompactLevel writes multiple smaller TSM files into 1 or more larger files.
+// Unlike CompactFull, blocks that are not merged are copied as is.
+func (c *Compactor) CompactLevel(tsmFiles []string) ([]string, error) {
+	return c.compactFiles(false, false, tsmFiles)
+}
+
+// CompactFast writes multiple smaller TSM files into 1 or more larger files.
+func (c *Compactor) CompactFast(tsmFiles []string) ([]string, error) {
+	return c.compactFiles(true, false, tsmFiles)
+}
+
+// compactFiles writes multiple smaller TSM files into 1 or more larger files
+// unless compactions are disabled or the files are already being compacted.
+func (c *Compactor) compactFiles(fast, recode bool, tsmFiles []string) ([]string, error) {
 	c.mu.RLock()
 	enabled := c.compactionsEnabled
 	c.mu.RUnlock()
@@ -918,7 +970,7 @@ func (c *Compactor) CompactFull(tsmFiles []string) ([]string, error) {
 	}
 	defer c.remove(tsmFiles)
 
-	files, err := c.compact(false, tsmFiles)
+	files, err := c.compact(fast, recode, tsmFiles)
 
 	// See if we were disabled while writing a snapshot
 	c.mu.RLock()
@@ -935,37 +987,53 @@ func (c *Compactor) CompactFull(tsmFiles []string) ([]string, error) {
 	return files, err
 }
 
-// CompactFast writes multiple smaller TSM files into 1 or more larger files.
-func (c *Compactor) CompactFast(tsmFiles []string) ([]string, error) {
+// PurgeTombstones rewrites the TSM file at path to a temporary file without
+// the data deleted by its tombstones.  Blocks not covered by a tombstone are
+// copied as is.  It returns the temporary file, which is empty if all the data
+// was deleted, and the number of blocks that were rewritten or dropped.
+func (c *Compactor) PurgeTombstones(path string) (string, int, error) {
 	c.mu.RLock()
 	enabled := c.compactionsEnabled
+	intC := c.compactionsInterrupt
 	c.mu.RUnlock()
 
 	if !enabled {
-		return nil, errCompactionsDisabled
+		return "", 0, errCompactionsDisabled
 	}
 
-	if !c.add(tsmFiles) {
-		return nil, errCompactionInProgress{}
+	if !c.add([]string{path}) {
+		return "", 0, errCompactionInProgress{}
 	}
-	defer c.remove(tsmFiles)
+	defer c.remove([]string{path})
 
-	files, err := c.compact(true, tsmFiles)
+	tr := c.FileStore.TSMReader(path)
+	if tr == nil {
+		return "", 0, errCompactionAborted{fmt.Errorf("bad plan: %s", path)}
+	}
 
-	// See if we were disabled while writing a snapshot
+	tmp := fmt.Sprintf("%s.%s", path, PurgeTmpExtension)
+	n, err := writePurgedFile(tmp, tr, c.StringCodec, c.RateLimit, c.BlockStatistics, intC)
+	if err == ErrNoValues {
+		tmp, err = "", nil
+	} else if err != nil {
+		return "", 0, err
+	}
+
+	// See if we were disabled while purging the file
 	c.mu.RLock()
 	enabled = c.compactionsEnabled
 	c.mu.RUnlock()
 
 	if !enabled {
-		if err := c.removeTmpFiles(files); err != nil {
-			return nil, err
+		if tmp != "" {
+			if err := c.removeTmpFiles([]string{tmp}); err != nil {
+				return "", 0, err
+			}
 		}
-		return nil, errCompactionsDisabled
+		return "", 0, errCompactionsDisabled
 	}
 
-	return files, err
-
+	return tmp, n, nil
 }
 
 // removeTmpFiles is responsible for cleaning up a compaction that
@@ -980,8 +1048,9 @@ func (c *Compactor) removeTmpFiles(files []string) error {
 }
 
 // writeNewFiles writes from the iterator into new TSM files, rotating
-// to a new file once it has reached the max TSM file size.
-func (c *Compactor) writeNewFiles(generation, sequence int, iter KeyIterator, throttle bool) ([]string, error) {
+// to a new file once it has reached the max TSM file size.  If abortable is
+// true, writing stops once snapshots and compactions are both disabled.
+func (c *Compactor) writeNewFiles(generation, sequence int, iter KeyIterator, throttle, abortable bool) ([]string, error) {
 	// These are the new TSM files written
 	var files []string
 
@@ -991,7 +1060,7 @@ func (c *Compactor) writeNewFiles(generation, sequence int, iter KeyIterator, th
 		fileName := filepath.Join(c.Dir, fmt.Sprintf("%09d-%09d.%s.%s", generation, sequence, TSMFileExtension, TmpTSMFileExtension))
 
 		// Write as much as possible to this file
-		err := c.write(fileName, iter, throttle)
+		err := c.write(fileName, iter, throttle, abortable)
 
 		// We've hit the max file limit and there is more to write.  Create a new file
 		// and continue.
@@ -1030,7 +1099,7 @@ func (c *Compactor) writeNewFiles(generation, sequence int, iter KeyIterator, th
 	return files, nil
 }
 
-func (c *Compactor) write(path string, iter KeyIterator, throttle bool) (err error) {
+func (c *Compactor) write(path string, iter KeyIterator, throttle, abortable bool) (err error) {
 	fd, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
 	if err != nil {
 		return errCompactionInProgress{err: err}
@@ -1049,12 +1118,12 @@ func (c *Compactor) write(path string, iter KeyIterator, throttle bool) (err err
 	// Use a disk based TSM buffer if it looks like we might create a big index
 	// in memory.
 	if iter.EstimatedIndexSize() > 64*1024*1024 {
-		w, err = NewTSMWriterWithDiskBuffer(limitWriter)
+		w, err = NewTSMWriterWithDiskBuffer(limitWriter, WithBlockStatistics(c.BlockStatistics))
 		if err != nil {
 			return err
 		}
 	} else {
-		w, err = NewTSMWriter(limitWriter)
+		w, err = NewTSMWriter(limitWriter, WithBlockStatistics(c.BlockStatistics))
 		if err != nil {
 			return err
 		}
@@ -1084,7 +1153,7 @@ func (c *Compactor) write(path string, iter KeyIterator, throttle bool) (err err
 		enabled := c.snapshotsEnabled || c.compactionsEnabled
 		c.mu.RUnlock()
 
-		if !enabled {
+		if abortable && !enabled {
 			return errCompactionAborted{}
 		}
 		// Each call to read returns the next sorted key (or the prior one if there are
@@ -1202,6 +1271,11 @@ type tsmKeyIterator struct {
 	// size is the maximum number of values to encode in a single block
 	size int
 
+	// codec is the codec used to compress merged string blocks.  If recode is
+	// true, string blocks used as is are recompressed with it as well.
+	codec  StringCodec
+	recode bool
+
 	// key is the current key lowest key across all readers that has not be fully exhausted
 	// of values.
 	key []byte
@@ -1223,6 +1297,9 @@ type tsmKeyIterator struct {
 	// without decode
 	merged    blocks
 	interrupt chan struct{}
+
+	// readLimit limits the rate that blocks are read at, if set.
+	readLimit limiter.Rate
 }
 
 type block struct {
@@ -1280,6 +1357,13 @@ func (a blocks) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 // NewTSMKeyIterator returns a new TSM key iterator from readers.
 // size indicates the maximum number of values to encode in a single block.
 func NewTSMKeyIterator(size int, fast bool, interrupt chan struct{}, readers ...*TSMReader) (KeyIterator, error) {
+	return newTSMKeyIterator(size, fast, StringCodecSnappy, false, interrupt, nil, readers...)
+}
+
+// newTSMKeyIterator returns a new TSM key iterator from readers that encodes
+// merged string blocks using codec.  If recode is true, string blocks that are
+// not merged are recompressed using codec as well.
+func newTSMKeyIterator(size int, fast bool, codec StringCodec, recode bool, interrupt chan struct{}, readLimit limiter.Rate, readers ...*TSMReader) (KeyIterator, error) {
 	var iter []*BlockIterator
 	for _, r := range readers {
 		iter = append(iter, r.BlockIterator())
@@ -1292,11 +1376,24 @@ func NewTSMKeyIterator(size int, fast bool, interrupt chan struct{}, readers ...
 		size:      size,
 		iterators: iter,
 		fast:      fast,
+		codec:     codec,
+		recode:    recode,
 		buf:       make([]blocks, len(iter)),
 		interrupt: interrupt,
+		readLimit: readLimit,
 	}, nil
 }
 
+// throttle waits until the read limit allows n more bytes to be read.
+func (k *tsmKeyIterator) throttle(n int) {
+	if k.readLimit == nil {
+		return
+	}
+	if err := k.readLimit.WaitN(context.Background(), n); err != nil {
+		k.err = err
+	}
+}
+
 func (k *tsmKeyIterator) hasMergedValues() bool {
 	return len(k.mergedFloatValues) > 0 ||
 		len(k.mergedIntegerValues) > 0 ||
@@ -1349,6 +1446,7 @@ RETRY:
 				if err != nil {
 					k.err = err
 				}
+				k.throttle(len(b))
 
 				// This block may have ranges of time removed from it that would
 				// reduce the block min and max time.
@@ -1382,6 +1480,7 @@ RETRY:
 					if err != nil {
 						k.err = err
 					}
+					k.throttle(len(b))
 
 					tombstones := iter.r.TombstoneRange(key)
 
@@ -1490,6 +1589,13 @@ func (k *tsmKeyIterator) Read() ([]byte, int64, int64, []byte, error) {
 	}
 
 	block := k.merged[0]
+	if k.recode && k.err == nil && len(block.b) > 0 && block.b[0] == BlockString {
+		b, err := recodeStringBlock(block.b, k.codec)
+		if err != nil {
+			return nil, 0, 0, nil, err
+		}
+		block.b = b
+	}
 	return block.key, block.minTime, block.maxTime, block.b, k.err
 }
 
@@ -1513,6 +1619,7 @@ func (k *tsmKeyIterator) Err() error {
 type cacheKeyIterator struct {
 	cache *Cache
 	size  int
+	codec StringCodec
 	order [][]byte
 
 	i         int
@@ -1531,6 +1638,12 @@ type cacheBlock struct {
 
 // NewCacheKeyIterator returns a new KeyIterator from a Cache.
 func NewCacheKeyIterator(cache *Cache, size int, interrupt chan struct{}) KeyIterator {
+	return newCacheKeyIterator(cache, size, StringCodecSnappy, interrupt)
+}
+
+// newCacheKeyIterator returns a new KeyIterator from a Cache that compresses
+// string blocks using codec.
+func newCacheKeyIterator(cache *Cache, size int, codec StringCodec, interrupt chan struct{}) KeyIterator {
 	keys := cache.Keys()
 
 	chans := make([]chan struct{}, len(keys))
@@ -1546,6 +1659,7 @@ func NewCacheKeyIterator(cache *Cache, size int, interrupt chan struct{}) KeyIte
 		ready:     chans,
 		blocks:    make([][]cacheBlock, len(keys)),
 		interrupt: interrupt,
+		codec:     codec,
 	}
 	go cki.encode()
 	return cki
@@ -1575,6 +1689,7 @@ func (c *cacheKeyIterator) encode() {
 			benc := getBooleanEncoder(tsdb.DefaultMaxPointsPerBlock)
 			uenc := getUnsignedEncoder(tsdb.DefaultMaxPointsPerBlock)
 			senc := getStringEncoder(tsdb.DefaultMaxPointsPerBlock)
+			senc.SetCodec(c.codec)
 			ienc := getIntegerEncoder(tsdb.DefaultMaxPointsPerBlock)
 
 			defer putTimeEncoder(tenc)
@@ -1757,3 +1872,26 @@ func (l *latencies) avg() time.Duration {
 	}
 	return time.Duration(0)
 }
+
+// throttledRate is a limiter.Rate that adds the time spent waiting on the
+// rate to a statistic.
+type throttledRate struct {
+	rate     limiter.Rate
+	duration *int64
+}
+
+// newThrottledRate returns rate adding its wait time to duration, or nil if
+// rate is nil.
+func newThrottledRate(rate limiter.Rate, duration *int64) limiter.Rate {
+	if rate == nil {
+		return nil
+	}
+	return &throttledRate{rate: rate, duration: duration}
+}
+
+func (r *throttledRate) WaitN(ctx context.Context, n int) error {
+	start := time.Now()
+	err := r.rate.WaitN(ctx, n)
+	atomic.AddInt64(r.duration, time.Since(start).Nanoseconds())
+	return err
+}
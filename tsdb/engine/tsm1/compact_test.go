@@ -195,6 +195,134 @@ func TestCompactor_CompactFull(t *testing.T) {
 }
 
 // Ensures that a compaction will properly merge multiple TSM files
+// Ensures that full compactions recompress string blocks using the codec of
+// the compactor.
+func TestCompactor_CompactFull_StringCodec(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	// The block of the first file is full and is copied as is, while the
+	// block of the second file is decoded and encoded again.
+	a := []tsm1.Value{tsm1.NewValue(1, "a"), tsm1.NewValue(2, "b")}
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{"cpu,host=A#!~#msg": a})
+
+	b := []tsm1.Value{tsm1.NewValue(1, "c")}
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{"cpu,host=B#!~#msg": b})
+
+	fs := &fakeFileStore{}
+	defer fs.Close()
+	compactor := &tsm1.Compactor{
+		Dir:         dir,
+		FileStore:   fs,
+		Size:        2,
+		StringCodec: tsm1.StringCodecZstd,
+	}
+	compactor.Open()
+
+	files, err := compactor.CompactFull([]string{f1, f2})
+	if err != nil {
+		t.Fatalf("unexpected error writing snapshot: %v", err)
+	}
+
+	if got, exp := len(files), 1; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := MustOpenTSMReader(files[0])
+	defer r.Close()
+
+	for key, exp := range map[string][]tsm1.Value{
+		"cpu,host=A#!~#msg": a,
+		"cpu,host=B#!~#msg": b,
+	} {
+		entries := r.Entries([]byte(key))
+		if got, exp := len(entries), 1; got != exp {
+			t.Fatalf("entries length mismatch for %s: got %v, exp %v", key, got, exp)
+		}
+
+		_, block, err := r.ReadBytes(&entries[0], nil)
+		if err != nil {
+			t.Fatalf("unexpected error reading block: %v", err)
+		}
+		if codec, err := tsm1.StringBlockCodec(block); err != nil {
+			t.Fatalf("unexpected error reading codec: %v", err)
+		} else if codec != tsm1.StringCodecZstd {
+			t.Fatalf("codec mismatch for %s: got %v, exp %v", key, codec, tsm1.StringCodecZstd)
+		}
+
+		values, err := r.ReadAll([]byte(key))
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+		if got, exp := len(values), len(exp); got != exp {
+			t.Fatalf("values length mismatch for %s: got %v, exp %v", key, got, exp)
+		}
+		for i, v := range exp {
+			if got, exp := values[i].String(), v.String(); got != exp {
+				t.Fatalf("value mismatch for %s: got %v, exp %v", key, got, exp)
+			}
+		}
+	}
+}
+
+// Ensures that level compactions copy full string blocks as is and encode
+// merged string blocks using the codec of the compactor.
+func TestCompactor_CompactLevel_StringCodec(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	// The full block of host=A is copied while the partial blocks of host=B
+	// are merged.
+	a := []tsm1.Value{tsm1.NewValue(1, "a"), tsm1.NewValue(2, "b")}
+	b1 := []tsm1.Value{tsm1.NewValue(1, "c")}
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{"cpu,host=A#!~#msg": a, "cpu,host=B#!~#msg": b1})
+
+	b2 := []tsm1.Value{tsm1.NewValue(2, "d")}
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{"cpu,host=B#!~#msg": b2})
+
+	fs := &fakeFileStore{}
+	defer fs.Close()
+	compactor := &tsm1.Compactor{
+		Dir:         dir,
+		FileStore:   fs,
+		Size:        2,
+		StringCodec: tsm1.StringCodecZstd,
+	}
+	compactor.Open()
+
+	files, err := compactor.CompactLevel([]string{f1, f2})
+	if err != nil {
+		t.Fatalf("unexpected error writing snapshot: %v", err)
+	}
+
+	if got, exp := len(files), 1; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := MustOpenTSMReader(files[0])
+	defer r.Close()
+
+	for key, exp := range map[string]tsm1.StringCodec{
+		"cpu,host=A#!~#msg": tsm1.StringCodecSnappy,
+		"cpu,host=B#!~#msg": tsm1.StringCodecZstd,
+	} {
+		entries := r.Entries([]byte(key))
+		if got, exp := len(entries), 1; got != exp {
+			t.Fatalf("entries length mismatch for %s: got %v, exp %v", key, got, exp)
+		}
+
+		_, block, err := r.ReadBytes(&entries[0], nil)
+		if err != nil {
+			t.Fatalf("unexpected error reading block: %v", err)
+		}
+		if codec, err := tsm1.StringBlockCodec(block); err != nil {
+			t.Fatalf("unexpected error reading codec: %v", err)
+		} else if codec != exp {
+			t.Fatalf("codec mismatch for %s: got %v, exp %v", key, codec, exp)
+		}
+	}
+}
+
 func TestCompactor_Compact_OverlappingBlocks(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
@@ -940,6 +940,90 @@ func DecodeStringBlock(block []byte, a *[]StringValue) ([]StringValue, error) {
 	return (*a)[:i], err
 }
 
+// StringBlockCodec returns the codec used to compress the values of the
+// string block.
+func StringBlockCodec(block []byte) (StringCodec, error) {
+	if len(block) == 0 || block[0] != BlockString {
+		return 0, fmt.Errorf("invalid block type: exp %d", BlockString)
+	}
+
+	_, vb, err := unpackBlock(block[1:])
+	if err != nil {
+		return 0, err
+	} else if len(vb) == 0 {
+		return 0, fmt.Errorf("StringBlockCodec: no values in block")
+	}
+	return StringCodec(vb[0] >> 4), nil
+}
+
+// recodeStringBlock returns the string block with its values compressed using
+// codec.  The block is returned as is if it already uses codec.
+func recodeStringBlock(block []byte, codec StringCodec) ([]byte, error) {
+	if c, err := StringBlockCodec(block); err != nil {
+		return nil, err
+	} else if c == codec {
+		return block, nil
+	}
+
+	values, err := DecodeStringBlock(block, &[]StringValue{})
+	if err != nil {
+		return nil, err
+	}
+	return encodeStringValuesBlockCodec(nil, values, codec)
+}
+
+// encodeStringValuesBlockCodec returns a string block of values compressed
+// using codec.
+func encodeStringValuesBlockCodec(buf []byte, values []StringValue, codec StringCodec) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	tenc := getTimeEncoder(len(values))
+	venc := getStringEncoder(len(values))
+	venc.SetCodec(codec)
+
+	var b []byte
+	err := func() error {
+		for _, v := range values {
+			tenc.Write(v.unixnano)
+			venc.Write(v.value)
+		}
+
+		tb, err := tenc.Bytes()
+		if err != nil {
+			return err
+		}
+		vb, err := venc.Bytes()
+		if err != nil {
+			return err
+		}
+
+		b = packBlock(buf, BlockString, tb, vb)
+		return nil
+	}()
+
+	putTimeEncoder(tenc)
+	putStringEncoder(venc)
+
+	return b, err
+}
+
+// encodeStringBlockCodec returns a string block of values compressed using
+// codec.
+func encodeStringBlockCodec(buf []byte, values []Value, codec StringCodec) ([]byte, error) {
+	tenc := getTimeEncoder(len(values))
+	venc := getStringEncoder(len(values) * len(values[0].(StringValue).value))
+	venc.SetCodec(codec)
+
+	b, err := encodeStringBlockUsing(buf, values, tenc, venc)
+
+	putTimeEncoder(tenc)
+	putStringEncoder(venc)
+
+	return b, err
+}
+
 func packBlock(buf []byte, typ byte, ts []byte, values []byte) []byte {
 	// We encode the length of the timestamp block using a variable byte encoding.
 	// This allows small byte slices to take up 1 byte while larger ones use 2 or more.
@@ -1022,6 +1106,7 @@ func putFloatEncoder(enc *FloatEncoder) { floatEncoderPool.Put(enc) }
 func getStringEncoder(sz int) StringEncoder {
 	x := stringEncoderPool.Get(sz).(StringEncoder)
 	x.Reset()
+	x.SetCodec(StringCodecSnappy)
 	return x
 }
 func putStringEncoder(enc StringEncoder) { stringEncoderPool.Put(enc) }
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -84,6 +85,19 @@ const (
 
 	// deleteFlushThreshold is the size in bytes of a batch of series keys to delete.
 	deleteFlushThreshold = 50 * 1024 * 1024
+
+	// cacheSpillTimeout is how long a write waits for room in a full cache
+	// when cache spilling is enabled.
+	cacheSpillTimeout = 10 * time.Second
+
+	// tombstonePurgeReplaceRetries and tombstonePurgeReplaceInterval bound
+	// how long a tombstone purge waits for queries to release the file.
+	tombstonePurgeReplaceRetries  = 50
+	tombstonePurgeReplaceInterval = 100 * time.Millisecond
+
+	// cacheSpillRetryInterval is how often a write waiting for room in a full
+	// cache retries to snapshot and spill the cache.
+	cacheSpillRetryInterval = 100 * time.Millisecond
 )
 
 // Statistics gathered by the engine.
@@ -122,6 +136,19 @@ const (
 	statTSMFullCompactionError    = "tsmFullCompactionErr"
 	statTSMFullCompactionDuration = "tsmFullCompactionDuration"
 	statTSMFullCompactionQueue    = "tsmFullCompactionQueue"
+
+	statTSMCompactionReadThrottleDuration  = "tsmCompactionReadThrottleDuration"
+	statTSMCompactionWriteThrottleDuration = "tsmCompactionWriteThrottleDuration"
+
+	statTSMTombstonePurges        = "tsmTombstonePurges"
+	statTSMTombstonePurgesActive  = "tsmTombstonePurgesActive"
+	statTSMTombstonePurgeError    = "tsmTombstonePurgeErr"
+	statTSMTombstonePurgeDuration = "tsmTombstonePurgeDuration"
+
+	statTSMBulkLoads        = "tsmBulkLoads"
+	statTSMBulkLoadError    = "tsmBulkLoadErr"
+	statTSMBulkLoadDuration = "tsmBulkLoadDuration"
+	statTSMBulkLoadValues   = "tsmBulkLoadValues"
 )
 
 // Engine represents a storage engine with compressed blocks.
@@ -130,6 +157,11 @@ type Engine struct {
 
 	index tsdb.Index
 
+	// The index being built by BuildIndex, if any.  It is updated with the
+	// series created and dropped until StopBuildingIndex is called.
+	buildMu sync.RWMutex
+	build   *indexBuild
+
 	// The following group of fields is used to track the state of level compactions within the
 	// Engine. The WaitGroup is used to monitor the compaction goroutines, the 'done' channel is
 	// used to signal those goroutines to shutdown. Every request to disable level compactions will
@@ -172,6 +204,19 @@ type Engine struct {
 	// a snapshot of the cache to a TSM file
 	CacheFlushWriteColdDuration time.Duration
 
+	// TombstonePurgeThreshold specifies the size of the tombstones of a TSM
+	// file above which the engine rewrites the file without the deleted data.
+	TombstonePurgeThreshold int64
+
+	// BulkLoadBufferSize specifies the size of the bulk loaded values that are
+	// buffered before they are written to TSM files.
+	BulkLoadBufferSize uint64
+
+	// CacheSpillEnabled determines whether writes exceeding the maximum cache
+	// size force a snapshot and spill cold cache partitions to disk instead
+	// of failing.
+	CacheSpillEnabled bool
+
 	// WALEnabled determines whether writes to the WAL are enabled.  If this is false,
 	// writes will only exist in the cache and can be lost if a snapshot has not occurred.
 	WALEnabled bool
@@ -179,8 +224,24 @@ type Engine struct {
 	// Controls whether to enabled compactions when the engine is open
 	enableCompactionsOnOpen bool
 
+	// Determines whether aggregates are computed from the statistics of the
+	// blocks of TSM files instead of their values.
+	blockStatistics bool
+
 	stats *EngineStatistics
 
+	// The bulk loaded values that are not written to TSM files yet, with
+	// their count and time range, the log they are written to until then,
+	// and the timer that writes them once the engine has not been bulk
+	// loaded for CacheFlushWriteColdDuration.
+	bulkMu           sync.Mutex
+	bulkCache        *Cache
+	bulkWAL          *WAL
+	bulkN            int
+	bulkMin, bulkMax int64
+	bulkTimer        *time.Timer
+	bulkLoadObserver func(shardID uint64, min, max int64)
+
 	// Limiter for concurrent compactions.
 	compactionLimiter limiter.Fixed
 
@@ -194,18 +255,32 @@ type Engine struct {
 func NewEngine(id uint64, idx tsdb.Index, database, path string, walPath string, sfile *tsdb.SeriesFile, opt tsdb.EngineOptions) tsdb.Engine {
 	w := NewWAL(walPath)
 	w.syncDelay = time.Duration(opt.Config.WALFsyncDelay)
+	w.syncObserver = opt.WALSyncObserver
+	// The compression has been validated with the configuration.
+	w.compression, _ = ParseWALCompression(opt.Config.WALCompression)
+
+	// Buffered bulk loads are logged separately from the values in the cache.
+	bw := NewWAL(filepath.Join(walPath, bulkLoadWALDirectory))
+	bw.syncDelay = w.syncDelay
+	bw.compression = w.compression
 
 	fs := NewFileStore(path)
 	cache := NewCache(uint64(opt.Config.CacheMaxMemorySize), path)
 
+	// The codec has been validated with the configuration.
+	codec, _ := ParseStringCodec(opt.Config.StringCodec(database))
+
+	stats := &EngineStatistics{}
 	c := &Compactor{
-		Dir:       path,
-		FileStore: fs,
-		RateLimit: opt.CompactionThroughputLimiter,
+		Dir:             path,
+		FileStore:       fs,
+		RateLimit:       newThrottledRate(opt.CompactionThroughputLimiter, &stats.TSMCompactionWriteThrottleDuration),
+		ReadRateLimit:   newThrottledRate(opt.CompactionReadThroughputLimiter, &stats.TSMCompactionReadThrottleDuration),
+		BlockStatistics: opt.Config.TSMBlockStatistics,
+		StringCodec:     codec,
 	}
 
 	logger := zap.NewNop()
-	stats := &EngineStatistics{}
 	e := &Engine{
 		id:           id,
 		database:     database,
@@ -225,18 +300,26 @@ func NewEngine(id uint64, idx tsdb.Index, database, path string, walPath string,
 
 		CacheFlushMemorySizeThreshold: uint64(opt.Config.CacheSnapshotMemorySize),
 		CacheFlushWriteColdDuration:   time.Duration(opt.Config.CacheSnapshotWriteColdDuration),
+		CacheSpillEnabled:             opt.Config.CacheSpillEnabled,
+		BulkLoadBufferSize:            uint64(opt.Config.BulkLoadBufferSize),
+		TombstonePurgeThreshold:       int64(opt.Config.CompactTombstonePurgeThreshold),
 		enableCompactionsOnOpen:       true,
+		blockStatistics:               opt.Config.TSMBlockStatistics,
 		WALEnabled:                    opt.WALEnabled,
 		stats:                         stats,
 		compactionLimiter:             opt.CompactionLimiter,
 		scheduler:                     newScheduler(stats, opt.CompactionLimiter.Capacity()),
 		seriesIDSets:                  opt.SeriesIDSets,
+		bulkLoadObserver:              opt.BulkLoadObserver,
+		bulkWAL:                       bw,
 	}
 
 	if e.traceLogging {
 		fs.enableTraceLogging(true)
 		w.enableTraceLogging(true)
+		bw.enableTraceLogging(true)
 	}
+	fs.enableChecksumVerification(opt.Config.TSMVerifyChecksums)
 
 	return e
 }
@@ -471,7 +554,7 @@ func (e *Engine) disableSnapshotCompactions() {
 	e.mu.Unlock()
 
 	// If the cache is empty, free up its resources as well.
-	if e.Cache.Size() == 0 {
+	if e.Cache.Size() == 0 && !e.Cache.spilled() {
 		e.Cache.Free()
 	}
 }
@@ -588,6 +671,19 @@ type EngineStatistics struct {
 	TSMFullCompactionErrors   int64 // Counter of full compactions that have failed due to error.
 	TSMFullCompactionDuration int64 // Counter of number of wall nanoseconds spent in full compactions.
 	TSMFullCompactionsQueue   int64 // Gauge of full compactions queue.
+
+	TSMCompactionReadThrottleDuration  int64 // Counter of number of wall nanoseconds compactions waited on the read limit.
+	TSMCompactionWriteThrottleDuration int64 // Counter of number of wall nanoseconds compactions waited on the write limit.
+
+	TSMTombstonePurges        int64 // Counter of tombstone purges that have ever run.
+	TSMTombstonePurgesActive  int64 // Gauge of tombstone purges currently running.
+	TSMTombstonePurgeErrors   int64 // Counter of tombstone purges that have failed due to error.
+	TSMTombstonePurgeDuration int64 // Counter of number of wall nanoseconds spent in tombstone purges.
+
+	TSMBulkLoads        int64 // Counter of bulk loads that have ever run.
+	TSMBulkLoadErrors   int64 // Counter of bulk loads that have failed due to error.
+	TSMBulkLoadDuration int64 // Counter of number of wall nanoseconds spent in bulk loads.
+	TSMBulkLoadValues   int64 // Counter of values written by bulk loads.
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -631,6 +727,19 @@ func (e *Engine) Statistics(tags map[string]string) []models.Statistic {
 			statTSMFullCompactionError:    atomic.LoadInt64(&e.stats.TSMFullCompactionErrors),
 			statTSMFullCompactionDuration: atomic.LoadInt64(&e.stats.TSMFullCompactionDuration),
 			statTSMFullCompactionQueue:    atomic.LoadInt64(&e.stats.TSMFullCompactionsQueue),
+
+			statTSMCompactionReadThrottleDuration:  atomic.LoadInt64(&e.stats.TSMCompactionReadThrottleDuration),
+			statTSMCompactionWriteThrottleDuration: atomic.LoadInt64(&e.stats.TSMCompactionWriteThrottleDuration),
+
+			statTSMTombstonePurges:        atomic.LoadInt64(&e.stats.TSMTombstonePurges),
+			statTSMTombstonePurgesActive:  atomic.LoadInt64(&e.stats.TSMTombstonePurgesActive),
+			statTSMTombstonePurgeError:    atomic.LoadInt64(&e.stats.TSMTombstonePurgeErrors),
+			statTSMTombstonePurgeDuration: atomic.LoadInt64(&e.stats.TSMTombstonePurgeDuration),
+
+			statTSMBulkLoads:        atomic.LoadInt64(&e.stats.TSMBulkLoads),
+			statTSMBulkLoadError:    atomic.LoadInt64(&e.stats.TSMBulkLoadErrors),
+			statTSMBulkLoadDuration: atomic.LoadInt64(&e.stats.TSMBulkLoadDuration),
+			statTSMBulkLoadValues:   atomic.LoadInt64(&e.stats.TSMBulkLoadValues),
 		},
 	})
 
@@ -642,7 +751,7 @@ func (e *Engine) Statistics(tags map[string]string) []models.Statistic {
 
 // DiskSize returns the total size in bytes of all TSM and WAL segments on disk.
 func (e *Engine) DiskSize() int64 {
-	return e.FileStore.DiskSizeBytes() + e.WAL.DiskSizeBytes() + e.index.DiskSizeBytes()
+	return e.FileStore.DiskSizeBytes() + e.WAL.DiskSizeBytes() + e.bulkWAL.DiskSizeBytes() + e.index.DiskSizeBytes()
 }
 
 // Open opens and initializes the engine.
@@ -680,6 +789,10 @@ func (e *Engine) Open() error {
 
 	e.Compactor.Open()
 
+	if err := e.reloadBulkLoad(); err != nil {
+		return err
+	}
+
 	if e.enableCompactionsOnOpen {
 		e.SetCompactionsEnabled(true)
 	}
@@ -689,6 +802,9 @@ func (e *Engine) Open() error {
 
 // Close closes the engine. Subsequent calls to Close are a nop.
 func (e *Engine) Close() error {
+	if err := e.FlushBulkLoad(); err != nil {
+		e.logger.Info("Error writing bulk loaded points", zap.Error(err))
+	}
 	e.SetCompactionsEnabled(false)
 
 	// Lock now and close everything else down.
@@ -699,6 +815,9 @@ func (e *Engine) Close() error {
 	if err := e.FileStore.Close(); err != nil {
 		return err
 	}
+	if err := e.bulkWAL.Close(); err != nil {
+		return err
+	}
 	return e.WAL.Close()
 }
 
@@ -711,6 +830,7 @@ func (e *Engine) WithLogger(log *zap.Logger) {
 	}
 
 	e.WAL.WithLogger(e.logger)
+	e.bulkWAL.WithLogger(e.logger)
 	e.FileStore.WithLogger(e.logger)
 }
 
@@ -808,6 +928,10 @@ func (e *Engine) LoadMetadataIndex(shardID uint64, index tsdb.Index) error {
 func (e *Engine) IsIdle() bool {
 	cacheEmpty := e.Cache.Size() == 0
 
+	e.bulkMu.Lock()
+	bulkEmpty := e.bulkCache == nil
+	e.bulkMu.Unlock()
+
 	runningCompactions := atomic.LoadInt64(&e.stats.CacheCompactionsActive)
 	runningCompactions += atomic.LoadInt64(&e.stats.TSMCompactionsActive[0])
 	runningCompactions += atomic.LoadInt64(&e.stats.TSMCompactionsActive[1])
@@ -815,7 +939,24 @@ func (e *Engine) IsIdle() bool {
 	runningCompactions += atomic.LoadInt64(&e.stats.TSMFullCompactionsActive)
 	runningCompactions += atomic.LoadInt64(&e.stats.TSMOptimizeCompactionsActive)
 
-	return cacheEmpty && runningCompactions == 0 && e.CompactionPlan.FullyCompacted()
+	return cacheEmpty && bulkEmpty && runningCompactions == 0 && e.CompactionPlan.FullyCompacted()
+}
+
+// MaxTime returns the highest timestamp of the values in the TSM files.  Values
+// in the cache are not indexed by time, so math.MaxInt64 is returned if the
+// cache is not empty.  Returns math.MinInt64 if the engine holds no values.
+func (e *Engine) MaxTime() int64 {
+	if e.Cache.Size() > 0 {
+		return math.MaxInt64
+	}
+
+	max := int64(math.MinInt64)
+	for _, stat := range e.FileStore.Stats() {
+		if stat.MaxTime > max {
+			max = stat.MaxTime
+		}
+	}
+	return max
 }
 
 // Free releases any resources held by the engine to free up memory or CPU.
@@ -1159,27 +1300,586 @@ func (e *Engine) addToIndexFromKey(keys [][]byte, fieldTypes []influxql.DataType
 			return err
 		}
 
-		names = append(names, name)
-		tags = append(tags, models.ParseTags(keys[i]))
+		names = append(names, name)
+		tags = append(tags, models.ParseTags(keys[i]))
+	}
+
+	// Build in-memory index, if necessary.
+	if e.index.Type() == inmem.IndexName {
+		if err := e.index.InitializeSeries(keys, names, tags); err != nil {
+			return err
+		}
+	} else {
+		if err := e.CreateSeriesListIfNotExists(keys, names, tags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexBuild represents an index being built from the series keys of the
+// engine.
+type indexBuild struct {
+	index tsdb.Index
+
+	mu  sync.Mutex
+	err error // first error updating the index
+}
+
+// BuildIndex adds the series of the TSM files and the cache to index, which
+// is then kept up to date with the series created and dropped by the engine
+// until StopBuildingIndex is called.  progress is called with the number of
+// series added after each batch, and the build is aborted if it returns an
+// error.
+func (e *Engine) BuildIndex(index tsdb.Index, progress func(seriesN int) error) error {
+	e.buildMu.Lock()
+	if e.build != nil {
+		e.buildMu.Unlock()
+		return errors.New("index build already in progress")
+	}
+	e.build = &indexBuild{index: index}
+	e.buildMu.Unlock()
+
+	if err := e.buildIndex(index, progress); err != nil {
+		e.StopBuildingIndex()
+		return err
+	}
+	return nil
+}
+
+// buildIndex adds the series of the TSM files and the cache to index.
+func (e *Engine) buildIndex(index tsdb.Index, progress func(seriesN int) error) error {
+	var seriesN int
+	var prev []byte
+	keys := make([][]byte, 0, 10000)
+	names := make([][]byte, 0, 10000)
+	tags := make([]models.Tags, 0, 10000)
+
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := index.CreateSeriesListIfNotExists(keys, names, tags); err != nil {
+			if _, ok := err.(*tsdb.PartialWriteError); !ok {
+				return err
+			}
+		}
+
+		seriesN += len(keys)
+		keys, names, tags = keys[:0], names[:0], tags[:0]
+		return progress(seriesN)
+	}
+
+	add := func(key []byte) error {
+		// Fields of a series are sorted together so only add each series once.
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+		if bytes.Equal(seriesKey, prev) {
+			return nil
+		}
+		prev = seriesKey
+
+		keys = append(keys, seriesKey)
+		names = append(names, tsdb.MeasurementFromSeriesKey(seriesKey))
+		tags = append(tags, models.ParseTags(seriesKey))
+		if len(keys) == cap(keys) {
+			return flush()
+		}
+		return nil
+	}
+
+	if err := e.FileStore.WalkKeys(nil, func(key []byte, _ byte) error {
+		return add(key)
+	}); err != nil {
+		return err
+	}
+
+	prev = nil
+	for _, key := range e.Cache.Keys() {
+		if err := add(key); err != nil {
+			return err
+		}
+	}
+	return flush()
+}
+
+// StopBuildingIndex stops updating the index given to BuildIndex.  It returns
+// the first error that occurred while updating the index, if any.
+func (e *Engine) StopBuildingIndex() error {
+	e.buildMu.Lock()
+	defer e.buildMu.Unlock()
+
+	if e.build == nil {
+		return nil
+	}
+	err := e.build.err
+	e.build = nil
+	return err
+}
+
+// updateIndexBuild applies fn to the index being built, if any.  Errors are
+// returned by StopBuildingIndex so they do not fail the engine operation.
+func (e *Engine) updateIndexBuild(fn func(index tsdb.Index) error) {
+	e.buildMu.RLock()
+	defer e.buildMu.RUnlock()
+
+	if e.build == nil {
+		return
+	}
+	if err := fn(e.build.index); err != nil {
+		if _, ok := err.(*tsdb.PartialWriteError); ok {
+			return
+		}
+
+		e.build.mu.Lock()
+		if e.build.err == nil {
+			e.build.err = err
+		}
+		e.build.mu.Unlock()
+	}
+}
+
+// SetIndex replaces the index of the engine.  It must not be called
+// concurrently with other engine operations using the index.
+func (e *Engine) SetIndex(index tsdb.Index) {
+	e.index = index
+	e.index.SetFieldSet(e.fieldset)
+}
+
+// IngestTSMFiles adds the externally produced TSM files at paths to the
+// engine without replaying their values through the WAL and the cache.  Each
+// file is validated first: its keys must be sorted, the blocks of each key
+// must be sorted, not overlap and lie within minTime and maxTime, and the
+// types of its fields must match the existing fields of the shard and the
+// other files.  The files are hard linked into the shard, or copied if they
+// cannot be linked, and are added together once the missing series and
+// fields are created, so either all the files or none of them become
+// visible.  Like bulk loaded files, values in the cache take precedence over
+// ingested values with the same timestamp until the cache is snapshotted.
+func (e *Engine) IngestTSMFiles(paths []string, minTime, maxTime int64) error {
+	fieldTypes := make(map[string]influxql.DataType)
+	for _, path := range paths {
+		if err := e.validateTSMFile(path, minTime, maxTime, fieldTypes); err != nil {
+			return fmt.Errorf("invalid tsm file %s: %v", path, err)
+		}
+	}
+
+	newFiles := make([]string, 0, len(paths))
+	removeNewFiles := func() {
+		for _, f := range newFiles {
+			os.RemoveAll(f)
+		}
+	}
+
+	for _, path := range paths {
+		filename := fmt.Sprintf("%09d-%09d.%s", e.FileStore.NextGeneration(), 1, TSMFileExtension)
+		tmp := fmt.Sprintf("%s.%s", filepath.Join(e.path, filename), TmpTSMFileExtension)
+		if err := linkOrCopyFile(path, tmp); err != nil {
+			removeNewFiles()
+			return err
+		}
+		newFiles = append(newFiles, tmp)
+	}
+
+	if err := syncDir(e.path); err != nil {
+		removeNewFiles()
+		return err
+	}
+
+	// Series and fields must exist before the values are visible to queries.
+	if err := e.addToIndexFromTSMFiles(newFiles); err != nil {
+		removeNewFiles()
+		return err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if err := e.FileStore.Replace(nil, newFiles); err != nil {
+		removeNewFiles()
+		return err
+	}
+
+	e.logger.Info("Ingested TSM files",
+		zap.Strings("paths", paths),
+		zap.Strings("files", newFiles))
+	return nil
+}
+
+// validateTSMFile checks that the TSM file at path can be ingested by the
+// engine.  fieldTypes holds the types of the fields of previously validated
+// files by measurement and field name and is updated with the fields of the
+// file.
+func (e *Engine) validateTSMFile(path string, minTime, maxTime int64, fieldTypes map[string]influxql.DataType) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer r.Close()
+
+	n := r.KeyCount()
+	if n == 0 {
+		return ErrNoValues
+	}
+
+	var prev []byte
+	var entries []IndexEntry
+	var values []Value
+	for i := 0; i < n; i++ {
+		var key []byte
+		var typ byte
+		key, typ, entries = r.Key(i, &entries)
+		if i > 0 && bytes.Compare(prev, key) >= 0 {
+			return fmt.Errorf("key %q is not sorted after key %q", key, prev)
+		}
+		prev = key
+
+		for j := range entries {
+			entry := &entries[j]
+			if entry.MinTime > entry.MaxTime {
+				return fmt.Errorf("key %q: block min time %d after max time %d", key, entry.MinTime, entry.MaxTime)
+			} else if entry.MinTime < minTime || entry.MaxTime > maxTime {
+				return fmt.Errorf("key %q: block time range %d-%d outside of shard time range %d-%d", key, entry.MinTime, entry.MaxTime, minTime, maxTime)
+			} else if j > 0 && entry.MinTime <= entries[j-1].MaxTime {
+				return fmt.Errorf("key %q: blocks are not sorted or overlap", key)
+			}
+
+			// Verify the block data like influx_inspect verify, and that it
+			// matches its index entry.
+			checksum, block, err := r.ReadBytes(entry, nil)
+			if err != nil {
+				return fmt.Errorf("key %q: %v", key, err)
+			} else if len(block) <= encodedBlockHeaderSize {
+				return fmt.Errorf("key %q: block at offset %d is too short", key, entry.Offset)
+			} else if !VerifyBlockChecksum(checksum, block) {
+				return fmt.Errorf("key %q: %v", key, errBlockChecksum{offset: entry.Offset, size: entry.Size})
+			} else if block[0] != typ {
+				return fmt.Errorf("key %q: block at offset %d is type %d, index type is %d", key, entry.Offset, block[0], typ)
+			}
+
+			values, err = DecodeBlock(block, values[:0])
+			if err != nil {
+				return fmt.Errorf("key %q: block at offset %d: %v", key, entry.Offset, err)
+			} else if len(values) == 0 {
+				return fmt.Errorf("key %q: block at offset %d has no values", key, entry.Offset)
+			} else if values[0].UnixNano() < entry.MinTime || values[len(values)-1].UnixNano() > entry.MaxTime {
+				return fmt.Errorf("key %q: block at offset %d has values outside of its time range %d-%d", key, entry.Offset, entry.MinTime, entry.MaxTime)
+			}
+		}
+
+		fieldType := BlockTypeToInfluxQLDataType(typ)
+		if fieldType == influxql.Unknown {
+			return fmt.Errorf("key %q: unknown block type: %v", key, typ)
+		}
+
+		seriesKey, field := SeriesAndFieldFromCompositeKey(key)
+		name := tsdb.MeasurementFromSeriesKey(seriesKey)
+		// The shard creates the fields of the points written to it in the
+		// field set of the engine before the points are written.
+		if mf := e.MeasurementFieldSet().Fields(name); mf != nil {
+			if f := mf.FieldBytes(field); f != nil && f.Type != fieldType {
+				return fmt.Errorf("%s: field %q on measurement %q is type %s, already exists as type %s", tsdb.ErrFieldTypeConflict, field, name, fieldType, f.Type)
+			}
+		}
+
+		k := string(name) + string(keyFieldSeparator) + string(field)
+		if typ, ok := fieldTypes[k]; ok && typ != fieldType {
+			return fmt.Errorf("%s: field %q on measurement %q is type %s, is type %s in another file", tsdb.ErrFieldTypeConflict, field, name, fieldType, typ)
+		}
+		fieldTypes[k] = fieldType
+	}
+	return nil
+}
+
+// addToIndexFromTSMFiles adds the series and fields of the TSM files at paths
+// to the index and the measurement fields.
+func (e *Engine) addToIndexFromTSMFiles(paths []string) error {
+	readers := make([]chan seriesKey, 0, len(paths))
+	for _, path := range paths {
+		fd, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		r, err := NewTSMReader(fd)
+		if err != nil {
+			fd.Close()
+			return err
+		}
+		defer r.Close()
+
+		ch := make(chan seriesKey, 1)
+		readers = append(readers, ch)
+		go func(c chan seriesKey, r *TSMReader) {
+			n := r.KeyCount()
+			for i := 0; i < n; i++ {
+				key, typ := r.KeyAt(i)
+				c <- seriesKey{key, typ}
+			}
+			close(c)
+		}(ch, r)
+	}
+
+	// Merge and dedup all the series keys across each reader to reduce
+	// lock contention on the index.
+	keys := make([][]byte, 0, 10000)
+	fieldTypes := make([]influxql.DataType, 0, 10000)
+	for v := range merge(readers...) {
+		keys = append(keys, v.key)
+		fieldTypes = append(fieldTypes, BlockTypeToInfluxQLDataType(v.typ))
+
+		if len(keys) == cap(keys) {
+			if err := e.addToIndexFromKey(keys, fieldTypes); err != nil {
+				return err
+			}
+			keys, fieldTypes = keys[:0], fieldTypes[:0]
+		}
+	}
+
+	if len(keys) > 0 {
+		return e.addToIndexFromKey(keys, fieldTypes)
+	}
+	return nil
+}
+
+// linkOrCopyFile hard links src to dst, or copies it if it cannot be linked,
+// such as when src is on another file system.
+func linkOrCopyFile(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+// WritePoints writes metadata and point data into the engine.
+// It returns an error if new points are added to an existing key.
+func (e *Engine) WritePoints(points []models.Point) error {
+	values, err := pointValues(points)
+	if err != nil {
+		return err
+	}
+
+	err = e.writeValues(values)
+	if _, ok := err.(errCacheMemorySizeLimitExceeded); ok && e.CacheSpillEnabled {
+		var n uint64
+		for _, v := range values {
+			n += uint64(Values(v).Size())
+		}
+		if err = e.relieveCache(n); err == nil {
+			err = e.writeValues(values)
+		}
+	}
+	return err
+}
+
+// BulkLoadPoints sorts points and writes them directly to new TSM files,
+// bypassing the WAL and the cache.  Points are buffered across calls until
+// their size reaches BulkLoadBufferSize or the engine has not been bulk loaded
+// for CacheFlushWriteColdDuration, and the files written for them are added to
+// the FileStore together, so either all the buffered points or none of them
+// become visible.  Buffered points are written to a log of their own before
+// BulkLoadPoints returns, which is replayed when the engine is opened.  It avoids the cache growth and the merges of overlapping
+// blocks caused by writing large amounts of historical or unsorted data.
+// Values in the cache take precedence over loaded values with the same
+// timestamp until the cache is snapshotted.
+func (e *Engine) BulkLoadPoints(points []models.Point) error {
+	values, err := pointValues(points)
+	if err != nil || len(values) == 0 {
+		return err
+	}
+
+	e.bulkMu.Lock()
+	defer e.bulkMu.Unlock()
+
+	if e.bulkCache == nil {
+		// A private cache sorts and deduplicates the values of each key.
+		e.bulkCache = NewCache(0, "")
+		e.bulkN, e.bulkMin, e.bulkMax = 0, math.MaxInt64, math.MinInt64
+	}
+	for _, p := range points {
+		t := p.UnixNano()
+		if t < e.bulkMin {
+			e.bulkMin = t
+		}
+		if t > e.bulkMax {
+			e.bulkMax = t
+		}
+	}
+	for _, v := range values {
+		e.bulkN += len(v)
+	}
+	if e.WALEnabled {
+		if _, err := e.bulkWAL.WriteMulti(values); err != nil {
+			return err
+		}
+	}
+	if err := e.bulkCache.WriteMulti(values); err != nil {
+		return err
+	}
+
+	if e.bulkCache.Size() >= e.BulkLoadBufferSize {
+		return e.writeBulkLoad()
+	}
+
+	if e.bulkTimer == nil {
+		e.bulkTimer = time.AfterFunc(e.CacheFlushWriteColdDuration, func() {
+			if err := e.FlushBulkLoad(); err != nil {
+				e.logger.Info("Error writing bulk loaded points", zap.Error(err))
+			}
+		})
+	} else {
+		e.bulkTimer.Reset(e.CacheFlushWriteColdDuration)
+	}
+	return nil
+}
+
+// FlushBulkLoad writes the buffered bulk loaded points to new TSM files.
+func (e *Engine) FlushBulkLoad() error {
+	e.bulkMu.Lock()
+	defer e.bulkMu.Unlock()
+	return e.writeBulkLoad()
+}
+
+// writeBulkLoad writes the buffered bulk loaded points to new TSM files and
+// adds them to the FileStore.  The points stay buffered if the files cannot be
+// written.  bulkMu must be held.
+func (e *Engine) writeBulkLoad() (err error) {
+	if e.bulkCache == nil {
+		return nil
+	}
+	if e.bulkTimer != nil {
+		e.bulkTimer.Stop()
+		e.bulkTimer = nil
+	}
+
+	start := time.Now()
+	atomic.AddInt64(&e.stats.TSMBulkLoads, 1)
+	defer func() {
+		if err != nil {
+			atomic.AddInt64(&e.stats.TSMBulkLoadErrors, 1)
+		}
+		atomic.AddInt64(&e.stats.TSMBulkLoadDuration, time.Since(start).Nanoseconds())
+	}()
+
+	e.bulkCache.Deduplicate()
+
+	files, err := e.Compactor.WriteBulkLoad(e.bulkCache)
+	if err != nil {
+		return err
+	}
+
+	e.mu.RLock()
+	err = e.FileStore.Replace(nil, files)
+	e.mu.RUnlock()
+	if err != nil {
+		for _, f := range files {
+			os.RemoveAll(f)
+		}
+		return err
+	}
+
+	n, min, max := e.bulkN, e.bulkMin, e.bulkMax
+	e.bulkCache = nil
+
+	// The logged points are written, so the log can be removed.  Segments
+	// failing to be removed are replayed when the engine is opened, which
+	// writes the same values again.
+	if e.WALEnabled {
+		if err := e.bulkWAL.CloseSegment(); err != nil {
+			e.logger.Info("Error closing bulk load WAL segment", zap.Error(err))
+		} else if segments, err := e.bulkWAL.ClosedSegments(); err != nil {
+			e.logger.Info("Error listing bulk load WAL segments", zap.Error(err))
+		} else if err := e.bulkWAL.Remove(segments); err != nil {
+			e.logger.Info("Error removing bulk load WAL segments", zap.Error(err))
+		}
+	}
+
+	atomic.AddInt64(&e.stats.TSMBulkLoadValues, int64(n))
+	e.traceLogger.Info("Bulk loaded points",
+		zap.Int("values", n),
+		zap.Strings("files", files),
+		zap.Duration("duration", time.Since(start)))
+
+	if e.bulkLoadObserver != nil {
+		e.bulkLoadObserver(e.id, min, max)
+	}
+	return nil
+}
+
+// reloadBulkLoad opens the log of the buffered bulk loaded points and writes
+// the points logged, but not written to TSM files, before the engine was
+// closed.
+func (e *Engine) reloadBulkLoad() error {
+	if err := e.bulkWAL.Open(); err != nil {
+		return err
 	}
 
-	// Build in-memory index, if necessary.
-	if e.index.Type() == inmem.IndexName {
-		if err := e.index.InitializeSeries(keys, names, tags); err != nil {
-			return err
-		}
-	} else {
-		if err := e.index.CreateSeriesListIfNotExists(keys, names, tags); err != nil {
-			return err
-		}
+	files, err := segmentFileNames(e.bulkWAL.Path())
+	if err != nil || len(files) == 0 {
+		return err
 	}
 
-	return nil
+	cache := NewCache(0, "")
+	loader := NewCacheLoader(files)
+	loader.WithLogger(e.logger)
+	if err := loader.Load(cache); err != nil {
+		return err
+	}
+
+	e.bulkMu.Lock()
+	defer e.bulkMu.Unlock()
+	if cache.Size() == 0 {
+		return nil
+	}
+
+	e.bulkCache = cache
+	e.bulkN, e.bulkMin, e.bulkMax = 0, math.MaxInt64, math.MinInt64
+	for _, key := range cache.Keys() {
+		for _, v := range cache.Values(key) {
+			t := v.UnixNano()
+			if t < e.bulkMin {
+				e.bulkMin = t
+			}
+			if t > e.bulkMax {
+				e.bulkMax = t
+			}
+			e.bulkN++
+		}
+	}
+	return e.writeBulkLoad()
 }
 
-// WritePoints writes metadata and point data into the engine.
-// It returns an error if new points are added to an existing key.
-func (e *Engine) WritePoints(points []models.Point) error {
+// pointValues returns the values of the fields of points by series key and
+// field.
+func pointValues(points []models.Point) (map[string][]Value, error) {
 	values := make(map[string][]Value, len(points))
 	var keyBuf []byte
 	var baseLen int
@@ -1201,19 +1901,19 @@ func (e *Engine) WritePoints(points []models.Point) error {
 			case models.Float:
 				fv, err := iter.FloatValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewFloatValue(t, fv)
 			case models.Integer:
 				iv, err := iter.IntegerValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewIntegerValue(t, iv)
 			case models.Unsigned:
 				iv, err := iter.UnsignedValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewUnsignedValue(t, iv)
 			case models.String:
@@ -1221,16 +1921,21 @@ func (e *Engine) WritePoints(points []models.Point) error {
 			case models.Boolean:
 				bv, err := iter.BooleanValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewBooleanValue(t, bv)
 			default:
-				return fmt.Errorf("unknown field type for %s: %s", string(iter.FieldKey()), p.String())
+				return nil, fmt.Errorf("unknown field type for %s: %s", string(iter.FieldKey()), p.String())
 			}
 			values[string(keyBuf)] = append(values[string(keyBuf)], v)
 		}
 	}
 
+	return values, nil
+}
+
+// writeValues writes values to the cache and the WAL.
+func (e *Engine) writeValues(values map[string][]Value) error {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
@@ -1246,8 +1951,70 @@ func (e *Engine) WritePoints(points []models.Point) error {
 	return err
 }
 
+// relieveCache makes room for n bytes in the cache.  It snapshots the cache
+// and, while the cache is still too large, spills its least written
+// partitions to disk.  Writers wait up to cacheSpillTimeout for room before
+// the write fails.
+func (e *Engine) relieveCache(n uint64) error {
+	limit := e.Cache.MaxSize()
+	if limit > 0 && n > limit {
+		return ErrCacheMemorySizeLimitExceeded(n, limit)
+	}
+
+	deadline := time.Now().Add(cacheSpillTimeout)
+	for {
+		// A snapshot in progress or disabled snapshots leave the data in
+		// memory, which the spill below moves to disk.
+		if err := e.WriteSnapshot(); err != nil && err != ErrSnapshotInProgress {
+			e.logger.Info("Error writing snapshot to relieve cache", zap.Error(err))
+		}
+		if e.Cache.Size()+n <= e.Cache.MaxSize() {
+			return nil
+		}
+
+		if err := e.spillCache(e.Cache.Size() + n - e.Cache.MaxSize()); err != nil {
+			return err
+		}
+		if size := e.Cache.Size(); size+n <= e.Cache.MaxSize() {
+			return nil
+		} else if time.Now().After(deadline) {
+			return ErrCacheMemorySizeLimitExceeded(size+n, e.Cache.MaxSize())
+		}
+		time.Sleep(cacheSpillRetryInterval)
+	}
+}
+
+// spillCache moves at least n bytes of cache entries to disk.
+func (e *Engine) spillCache(n uint64) error {
+	// Entries can only be detached while no writes are in progress.
+	e.mu.Lock()
+	s := e.Cache.spill(n)
+	e.mu.Unlock()
+
+	if s == nil {
+		return nil
+	}
+
+	start := time.Now()
+	if err := s.write(); err != nil {
+		// The entries stay in memory and are written with the next snapshot.
+		e.logger.Info("Error spilling cache to disk", zap.String("path", s.path), zap.Error(err))
+		return err
+	}
+	e.traceLogger.Info("Spilled cache to disk",
+		zap.String("path", s.path),
+		zap.Uint64("size", s.size),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
 // DeleteSeriesRange removes the values between min and max (inclusive) from all series
 func (e *Engine) DeleteSeriesRange(itr tsdb.SeriesIterator, min, max int64) error {
+	// Buffered bulk loaded values are deleted from the files they are written to.
+	if err := e.FlushBulkLoad(); err != nil {
+		return err
+	}
+
 	var disableOnce bool
 
 	// Ensure that the index does not compact away the measurement or series we're
@@ -1502,6 +2269,9 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 			if err := e.index.DropSeries(sid, k, false); err != nil {
 				return err
 			}
+			e.updateIndexBuild(func(index tsdb.Index) error {
+				return index.DropSeries(sid, k, false)
+			})
 
 			// Add the id to the set of delete ids.
 			ids.Add(sid)
@@ -1511,6 +2281,9 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 			if err := e.index.DropMeasurementIfSeriesNotExist([]byte(k)); err != nil {
 				return err
 			}
+			e.updateIndexBuild(func(index tsdb.Index) error {
+				return index.DropMeasurementIfSeriesNotExist([]byte(k))
+			})
 		}
 
 		// Remove any series IDs for our set that still exist in other shards.
@@ -1613,11 +2386,19 @@ func (e *Engine) ForEachMeasurementName(fn func(name []byte) error) error {
 }
 
 func (e *Engine) CreateSeriesListIfNotExists(keys, names [][]byte, tagsSlice []models.Tags) error {
-	return e.index.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	err := e.index.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	e.updateIndexBuild(func(index tsdb.Index) error {
+		return index.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	})
+	return err
 }
 
 func (e *Engine) CreateSeriesIfNotExists(key, name []byte, tags models.Tags) error {
-	return e.index.CreateSeriesIfNotExists(key, name, tags)
+	err := e.index.CreateSeriesIfNotExists(key, name, tags)
+	e.updateIndexBuild(func(index tsdb.Index) error {
+		return index.CreateSeriesIfNotExists(key, name, tags)
+	})
+	return err
 }
 
 // WriteTo is not implemented.
@@ -1665,7 +2446,7 @@ func (e *Engine) WriteSnapshot() error {
 		return err
 	}
 
-	if snapshot.Size() == 0 {
+	if snapshot.Size() == 0 && !snapshot.spilled() {
 		e.Cache.ClearSnapshot(true)
 		return nil
 	}
@@ -1685,6 +2466,9 @@ func (e *Engine) WriteSnapshot() error {
 // CreateSnapshot will create a temp directory that holds
 // temporary hardlinks to the underylyng shard files.
 func (e *Engine) CreateSnapshot() (string, error) {
+	if err := e.FlushBulkLoad(); err != nil {
+		return "", err
+	}
 	if err := e.WriteSnapshot(); err != nil {
 		return "", err
 	}
@@ -1806,6 +2590,14 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 				atomic.StoreInt64(&e.stats.TSMOptimizeCompactionsQueue, int64(len(level4Groups)))
 			}
 
+			// Leave the quarantined files as they are until they are repaired.
+			if quarantined := e.FileStore.Quarantined(); len(quarantined) > 0 {
+				level1Groups = e.skipQuarantined(level1Groups, quarantined)
+				level2Groups = e.skipQuarantined(level2Groups, quarantined)
+				level3Groups = e.skipQuarantined(level3Groups, quarantined)
+				level4Groups = e.skipQuarantined(level4Groups, quarantined)
+			}
+
 			// Update the level plan queue stats
 			atomic.StoreInt64(&e.stats.TSMCompactionsQueue[0], int64(len(level1Groups)))
 			atomic.StoreInt64(&e.stats.TSMCompactionsQueue[1], int64(len(level2Groups)))
@@ -1837,6 +2629,8 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 						level4Groups = level4Groups[1:]
 					}
 				}
+			} else {
+				e.purgeTombstones(wg)
 			}
 
 			// Release all the plans we didn't start.
@@ -1848,6 +2642,30 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 	}
 }
 
+// skipQuarantined releases and removes the groups containing a quarantined
+// file.
+func (e *Engine) skipQuarantined(groups []CompactionGroup, quarantined map[string]struct{}) []CompactionGroup {
+	var skipped []CompactionGroup
+	n := 0
+	for _, group := range groups {
+		skip := false
+		for _, path := range group {
+			if _, ok := quarantined[path]; ok {
+				skip = true
+				break
+			}
+		}
+		if skip {
+			skipped = append(skipped, group)
+			continue
+		}
+		groups[n] = group
+		n++
+	}
+	e.CompactionPlan.Release(skipped)
+	return groups[:n]
+}
+
 // compactHiPriorityLevel kicks off compactions using the high priority policy. It returns
 // true if the compaction was started
 func (e *Engine) compactHiPriorityLevel(grp CompactionGroup, level int, fast bool, wg *sync.WaitGroup) bool {
@@ -1927,6 +2745,101 @@ func (e *Engine) compactFull(grp CompactionGroup, wg *sync.WaitGroup) bool {
 	return false
 }
 
+// purgeTombstones kicks off a tombstone purge of the TSM file with the most
+// tombstones above the threshold using the lo priority policy.  It returns
+// true if the purge was started.
+func (e *Engine) purgeTombstones(wg *sync.WaitGroup) bool {
+	if e.TombstonePurgeThreshold <= 0 || atomic.LoadInt64(&e.stats.TSMTombstonePurgesActive) > 0 {
+		return false
+	}
+
+	quarantined := e.FileStore.Quarantined()
+
+	var path string
+	var max int64
+	for _, f := range e.FileStore.Files() {
+		if _, ok := quarantined[f.Path()]; ok {
+			continue
+		}
+		if n := TombstoneSize(f); n >= e.TombstonePurgeThreshold && n > max {
+			path, max = f.Path(), n
+		}
+	}
+	if path == "" {
+		return false
+	}
+
+	if e.compactionLimiter.TryTake() {
+		atomic.AddInt64(&e.stats.TSMTombstonePurgesActive, 1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer atomic.AddInt64(&e.stats.TSMTombstonePurgesActive, -1)
+			defer e.compactionLimiter.Release()
+
+			start := time.Now()
+			e.purgeTombstonesFile(path)
+			atomic.AddInt64(&e.stats.TSMTombstonePurgeDuration, time.Since(start).Nanoseconds())
+		}()
+		return true
+	}
+	return false
+}
+
+// purgeTombstonesFile rewrites the TSM file at path without the data deleted
+// by its tombstones.
+func (e *Engine) purgeTombstonesFile(path string) {
+	start := time.Now()
+	log, logEnd := logger.NewOperation(e.logger, "TSM tombstone purge", "tsm1_purge_tombstones")
+	defer logEnd()
+
+	log.Info("Purging tombstones", zap.String("tsm1_file", path))
+	tmp, n, err := e.Compactor.PurgeTombstones(path)
+	if err != nil {
+		_, inProgress := err.(errCompactionInProgress)
+		_, aborted := err.(errCompactionAborted)
+		if err == errCompactionsDisabled || inProgress || aborted {
+			log.Info("Aborted tombstone purge", zap.Error(err))
+			return
+		}
+
+		log.Info("Error purging tombstones", zap.Error(err))
+		atomic.AddInt64(&e.stats.TSMTombstonePurgeErrors, 1)
+		time.Sleep(time.Second)
+		return
+	}
+
+	// Queries release the file quickly, so wait a little for them.
+	for i := 0; ; i++ {
+		err = e.FileStore.ReplacePurged(path, tmp)
+		if err != ErrFileInUse || i == tombstonePurgeReplaceRetries {
+			break
+		}
+		time.Sleep(tombstonePurgeReplaceInterval)
+	}
+
+	if err != nil {
+		if tmp != "" {
+			os.Remove(tmp)
+		}
+		if err == ErrFileInUse {
+			log.Info("Aborted tombstone purge", zap.Error(err))
+			return
+		}
+
+		log.Info("Error replacing purged TSM file", zap.Error(err))
+		atomic.AddInt64(&e.stats.TSMTombstonePurgeErrors, 1)
+		time.Sleep(time.Second)
+		return
+	}
+
+	log.Info("Finished purging tombstones",
+		zap.String("tsm1_file", path),
+		zap.Int("blocks", n),
+		zap.Duration("duration", time.Since(start)))
+	atomic.AddInt64(&e.stats.TSMTombstonePurges, 1)
+}
+
 // compactionStrategy holds the details of what to do in a compaction.
 type compactionStrategy struct {
 	group CompactionGroup
@@ -1970,9 +2883,12 @@ func (s *compactionStrategy) compactGroup() {
 		files []string
 	)
 
-	if s.fast {
+	switch {
+	case s.fast:
 		files, err = s.compactor.CompactFast(group)
-	} else {
+	case s.level < 4:
+		files, err = s.compactor.CompactLevel(group)
+	default:
 		files, err = s.compactor.CompactFull(group)
 	}
 
@@ -2095,6 +3011,7 @@ func (e *Engine) cleanup() error {
 	}
 
 	ext := fmt.Sprintf(".%s", TmpTSMFileExtension)
+	spillExt := fmt.Sprintf(".%s", cacheSpillExtension)
 	for _, f := range allfiles {
 		// Check to see if there are any `.tmp` directories that were left over from failed shard snapshots
 		if f.IsDir() && strings.HasSuffix(f.Name(), ext) {
@@ -2102,6 +3019,13 @@ func (e *Engine) cleanup() error {
 				return fmt.Errorf("error removing tmp snapshot directory %q: %s", f.Name(), err)
 			}
 		}
+
+		// Spilled cache entries are reloaded from the WAL.
+		if !f.IsDir() && strings.HasSuffix(f.Name(), spillExt) {
+			if err := os.Remove(filepath.Join(e.path, f.Name())); err != nil {
+				return fmt.Errorf("error removing cache spill file %q: %s", f.Name(), err)
+			}
+		}
 	}
 
 	return e.cleanupTempTSMFiles()
@@ -2229,6 +3153,19 @@ func (e *Engine) createCallIterator(ctx context.Context, measurement string, cal
 			default:
 			}
 
+			// Aggregate the blocks of each series using their statistics if
+			// possible. These iterators are already wrapped in a call iterator.
+			if e.blockStatistics && canAggregateBlocks(call, opt) {
+				inputs, err := e.createBlockAggregateIterators(ctx, call, ref, measurement, t, opt)
+				if err != nil {
+					return err
+				} else if len(inputs) == 0 {
+					continue
+				}
+				itrs = append(itrs, query.NewParallelMergeIterator(inputs, opt, runtime.GOMAXPROCS(0)))
+				continue
+			}
+
 			inputs, err := e.createTagSetIterators(ctx, ref, measurement, t, opt)
 			if err != nil {
 				return err
@@ -2262,6 +3199,178 @@ func (e *Engine) createCallIterator(ctx context.Context, measurement string, cal
 	return itrs, nil
 }
 
+// canAggregateBlocks returns true if the call can be computed from the
+// statistics of the blocks of each series.  The min and max selectors are
+// only supported when grouping by time as the time of the selected value is
+// not known.
+func canAggregateBlocks(call *influxql.Call, opt query.IteratorOptions) bool {
+	if len(call.Args) != 1 || len(opt.Aux) > 0 {
+		return false
+	} else if _, ok := call.Args[0].(*influxql.VarRef); !ok {
+		return false
+	}
+
+	switch call.Name {
+	case "count", "sum":
+		return true
+	case "min", "max":
+		return !opt.Interval.IsZero()
+	default:
+		return false
+	}
+}
+
+// createBlockAggregateIterators creates a call iterator for each series in the
+// tag set.  Blocks that lie within a single window and do not overlap other
+// data are aggregated using the statistics stored in the TSM index instead of
+// decoding them.
+func (e *Engine) createBlockAggregateIterators(ctx context.Context, call *influxql.Call, ref *influxql.VarRef, name string, t *query.TagSet, opt query.IteratorOptions) ([]query.Iterator, error) {
+	itrs := make([]query.Iterator, 0, len(t.SeriesKeys))
+	for i, seriesKey := range t.SeriesKeys {
+		var itr query.Iterator
+		var err error
+		if t.Filters[i] != nil {
+			// Series with a condition on their fields must read every value.
+			itr, err = e.createCallSeriesIterator(ctx, ref, name, seriesKey, t, t.Filters[i], opt)
+		} else {
+			itr, err = e.createBlockAggregateSeriesIterator(ctx, call, ref, name, seriesKey, t, opt)
+		}
+		if err != nil {
+			query.Iterators(itrs).Close()
+			return nil, err
+		} else if itr == nil {
+			continue
+		}
+		itrs = append(itrs, itr)
+
+		// Abort if the query was killed
+		select {
+		case <-opt.InterruptCh:
+			query.Iterators(itrs).Close()
+			return nil, query.ErrQueryInterrupted
+		default:
+		}
+
+		// Enforce series limit at creation time.
+		if opt.MaxSeriesN > 0 && len(itrs) > opt.MaxSeriesN {
+			query.Iterators(itrs).Close()
+			return nil, fmt.Errorf("max-select-series limit exceeded: (%d/%d)", len(itrs), opt.MaxSeriesN)
+		}
+	}
+	return itrs, nil
+}
+
+// createCallSeriesIterator creates a call iterator for a series that reads
+// every value of the series.
+func (e *Engine) createCallSeriesIterator(ctx context.Context, ref *influxql.VarRef, name string, seriesKey string, t *query.TagSet, filter influxql.Expr, opt query.IteratorOptions) (query.Iterator, error) {
+	var conditionFields []influxql.VarRef
+	if filter != nil {
+		conditionFields = influxql.ExprNames(filter)
+	}
+
+	input, err := e.createVarRefSeriesIterator(ctx, ref, name, seriesKey, t, filter, conditionFields, opt)
+	if err != nil || input == nil {
+		return nil, err
+	}
+
+	if opt.InterruptCh != nil {
+		input = query.NewInterruptIterator(input, opt.InterruptCh)
+	}
+
+	itr, err := query.NewCallIterator(input, opt)
+	if err != nil {
+		input.Close()
+		return nil, err
+	}
+	return itr, nil
+}
+
+// createBlockAggregateSeriesIterator creates a call iterator for a series that
+// aggregates whole blocks using their statistics and reads the values of the
+// remaining blocks.
+func (e *Engine) createBlockAggregateSeriesIterator(ctx context.Context, call *influxql.Call, ref *influxql.VarRef, name string, seriesKey string, t *query.TagSet, opt query.IteratorOptions) (query.Iterator, error) {
+	// Fields that do not exist, system fields and casts are read normally.
+	var f *tsdb.Field
+	if mf := e.fieldset.FieldsByString(name); mf != nil {
+		f = mf.Field(ref.Val)
+	}
+	if f == nil || (ref.Type != influxql.Unknown && ref.Type != influxql.AnyField && ref.Type != f.Type) {
+		return e.createCallSeriesIterator(ctx, ref, name, seriesKey, t, nil, opt)
+	}
+
+	// Only the count of string and boolean blocks is stored.
+	switch f.Type {
+	case influxql.Float, influxql.Integer, influxql.Unsigned:
+	default:
+		if call.Name != "count" {
+			return e.createCallSeriesIterator(ctx, ref, name, seriesKey, t, nil, opt)
+		}
+	}
+
+	key := SeriesFieldKeyBytes(seriesKey, ref.Val)
+	cacheValues := e.Cache.Values(key)
+	keyCursor := e.KeyCursor(ctx, key, opt.SeekTime(), opt.Ascending)
+
+	// Take the blocks that are within the time range and a single window
+	// and have no values in the cache.
+	aggs := keyCursor.aggregateBlocks(opt.SeekTime(), func(entry *IndexEntry) bool {
+		if entry.MinTime < opt.StartTime || entry.MaxTime > opt.EndTime {
+			return false
+		} else if _, end := opt.Window(entry.MinTime); entry.MaxTime >= end {
+			return false
+		}
+		i := sort.Search(len(cacheValues), func(i int) bool {
+			return cacheValues[i].UnixNano() >= entry.MinTime
+		})
+		return i == len(cacheValues) || cacheValues[i].UnixNano() > entry.MaxTime
+	})
+
+	_, tfs := models.ParseKey([]byte(seriesKey))
+	tags := query.NewTags(tfs.Map())
+	tags = tags.Subset(opt.GetDimensions())
+	if opt.StripName {
+		name = ""
+	}
+
+	itrOpt := opt
+	itrOpt.Condition = nil
+
+	var input query.Iterator
+	switch f.Type {
+	case influxql.Float:
+		input = newFloatIterator(name, tags, itrOpt, newFloatCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor), nil, nil, nil)
+	case influxql.Integer:
+		input = newIntegerIterator(name, tags, itrOpt, newIntegerCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor), nil, nil, nil)
+	case influxql.Unsigned:
+		input = newUnsignedIterator(name, tags, itrOpt, newUnsignedCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor), nil, nil, nil)
+	case influxql.String:
+		input = newStringIterator(name, tags, itrOpt, newStringCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor), nil, nil, nil)
+	case influxql.Boolean:
+		input = newBooleanIterator(name, tags, itrOpt, newBooleanCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor), nil, nil, nil)
+	default:
+		panic("unreachable")
+	}
+	if col := metrics.GroupFromContext(ctx); col != nil {
+		col.GetCounter(numberOfRefCursorsCounter).Add(1)
+	}
+
+	if opt.InterruptCh != nil {
+		input = query.NewInterruptIterator(input, opt.InterruptCh)
+	}
+
+	itr, err := query.NewCallIterator(input, opt)
+	if err != nil {
+		input.Close()
+		return nil, err
+	} else if len(aggs) == 0 {
+		return itr, nil
+	}
+
+	// Merge the partial aggregates of the blocks with the aggregates of the
+	// values that were read.
+	return query.Iterators{itr, newBlockAggregateIterator(name, tags, call.Name, f.Type, aggs, opt)}.Merge(opt)
+}
+
 // createVarRefIterator creates an iterator for a variable reference.
 func (e *Engine) createVarRefIterator(ctx context.Context, measurement string, opt query.IteratorOptions) ([]query.Iterator, error) {
 	ref, _ := opt.Expr.(*influxql.VarRef)
@@ -2735,6 +3844,20 @@ func matchTagValues(tags models.Tags, condition influxql.Expr) []string {
 
 // IteratorCost produces the cost of an iterator.
 func (e *Engine) IteratorCost(measurement string, opt query.IteratorOptions) (query.IteratorCost, error) {
+	return e.iteratorCost(measurement, opt, nil)
+}
+
+// IteratorCostFiles produces the cost of an iterator like IteratorCost, but
+// only counts the TSM files that are not in files and adds their paths to it.
+// Series without values in the time range are not counted, and the shard is
+// not counted if no series has values.
+func (e *Engine) IteratorCostFiles(measurement string, opt query.IteratorOptions, files map[string]struct{}) (query.IteratorCost, error) {
+	return e.iteratorCost(measurement, opt, files)
+}
+
+// iteratorCost produces the cost of an iterator. If files is not nil, only
+// the files that are not in files and the series with values are counted.
+func (e *Engine) iteratorCost(measurement string, opt query.IteratorOptions, files map[string]struct{}) (query.IteratorCost, error) {
 	// Determine if this measurement exists. If it does not, then no shards are
 	// accessed to begin with.
 	if exists, err := e.index.MeasurementExists([]byte(measurement)); err != nil {
@@ -2765,12 +3888,13 @@ func (e *Engine) IteratorCost(measurement string, opt query.IteratorOptions) (qu
 	// Count the number of series concatenated from the tag set.
 	cost := query.IteratorCost{NumShards: 1}
 	for _, t := range tagSets {
-		cost.NumSeries += int64(len(t.SeriesKeys))
 		for i, key := range t.SeriesKeys {
+			var sc query.IteratorCost
+
 			// Retrieve the cost for the main expression (if it exists).
 			if ref != nil {
-				c := e.seriesCost(key, ref.Val, opt.StartTime, opt.EndTime)
-				cost = cost.Combine(c)
+				c := e.seriesCost(key, ref.Val, opt.StartTime, opt.EndTime, files)
+				sc = sc.Combine(c)
 			}
 
 			// Retrieve the cost for every auxiliary field since these are also
@@ -2779,8 +3903,8 @@ func (e *Engine) IteratorCost(measurement string, opt query.IteratorOptions) (qu
 			// anywhere close to the full costs of the auxiliary iterators because
 			// many of the selected values are usually skipped.
 			for _, ref := range opt.Aux {
-				c := e.seriesCost(key, ref.Val, opt.StartTime, opt.EndTime)
-				cost = cost.Combine(c)
+				c := e.seriesCost(key, ref.Val, opt.StartTime, opt.EndTime, files)
+				sc = sc.Combine(c)
 			}
 
 			// Retrieve the expression names in the condition (if there is a condition).
@@ -2788,18 +3912,29 @@ func (e *Engine) IteratorCost(measurement string, opt query.IteratorOptions) (qu
 			if t.Filters[i] != nil {
 				refs := influxql.ExprNames(t.Filters[i])
 				for _, ref := range refs {
-					c := e.seriesCost(key, ref.Val, opt.StartTime, opt.EndTime)
-					cost = cost.Combine(c)
+					c := e.seriesCost(key, ref.Val, opt.StartTime, opt.EndTime, files)
+					sc = sc.Combine(c)
 				}
 			}
+
+			// The index may hold series without values in this shard.
+			if files != nil && sc.BlocksRead == 0 && sc.CachedValues == 0 {
+				continue
+			}
+			cost.NumSeries++
+			cost = cost.Combine(sc)
 		}
 	}
+
+	if files != nil && cost.NumSeries == 0 {
+		return query.IteratorCost{}, nil
+	}
 	return cost, nil
 }
 
-func (e *Engine) seriesCost(seriesKey, field string, tmin, tmax int64) query.IteratorCost {
+func (e *Engine) seriesCost(seriesKey, field string, tmin, tmax int64, files map[string]struct{}) query.IteratorCost {
 	key := SeriesFieldKeyBytes(seriesKey, field)
-	c := e.FileStore.Cost(key, tmin, tmax)
+	c := e.FileStore.costFiles(key, tmin, tmax, files)
 
 	// Retrieve the range of values within the cache.
 	cacheValues := e.Cache.Values(key)
@@ -2807,6 +3942,17 @@ func (e *Engine) seriesCost(seriesKey, field string, tmin, tmax int64) query.Ite
 	return c
 }
 
+// HasFieldValues returns true if the field of the series has values between
+// min and max.  Only the index of the TSM files is read, so a block whose time
+// range overlaps min and max is assumed to hold values within it.
+func (e *Engine) HasFieldValues(seriesKey []byte, field string, min, max int64) bool {
+	key := SeriesFieldKeyBytes(string(seriesKey), field)
+	if len(e.Cache.Values(key).Include(min, max)) > 0 {
+		return true
+	}
+	return e.FileStore.hasValues(key, min, max)
+}
+
 // SeriesFieldKey combine a series key and field name for a unique string to be hashed to a numeric ID.
 func SeriesFieldKey(seriesKey, field string) string {
 	return seriesKey + keyFieldSeparator + field
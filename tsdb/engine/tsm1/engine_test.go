@@ -4,7 +4,9 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
 	"math"
@@ -30,6 +32,223 @@ import (
 	"github.com/influxdata/influxql"
 )
 
+// Ensure that bulk loaded points are buffered across loads and written sorted
+// to a TSM file, bypassing the cache, even while snapshots are disabled.
+func TestEngine_BulkLoadPoints(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			e := MustOpenEngine(index)
+			defer e.Close()
+			e.SetCompactionsEnabled(false)
+
+			for _, s := range []string{
+				"cpu,host=A value=1.3 3000000000\ncpu,host=A value=1.1 1000000000",
+				"cpu,host=A value=1.2 2000000000",
+			} {
+				points := MustParsePointsString(s)
+				for _, p := range points {
+					if err := e.Engine.CreateSeriesIfNotExists(p.Key(), p.Name(), p.Tags()); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := e.BulkLoadPoints(points); err != nil {
+					t.Fatalf("failed to bulk load points: %s", err.Error())
+				}
+			}
+
+			if got, exp := e.FileStore.Count(), 0; got != exp {
+				t.Fatalf("unexpected file count before flush: got %d, exp %d", got, exp)
+			}
+			if e.IsIdle() {
+				t.Fatal("expected engine with buffered points not to be idle")
+			}
+
+			if err := e.FlushBulkLoad(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, exp := e.Cache.Size(), uint64(0); got != exp {
+				t.Fatalf("unexpected cache size: got %d, exp %d", got, exp)
+			}
+			if got, exp := e.FileStore.Count(), 1; got != exp {
+				t.Fatalf("unexpected file count: got %d, exp %d", got, exp)
+			}
+
+			values, err := e.FileStore.Read(tsm1.SeriesFieldKeyBytes("cpu,host=A", "value"), 1000000000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp := []tsm1.Value{
+				tsm1.NewValue(1000000000, 1.1),
+				tsm1.NewValue(2000000000, 1.2),
+				tsm1.NewValue(3000000000, 1.3),
+			}
+			if !reflect.DeepEqual(values, exp) {
+				t.Fatalf("unexpected values: got %v, exp %v", values, exp)
+			}
+
+			// Loads reaching the buffer size are written at once.
+			e.BulkLoadBufferSize = 1
+			if err := e.BulkLoadPoints(MustParsePointsString("cpu,host=A value=1.4 4000000000")); err != nil {
+				t.Fatalf("failed to bulk load points: %s", err.Error())
+			}
+			if got, exp := e.FileStore.Count(), 2; got != exp {
+				t.Fatalf("unexpected file count: got %d, exp %d", got, exp)
+			}
+		})
+	}
+}
+
+// Ensure that buffered bulk loaded points are logged and written when an
+// engine is opened after it was not closed.
+func TestEngine_BulkLoadPoints_Recover(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			e := MustOpenEngine(index)
+			defer e.Close()
+
+			points := MustParsePointsString("cpu,host=A value=1.2 2000000000\ncpu,host=A value=1.1 1000000000")
+			for _, p := range points {
+				if err := e.Engine.CreateSeriesIfNotExists(p.Key(), p.Name(), p.Tags()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := e.BulkLoadPoints(points); err != nil {
+				t.Fatalf("failed to bulk load points: %s", err.Error())
+			}
+			if got, exp := e.FileStore.Count(), 0; got != exp {
+				t.Fatalf("unexpected file count before flush: got %d, exp %d", got, exp)
+			}
+
+			// Open another engine on the files of e, as if e had crashed.
+			other := tsm1.NewEngine(2, e.index, "db0", e.Path(), e.WAL.Path(), e.sfile, tsdb.NewEngineOptions()).(*tsm1.Engine)
+			if err := other.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer other.Close()
+
+			if got, exp := other.FileStore.Count(), 1; got != exp {
+				t.Fatalf("unexpected file count: got %d, exp %d", got, exp)
+			}
+
+			values, err := other.FileStore.Read(tsm1.SeriesFieldKeyBytes("cpu,host=A", "value"), 1000000000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp := []tsm1.Value{
+				tsm1.NewValue(1000000000, 1.1),
+				tsm1.NewValue(2000000000, 1.2),
+			}
+			if !reflect.DeepEqual(values, exp) {
+				t.Fatalf("unexpected values: got %v, exp %v", values, exp)
+			}
+		})
+	}
+}
+
+// Ensure that externally produced TSM files are validated and ingested with their series.
+func TestEngine_IngestTSMFiles(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			e := MustOpenEngine(index)
+			defer e.Close()
+
+			dir := MustTempDir()
+			defer os.RemoveAll(dir)
+
+			// Fields are created by the shard before points are written.
+			if err := e.MeasurementFields([]byte("mem")).CreateFieldIfNotExists([]byte("value"), influxql.Integer); err != nil {
+				t.Fatal(err)
+			}
+			if err := e.WritePointsString(`mem,host=A value=1i 1000000000`); err != nil {
+				t.Fatalf("failed to write points: %s", err.Error())
+			}
+
+			// A field type conflicting with the shard is rejected.
+			conflict := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+				"mem,host=B#!~#value": []tsm1.Value{tsm1.NewValue(1000000000, 1.1)},
+			})
+			if err := e.IngestTSMFiles([]string{conflict}, 0, 10000000000); err == nil {
+				t.Fatal("expected field type conflict error")
+			}
+
+			path := MustWriteTSM(dir, 2, map[string][]tsm1.Value{
+				"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(1000000000, 1.1), tsm1.NewValue(2000000000, 1.2)},
+				"cpu,host=B#!~#value": []tsm1.Value{tsm1.NewValue(3000000000, 2.1)},
+			})
+
+			// Values outside of the shard time range are rejected.
+			if err := e.IngestTSMFiles([]string{path}, 0, 2000000000); err == nil {
+				t.Fatal("expected time range error")
+			}
+			if got, exp := e.FileStore.Count(), 0; got != exp {
+				t.Fatalf("unexpected file count: got %d, exp %d", got, exp)
+			}
+
+			// Blocks failing checksum verification or whose type differs from
+			// the type of their key are rejected.
+			for i, corrupt := range []func(block []byte){
+				func(block []byte) { block[len(block)-1] ^= 0xff },
+				func(block []byte) {
+					block[4] = tsm1.BlockInteger
+					binary.BigEndian.PutUint32(block[:4], crc32.ChecksumIEEE(block[4:]))
+				},
+			} {
+				corruptPath := MustCorruptTSMBlock(path, filepath.Join(dir, fmt.Sprintf("corrupt%d.tsm", i)), corrupt)
+				if err := e.IngestTSMFiles([]string{corruptPath}, 0, 10000000000); err == nil {
+					t.Fatalf("expected error for corrupt block %d", i)
+				}
+			}
+
+			if err := e.IngestTSMFiles([]string{path}, 0, 10000000000); err != nil {
+				t.Fatalf("failed to ingest tsm file: %s", err.Error())
+			}
+
+			if got, exp := e.FileStore.Count(), 1; got != exp {
+				t.Fatalf("unexpected file count: got %d, exp %d", got, exp)
+			}
+			if f := e.MeasurementFields([]byte("cpu")).Field("value"); f == nil || f.Type != influxql.Float {
+				t.Fatalf("unexpected field: %v", f)
+			}
+			if got, exp := e.SeriesN(), int64(3); got != exp {
+				t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+			}
+
+			values, err := e.FileStore.Read(tsm1.SeriesFieldKeyBytes("cpu,host=B", "value"), 3000000000)
+			if err != nil {
+				t.Fatal(err)
+			} else if exp := []tsm1.Value{tsm1.NewValue(3000000000, 2.1)}; !reflect.DeepEqual(values, exp) {
+				t.Fatalf("unexpected values: got %v, exp %v", values, exp)
+			}
+
+			// The source file is left in place.
+			if _, err := os.Stat(path); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// MustCorruptTSMBlock copies the TSM file at src to dst after modifying its
+// first block, including the checksum, with fn.
+func MustCorruptTSMBlock(src, dst string, fn func(block []byte)) string {
+	buf, err := ioutil.ReadFile(src)
+	if err != nil {
+		panic(err)
+	}
+
+	r := MustOpenTSMReader(src)
+	key, _ := r.KeyAt(0)
+	entry := r.Entries(key)[0]
+	r.Close()
+
+	fn(buf[entry.Offset : entry.Offset+int64(entry.Size)])
+	if err := ioutil.WriteFile(dst, buf, 0666); err != nil {
+		panic(err)
+	}
+	return dst
+}
+
 // Ensure that deletes only sent to the WAL will clear out the data from the cache on restart
 func TestEngine_DeleteWALLoadMetadata(t *testing.T) {
 	for _, index := range tsdb.RegisteredIndexes() {
@@ -931,6 +1150,137 @@ func TestEngine_CreateIterator_Condition(t *testing.T) {
 	}
 }
 
+// Ensure aggregates computed from the statistics of blocks match the values
+// of the blocks that are not aggregated.
+func TestEngine_CreateIterator_BlockStatistics(t *testing.T) {
+	t.Parallel()
+
+	// Write a value every second from 0s to 9s, either in a single block or
+	// in one block per 5s window.
+	setup := func(index string, enabled, split bool) *Engine {
+		e, err := NewEngine(index, func(opt *tsdb.EngineOptions) {
+			opt.Config.TSMBlockStatistics = enabled
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.CompactionPlan = &mockPlanner{}
+		if err := e.Open(); err != nil {
+			t.Fatal(err)
+		}
+
+		e.MeasurementFields([]byte("cpu")).CreateFieldIfNotExists([]byte("value"), influxql.Float)
+		e.CreateSeriesIfNotExists([]byte("cpu,host=A"), []byte("cpu"), models.NewTags(map[string]string{"host": "A"}))
+		for i := 0; i < 10; i++ {
+			if err := e.WritePointsString(fmt.Sprintf("cpu,host=A value=%d %d", i, int64(i)*int64(time.Second))); err != nil {
+				t.Fatal(err)
+			}
+			if split && i == 4 {
+				e.MustWriteSnapshot()
+			}
+		}
+		e.MustWriteSnapshot()
+		return e
+	}
+
+	type point struct {
+		Time  int64
+		Value interface{}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		split  bool
+		expr   string
+		start  int64
+		end    int64
+		every  time.Duration
+		change func(e *Engine) error
+		exp    []point
+	}{
+		{name: "Count", expr: `count(value)`, end: 9, exp: []point{{0, int64(10)}}},
+		{name: "Sum", expr: `sum(value)`, end: 9, exp: []point{{0, float64(45)}}},
+		{name: "Partial", expr: `sum(value)`, start: 3, end: 6, exp: []point{{3, float64(18)}}},
+		{
+			name: "Cache", expr: `sum(value)`, end: 9,
+			change: func(e *Engine) error { return e.WritePointsString(`cpu,host=A value=100 5000000000`) },
+			exp:    []point{{0, float64(140)}},
+		},
+		{
+			name: "Tombstone", expr: `count(value)`, end: 9,
+			change: func(e *Engine) error {
+				return e.DeleteSeriesRange(&seriesIterator{keys: [][]byte{[]byte("cpu,host=A")}}, 2*int64(time.Second), 3*int64(time.Second))
+			},
+			exp: []point{{0, int64(8)}},
+		},
+		{name: "MaxWindows", split: true, expr: `max(value)`, end: 9, every: 5 * time.Second, exp: []point{{0, float64(4)}, {5, float64(9)}}},
+		{name: "MinWindows", split: true, expr: `min(value)`, end: 9, every: 5 * time.Second, exp: []point{{0, float64(0)}, {5, float64(5)}}},
+		{name: "MaxSpanningWindows", expr: `max(value)`, end: 9, every: 5 * time.Second, exp: []point{{0, float64(4)}, {5, float64(9)}}},
+		{
+			name: "MaxWindowsCache", split: true, expr: `max(value)`, end: 9, every: 5 * time.Second,
+			change: func(e *Engine) error { return e.WritePointsString(`cpu,host=A value=-1 4000000000`) },
+			exp:    []point{{0, float64(3)}, {5, float64(9)}},
+		},
+	} {
+		for _, index := range tsdb.RegisteredIndexes() {
+			for _, enabled := range []bool{true, false} {
+				t.Run(fmt.Sprintf("%s/%s/%t", tt.name, index, enabled), func(t *testing.T) {
+					e := setup(index, enabled, tt.split)
+					defer e.Close()
+
+					if tt.change != nil {
+						if err := tt.change(e); err != nil {
+							t.Fatal(err)
+						}
+					}
+
+					opt := query.IteratorOptions{
+						Expr:      influxql.MustParseExpr(tt.expr),
+						StartTime: tt.start * int64(time.Second),
+						EndTime:   tt.end * int64(time.Second),
+						Interval:  query.Interval{Duration: tt.every},
+						Ascending: true,
+					}
+					itr, err := e.CreateIterator(context.Background(), "cpu", opt)
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer itr.Close()
+
+					// Selectors return the time of a value instead of its window.
+					var got []point
+					add := func(ts int64, v interface{}) {
+						start, _ := opt.Window(ts)
+						got = append(got, point{start / int64(time.Second), v})
+					}
+					switch itr := itr.(type) {
+					case query.FloatIterator:
+						for p, err := itr.Next(); p != nil || err != nil; p, err = itr.Next() {
+							if err != nil {
+								t.Fatal(err)
+							}
+							add(p.Time, p.Value)
+						}
+					case query.IntegerIterator:
+						for p, err := itr.Next(); p != nil || err != nil; p, err = itr.Next() {
+							if err != nil {
+								t.Fatal(err)
+							}
+							add(p.Time, p.Value)
+						}
+					default:
+						t.Fatalf("unexpected iterator: %T", itr)
+					}
+
+					if !reflect.DeepEqual(got, tt.exp) {
+						t.Fatalf("unexpected points: got %v, exp %v", got, tt.exp)
+					}
+				})
+			}
+		}
+	}
+}
+
 // Test that series id set gets updated and returned appropriately.
 func TestIndex_SeriesIDSet(t *testing.T) {
 	test := func(index string) error {
@@ -1327,6 +1677,54 @@ func TestEngine_LastModified(t *testing.T) {
 	}
 }
 
+// Ensure the engine reports whether fields have values in a time range from
+// the time ranges of their blocks and the cache.
+func TestEngine_HasFieldValues(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			e := MustOpenEngine(index)
+			defer e.Close()
+
+			if err := e.WritePointsString(
+				`cpu,host=A value=1.1 1000000000`,
+				`cpu,host=A value=1.3 3000000000`,
+			); err != nil {
+				t.Fatalf("failed to write points: %s", err.Error())
+			}
+			e.MustWriteSnapshot()
+
+			if err := e.WritePointsString(`cpu,host=A value=1.4 10000000000`); err != nil {
+				t.Fatalf("failed to write points: %s", err.Error())
+			}
+
+			key := []byte("cpu,host=A")
+			for _, tt := range []struct {
+				field    string
+				min, max int64
+				exp      bool
+			}{
+				{field: "value", min: 0, max: 1000000000, exp: true},
+				{field: "value", min: 2000000000, max: 2000000000, exp: true}, // within the block
+				{field: "value", min: 4000000000, max: 9000000000, exp: false},
+				{field: "value", min: 10000000000, max: 20000000000, exp: true}, // cached
+				{field: "idle", min: 0, max: 20000000000, exp: false},
+			} {
+				if got := e.HasFieldValues(key, tt.field, tt.min, tt.max); got != tt.exp {
+					t.Errorf("field %s, time range %d-%d: got %v, exp %v", tt.field, tt.min, tt.max, got, tt.exp)
+				}
+			}
+
+			// Deleted blocks have no values.
+			itr := &seriesIterator{keys: [][]byte{key}}
+			if err := e.DeleteSeriesRange(itr, 0, 5000000000); err != nil {
+				t.Fatalf("failed to delete series: %v", err)
+			} else if e.HasFieldValues(key, "value", 0, 5000000000) {
+				t.Error("expected no values after delete")
+			}
+		})
+	}
+}
+
 func TestEngine_SnapshotsDisabled(t *testing.T) {
 	sfile := MustOpenSeriesFile()
 	defer sfile.Close()
@@ -1786,8 +2184,9 @@ type Engine struct {
 	sfile     *tsdb.SeriesFile
 }
 
-// NewEngine returns a new instance of Engine at a temporary location.
-func NewEngine(index string) (*Engine, error) {
+// NewEngine returns a new instance of Engine at a temporary location.  The
+// engine options can be changed by fns.
+func NewEngine(index string, fns ...func(opt *tsdb.EngineOptions)) (*Engine, error) {
 	root, err := ioutil.TempDir("", "tsm1-")
 	if err != nil {
 		panic(err)
@@ -1816,6 +2215,9 @@ func NewEngine(index string) (*Engine, error) {
 	// store level.
 	seriesIDs := tsdb.NewSeriesIDSet()
 	opt.SeriesIDSets = seriesIDSets([]*tsdb.SeriesIDSet{seriesIDs})
+	for _, fn := range fns {
+		fn(&opt)
+	}
 
 	idxPath := filepath.Join(dbPath, "index")
 	idx := tsdb.MustOpenIndex(1, db, idxPath, seriesIDs, sfile, opt)
@@ -45,6 +45,10 @@ type TSMFile interface {
 	ReadStringBlockAt(entry *IndexEntry, values *[]StringValue) ([]StringValue, error)
 	ReadBooleanBlockAt(entry *IndexEntry, values *[]BooleanValue) ([]BooleanValue, error)
 
+	// BlockStats returns the statistics of the values in the block identified
+	// by entry.  Returns false if the file does not store statistics.
+	BlockStats(entry *IndexEntry) (BlockStats, bool)
+
 	// Entries returns the index entries for all blocks for the given key.
 	Entries(key []byte) []IndexEntry
 	ReadEntries(key []byte, entries *[]IndexEntry) []IndexEntry
@@ -138,8 +142,10 @@ type TSMFile interface {
 
 // Statistics gathered by the FileStore.
 const (
-	statFileStoreBytes = "diskBytes"
-	statFileStoreCount = "numFiles"
+	statFileStoreBytes         = "diskBytes"
+	statFileStoreCount         = "numFiles"
+	statFileStoreCorruptBlocks = "corruptBlocks"
+	statFileStoreQuarantined   = "numQuarantinedFiles"
 )
 
 var (
@@ -172,6 +178,10 @@ type FileStore struct {
 	traceLogger  *zap.Logger // Logger to be used when trace-logging is on.
 	traceLogging bool
 
+	// verifyChecksums is true if the checksum of each block is verified when
+	// the block is read.
+	verifyChecksums bool
+
 	stats  *FileStoreStatistics
 	purger *purger
 
@@ -229,6 +239,28 @@ func (f *FileStore) enableTraceLogging(enabled bool) {
 	}
 }
 
+// enableChecksumVerification must be called before the FileStore is opened.
+func (f *FileStore) enableChecksumVerification(enabled bool) {
+	f.verifyChecksums = enabled
+}
+
+// readerOptions returns the options of the TSMReaders opened by the FileStore.
+func (f *FileStore) readerOptions() []TSMReaderOption {
+	return []TSMReaderOption{
+		WithChecksumVerification(f.verifyChecksums),
+		WithCorruptBlockFn(f.corruptBlock),
+	}
+}
+
+// corruptBlock reports a block that failed checksum verification.  The block
+// was excluded from the read and its file is quarantined.
+func (f *FileStore) corruptBlock(path string, err error) {
+	atomic.AddInt64(&f.stats.CorruptBlocks, 1)
+	f.logger.Error("Corrupt TSM block excluded from read, file quarantined",
+		zap.String("path", path),
+		zap.Error(err))
+}
+
 // WithLogger sets the logger on the file store.
 func (f *FileStore) WithLogger(log *zap.Logger) {
 	f.logger = log.With(zap.String("service", "filestore"))
@@ -241,8 +273,9 @@ func (f *FileStore) WithLogger(log *zap.Logger) {
 
 // FileStoreStatistics keeps statistics about the file store.
 type FileStoreStatistics struct {
-	DiskBytes int64
-	FileCount int64
+	DiskBytes     int64
+	FileCount     int64
+	CorruptBlocks int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -251,12 +284,32 @@ func (f *FileStore) Statistics(tags map[string]string) []models.Statistic {
 		Name: "tsm1_filestore",
 		Tags: tags,
 		Values: map[string]interface{}{
-			statFileStoreBytes: atomic.LoadInt64(&f.stats.DiskBytes),
-			statFileStoreCount: atomic.LoadInt64(&f.stats.FileCount),
+			statFileStoreBytes:         atomic.LoadInt64(&f.stats.DiskBytes),
+			statFileStoreCount:         atomic.LoadInt64(&f.stats.FileCount),
+			statFileStoreCorruptBlocks: atomic.LoadInt64(&f.stats.CorruptBlocks),
+			statFileStoreQuarantined:   int64(len(f.Quarantined())),
 		},
 	}}
 }
 
+// Quarantined returns the paths of the TSM files with blocks that failed
+// checksum verification.
+func (f *FileStore) Quarantined() map[string]struct{} {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var paths map[string]struct{}
+	for _, file := range f.files {
+		if r, ok := file.(*TSMReader); ok && r.Quarantined() {
+			if paths == nil {
+				paths = make(map[string]struct{})
+			}
+			paths[r.Path()] = struct{}{}
+		}
+	}
+	return paths
+}
+
 // Count returns the number of TSM files currently loaded.
 func (f *FileStore) Count() int {
 	f.mu.RLock()
@@ -300,7 +353,8 @@ func (f *FileStore) NextGeneration() int {
 }
 
 // WalkKeys calls fn for every key in every TSM file known to the FileStore.  If the key
-// exists in multiple files, it will be invoked for each file.
+// exists in multiple files, it will be invoked for each file.  The files are
+// referenced during the walk so they are not removed by compactions.
 func (f *FileStore) WalkKeys(seek []byte, fn func(key []byte, typ byte) error) error {
 	f.mu.RLock()
 	if len(f.files) == 0 {
@@ -308,7 +362,14 @@ func (f *FileStore) WalkKeys(seek []byte, fn func(key []byte, typ byte) error) e
 		return nil
 	}
 
-	ki := newMergeKeyIterator(f.files, seek)
+	files := make([]TSMFile, len(f.files))
+	copy(files, f.files)
+	for _, file := range files {
+		file.Ref()
+		defer file.Unref()
+	}
+
+	ki := newMergeKeyIterator(files, seek)
 	f.mu.RUnlock()
 	for ki.Next() {
 		key, typ := ki.Read()
@@ -482,7 +543,7 @@ func (f *FileStore) Open() error {
 
 		go func(idx int, file *os.File) {
 			start := time.Now()
-			df, err := NewTSMReader(file)
+			df, err := NewTSMReader(file, f.readerOptions()...)
 			f.logger.Info("Opened file",
 				zap.String("path", file.Name()),
 				zap.Int("id", idx),
@@ -569,9 +630,16 @@ func (f *FileStore) Read(key []byte, t int64) ([]Value, error) {
 }
 
 func (f *FileStore) Cost(key []byte, min, max int64) query.IteratorCost {
+	return f.costFiles(key, min, max, nil)
+}
+
+// costFiles returns the cost of reading key like Cost. If files is not nil,
+// only the files that are not in files are counted and their paths are added
+// to it.
+func (f *FileStore) costFiles(key []byte, min, max int64, files map[string]struct{}) query.IteratorCost {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
-	return f.cost(key, min, max)
+	return f.cost(key, min, max, files)
 }
 
 // Reader returns a TSMReader for path if one is currently managed by the FileStore.
@@ -634,6 +702,75 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 	return f.replace(oldFiles, newFiles, nil)
 }
 
+// ReplacePurged replaces the TSM file at path with newFile, the same file
+// rewritten without the data deleted by its tombstones, and removes the
+// tombstones.  The file is removed if newFile is empty.  ErrFileInUse is
+// returned if the file is in use by a query.
+func (f *FileStore) ReplacePurged(path, newFile string) error {
+	if newFile == "" {
+		return f.Replace([]string{path}, nil)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := -1
+	for j, file := range f.files {
+		if file.Path() == path {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return fmt.Errorf("replace purged file: %s not found", path)
+	}
+
+	old := f.files[i]
+	if old.InUse() {
+		return ErrFileInUse
+	}
+
+	// Renaming over the old file keeps it intact until the new file is live.
+	// The old file stays readable through its open reader.
+	if err := os.Rename(newFile, path); err != nil {
+		return err
+	}
+	if err := syncDir(f.dir); err != nil {
+		return err
+	}
+
+	oldSize := int64(old.Size())
+	for _, ts := range old.TombstoneFiles() {
+		oldSize += int64(ts.Size)
+		if err := os.RemoveAll(ts.Path); err != nil {
+			return err
+		}
+	}
+	if err := old.Close(); err != nil {
+		return err
+	}
+
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	tsm, err := NewTSMReader(fd, f.readerOptions()...)
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	// The slice of files may be in use by callers of Files.
+	files := make([]TSMFile, len(f.files))
+	copy(files, f.files)
+	files[i] = tsm
+
+	f.files = files
+	f.lastFileStats = nil
+	atomic.AddInt64(&f.stats.DiskBytes, int64(tsm.Size())-oldSize)
+	return nil
+}
+
 func (f *FileStore) replace(oldFiles, newFiles []string, updatedFn func(r []TSMFile)) error {
 	if len(oldFiles) == 0 && len(newFiles) == 0 {
 		return nil
@@ -672,7 +809,7 @@ func (f *FileStore) replace(oldFiles, newFiles []string, updatedFn func(r []TSMF
 			}
 		}
 
-		tsm, err := NewTSMReader(fd)
+		tsm, err := NewTSMReader(fd, f.readerOptions()...)
 		if err != nil {
 			return err
 		}
@@ -820,7 +957,7 @@ func (f *FileStore) BlockCount(path string, idx int) int {
 
 // We need to determine the possible files that may be accessed by this query given
 // the time range.
-func (f *FileStore) cost(key []byte, min, max int64) query.IteratorCost {
+func (f *FileStore) cost(key []byte, min, max int64, files map[string]struct{}) query.IteratorCost {
 	var cache []IndexEntry
 	cost := query.IteratorCost{}
 	for _, fd := range f.files {
@@ -852,13 +989,54 @@ func (f *FileStore) cost(key []byte, min, max int64) query.IteratorCost {
 			skipped = false
 		}
 
-		if !skipped {
-			cost.NumFiles++
+		if skipped {
+			continue
+		} else if files != nil {
+			if _, ok := files[fd.Path()]; ok {
+				continue
+			}
+			files[fd.Path()] = struct{}{}
 		}
+		cost.NumFiles++
 	}
 	return cost
 }
 
+// hasValues returns true if a block of key overlaps the time range min to max,
+// skipping blocks whose values are all tombstoned.  The blocks are not read, so
+// a block spanning the time range is assumed to hold values within it.
+func (f *FileStore) hasValues(key []byte, min, max int64) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var cache []IndexEntry
+	for _, fd := range f.files {
+		minTime, maxTime := fd.TimeRange()
+		if maxTime < min || minTime > max {
+			continue
+		}
+		tombstones := fd.TombstoneRange(key)
+
+		entries := fd.ReadEntries(key, &cache)
+	ENTRIES:
+		for i := 0; i < len(entries); i++ {
+			ie := entries[i]
+			if !ie.OverlapsTimeRange(min, max) {
+				continue
+			}
+
+			// Skip any blocks only contain values that are tombstoned.
+			for _, t := range tombstones {
+				if t.Min <= ie.MinTime && t.Max >= ie.MaxTime {
+					continue ENTRIES
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
 // locations returns the files and index blocks for a key and time.  ascending indicates
 // whether the key will be scan in ascending time order or descenging time order.
 // This function assumes the read-lock has been taken.
@@ -1099,10 +1277,10 @@ func (c *KeyCursor) Close() {
 
 // seek positions the cursor at the given time.
 func (c *KeyCursor) seek(t int64) {
+	c.current = nil
 	if len(c.seeks) == 0 {
 		return
 	}
-	c.current = nil
 
 	if c.ascending {
 		c.seekAscending(t)
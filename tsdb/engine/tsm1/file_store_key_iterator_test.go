@@ -196,3 +196,7 @@ func (*mockTSMFile) ReadStringBlockAt(*IndexEntry, *[]StringValue) ([]StringValu
 func (*mockTSMFile) ReadBooleanBlockAt(*IndexEntry, *[]BooleanValue) ([]BooleanValue, error) {
 	panic("implement me")
 }
+
+func (*mockTSMFile) BlockStats(*IndexEntry) (BlockStats, bool) {
+	panic("implement me")
+}
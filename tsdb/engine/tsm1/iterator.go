@@ -3,11 +3,13 @@ package tsm1
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/influxdata/influxdb/pkg/metrics"
 	"github.com/influxdata/influxdb/pkg/tracing"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
 	"go.uber.org/zap"
 )
 
@@ -216,3 +218,119 @@ func newInstrumentedIterator(ctx context.Context, itr query.Iterator) query.Iter
 		panic(fmt.Sprintf("unsupported instrumented iterator type: %T", itr))
 	}
 }
+
+// newBlockAggregateIterator returns an iterator of the partial aggregates of
+// blocks computed from their statistics.  The partial aggregates must be
+// merged with the aggregates of any values read from the series.
+func newBlockAggregateIterator(name string, tags query.Tags, call string, typ influxql.DataType, aggs []blockAggregate, opt query.IteratorOptions) query.Iterator {
+	if !opt.Ascending {
+		reversed := make([]blockAggregate, len(aggs))
+		for i := range aggs {
+			reversed[len(aggs)-1-i] = aggs[i]
+		}
+		aggs = reversed
+	}
+
+	var stats query.IteratorStats
+	for _, agg := range aggs {
+		stats.PointN += int(agg.stats.Count)
+	}
+
+	if call == "count" {
+		points := make([]query.IntegerPoint, len(aggs))
+		for i, agg := range aggs {
+			points[i] = query.IntegerPoint{Name: name, Tags: tags, Time: agg.minTime, Value: int64(agg.stats.Count), Aggregated: agg.stats.Count}
+		}
+		return &integerPointsIterator{points: points, stats: stats}
+	}
+
+	// Select the statistic used for the call.  The bits of the statistic are
+	// interpreted according to the type of the block.
+	stat := func(s BlockStats) uint64 {
+		switch call {
+		case "min":
+			return s.Min
+		case "max":
+			return s.Max
+		default:
+			return s.Sum
+		}
+	}
+
+	switch typ {
+	case influxql.Float:
+		points := make([]query.FloatPoint, len(aggs))
+		for i, agg := range aggs {
+			points[i] = query.FloatPoint{Name: name, Tags: tags, Time: agg.minTime, Value: math.Float64frombits(stat(agg.stats)), Aggregated: agg.stats.Count}
+		}
+		return &floatPointsIterator{points: points, stats: stats}
+	case influxql.Integer:
+		points := make([]query.IntegerPoint, len(aggs))
+		for i, agg := range aggs {
+			points[i] = query.IntegerPoint{Name: name, Tags: tags, Time: agg.minTime, Value: int64(stat(agg.stats)), Aggregated: agg.stats.Count}
+		}
+		return &integerPointsIterator{points: points, stats: stats}
+	case influxql.Unsigned:
+		points := make([]query.UnsignedPoint, len(aggs))
+		for i, agg := range aggs {
+			points[i] = query.UnsignedPoint{Name: name, Tags: tags, Time: agg.minTime, Value: stat(agg.stats), Aggregated: agg.stats.Count}
+		}
+		return &unsignedPointsIterator{points: points, stats: stats}
+	default:
+		panic(fmt.Sprintf("unsupported block aggregate type: %s", typ))
+	}
+}
+
+// floatPointsIterator returns points from a slice.
+type floatPointsIterator struct {
+	points []query.FloatPoint
+	stats  query.IteratorStats
+}
+
+func (itr *floatPointsIterator) Stats() query.IteratorStats { return itr.stats }
+func (itr *floatPointsIterator) Close() error               { itr.points = nil; return nil }
+
+func (itr *floatPointsIterator) Next() (*query.FloatPoint, error) {
+	if len(itr.points) == 0 {
+		return nil, nil
+	}
+	p := &itr.points[0]
+	itr.points = itr.points[1:]
+	return p, nil
+}
+
+// integerPointsIterator returns points from a slice.
+type integerPointsIterator struct {
+	points []query.IntegerPoint
+	stats  query.IteratorStats
+}
+
+func (itr *integerPointsIterator) Stats() query.IteratorStats { return itr.stats }
+func (itr *integerPointsIterator) Close() error               { itr.points = nil; return nil }
+
+func (itr *integerPointsIterator) Next() (*query.IntegerPoint, error) {
+	if len(itr.points) == 0 {
+		return nil, nil
+	}
+	p := &itr.points[0]
+	itr.points = itr.points[1:]
+	return p, nil
+}
+
+// unsignedPointsIterator returns points from a slice.
+type unsignedPointsIterator struct {
+	points []query.UnsignedPoint
+	stats  query.IteratorStats
+}
+
+func (itr *unsignedPointsIterator) Stats() query.IteratorStats { return itr.stats }
+func (itr *unsignedPointsIterator) Close() error               { itr.points = nil; return nil }
+
+func (itr *unsignedPointsIterator) Next() (*query.UnsignedPoint, error) {
+	if len(itr.points) == 0 {
+		return nil, nil
+	}
+	p := &itr.points[0]
+	itr.points = itr.points[1:]
+	return p, nil
+}
@@ -0,0 +1,265 @@
+package tsm1
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// QuarantineFileExtension is the extension of the file recording the
+	// blocks of a TSM file that failed checksum verification.  A TSM file is
+	// quarantined while this file exists.
+	QuarantineFileExtension = "quarantine"
+
+	// RepairTmpExtension is the extension of the temporary file a TSM file is
+	// rewritten to when its corrupt blocks are dropped.
+	RepairTmpExtension = "repair.tmp"
+)
+
+// errBlockChecksum is returned when the checksum stored with a block does
+// not match the block data.
+type errBlockChecksum struct {
+	offset int64
+	size   uint32
+}
+
+func (e errBlockChecksum) Error() string {
+	return fmt.Sprintf("block checksum mismatch: offset %d, size %d", e.offset, e.size)
+}
+
+// VerifyBlockChecksum returns true if the checksum matches the block data
+// returned by ReadBytes or BlockIterator.Read.
+func VerifyBlockChecksum(checksum uint32, block []byte) bool {
+	return crc32.ChecksumIEEE(block) == checksum
+}
+
+// verifyBlock returns an errBlockChecksum if the block at entry is corrupt.
+// Callers must hold m.mu and have checked the bounds of the block.
+func (m *mmapAccessor) verifyBlock(entry *IndexEntry) error {
+	if !m.verifyChecksums {
+		return nil
+	}
+
+	if entry.Size < crc32.Size {
+		return errBlockChecksum{offset: entry.Offset, size: entry.Size}
+	}
+	b := m.b[entry.Offset : entry.Offset+int64(entry.Size)]
+	if !VerifyBlockChecksum(binary.BigEndian.Uint32(b[:crc32.Size]), b[crc32.Size:]) {
+		return errBlockChecksum{offset: entry.Offset, size: entry.Size}
+	}
+	return nil
+}
+
+// checkBlock quarantines the file if err reports a corrupt block.  Corrupt
+// blocks are excluded from reads, so nil is returned in place of their error.
+func (t *TSMReader) checkBlock(err error) error {
+	if e, ok := err.(errBlockChecksum); ok {
+		t.quarantine(e)
+		return nil
+	}
+	return err
+}
+
+// quarantine records the corrupt block in the quarantine file.  Each block
+// is only recorded and reported once.
+func (t *TSMReader) quarantine(e errBlockChecksum) {
+	t.quarantineMu.Lock()
+	if _, ok := t.corrupt[e.offset]; ok {
+		t.quarantineMu.Unlock()
+		return
+	}
+
+	if t.corrupt == nil {
+		t.corrupt = make(map[int64]struct{})
+	}
+	t.corrupt[e.offset] = struct{}{}
+
+	path := t.Path()
+	var err error = e
+	if werr := appendQuarantineFile(path, e); werr != nil {
+		err = fmt.Errorf("%v: cannot write quarantine file: %v", e, werr)
+	}
+	fn := t.corruptBlockFn
+	t.quarantineMu.Unlock()
+
+	if fn != nil {
+		fn(path, err)
+	}
+}
+
+// Quarantined returns true if a block of the file failed checksum
+// verification.  Quarantined files should not be compacted until they are
+// repaired.
+func (t *TSMReader) Quarantined() bool {
+	t.quarantineMu.Lock()
+	defer t.quarantineMu.Unlock()
+	return len(t.corrupt) > 0
+}
+
+// quarantineFilePath returns the path of the quarantine file of the TSM file
+// at path.
+func quarantineFilePath(path string) string {
+	return fmt.Sprintf("%s.%s", path, QuarantineFileExtension)
+}
+
+// readQuarantineFile returns the offsets of the corrupt blocks recorded for
+// the TSM file at path.  It returns nil if the file is not quarantined.
+func readQuarantineFile(path string) (map[int64]struct{}, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(quarantineFilePath(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Each line holds the offset and the size of a corrupt block.  The file
+	// stays quarantined even if no line can be parsed, so an invalid offset
+	// is always recorded.
+	corrupt := map[int64]struct{}{-1: {}}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if offset, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+			corrupt[offset] = struct{}{}
+		}
+	}
+	return corrupt, scanner.Err()
+}
+
+// appendQuarantineFile records the corrupt block in the quarantine file of
+// the TSM file at path.
+func appendQuarantineFile(path string, e errBlockChecksum) error {
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(quarantineFilePath(path), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(f, "%d %d\n", e.offset, e.size); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// DropCorruptBlocks rewrites the TSM file at path without the blocks failing
+// checksum verification and removes its quarantine file.  The tombstones of
+// the file are kept.  If no block is left, the file and its tombstones are
+// removed.  The file must not be in use by a running engine.  It returns the
+// number of blocks that were dropped.
+func DropCorruptBlocks(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+
+	tmp := fmt.Sprintf("%s.%s", path, RepairTmpExtension)
+	n, err := writeVerifiedFile(tmp, r)
+	if n == 0 && err == nil {
+		// Nothing to drop, keep the file as is.
+		os.Remove(tmp)
+	}
+
+	var tombstones []string
+	for _, ts := range r.TombstoneFiles() {
+		tombstones = append(tombstones, ts.Path)
+	}
+	if closeErr := r.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == ErrNoValues {
+		// Every block of the file was corrupt.
+		for _, p := range append(tombstones, path) {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return 0, err
+			}
+		}
+	} else if err != nil {
+		os.Remove(tmp)
+		return 0, err
+	} else if n > 0 {
+		if err := os.Rename(tmp, path); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := os.Remove(quarantineFilePath(path)); err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	return n, nil
+}
+
+// writeVerifiedFile copies the blocks of r with a valid checksum to a new TSM
+// file at path.  It returns the number of blocks that were not copied, and
+// ErrNoValues if no block was copied.
+func writeVerifiedFile(path string, r *TSMReader) (int, error) {
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return 0, err
+	}
+
+	w, err := NewTSMWriter(fd)
+	if err != nil {
+		fd.Close()
+		return 0, err
+	}
+
+	var n int
+	iter := r.BlockIterator()
+	for iter.Next() {
+		key, minTime, maxTime, _, checksum, b, err := iter.Read()
+		if err != nil {
+			w.Close()
+			return 0, err
+		}
+		if !VerifyBlockChecksum(checksum, b) {
+			n++
+			continue
+		}
+		if err := w.WriteBlock(key, minTime, maxTime, b); err != nil {
+			w.Close()
+			return 0, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		w.Close()
+		return 0, err
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		w.Close()
+		if err == ErrNoValues {
+			os.Remove(path)
+			return n, err
+		}
+		return 0, err
+	}
+	return n, w.Close()
+}
@@ -0,0 +1,118 @@
+package tsm1_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+// corruptBlock flips a byte of the data of the first block of the TSM file.
+func corruptBlock(t *testing.T, path string) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("unexpected error opening file: %v", err)
+	}
+	defer f.Close()
+
+	// The first block follows the 5 byte header and its 4 byte checksum.
+	b := make([]byte, 1)
+	if _, err := f.ReadAt(b, 10); err != nil {
+		t.Fatalf("unexpected error reading file: %v", err)
+	}
+	b[0] ^= 0xff
+	if _, err := f.WriteAt(b, 10); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+}
+
+func TestTSMReader_VerifyChecksums(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	a1 := tsm1.NewValue(1, 1.1)
+	b1 := tsm1.NewValue(1, 2.1)
+	path := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{a1},
+		"cpu,host=B#!~#value": []tsm1.Value{b1},
+	})
+	corruptBlock(t, path)
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error opening file: %v", err)
+	}
+
+	var reported int
+	r, err := tsm1.NewTSMReader(f,
+		tsm1.WithChecksumVerification(true),
+		tsm1.WithCorruptBlockFn(func(string, error) { reported++ }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating reader: %v", err)
+	}
+
+	// The corrupt block is excluded, twice but reported once.
+	for i := 0; i < 2; i++ {
+		entries := r.Entries([]byte("cpu,host=A#!~#value"))
+		var buf []tsm1.FloatValue
+		values, err := r.ReadFloatBlockAt(&entries[0], &buf)
+		if err != nil {
+			t.Fatalf("unexpected error reading block: %v", err)
+		} else if len(values) != 0 {
+			t.Fatalf("expected no values, got %v", values)
+		}
+	}
+	if got, exp := reported, 1; got != exp {
+		t.Fatalf("reported blocks mismatch: got %v, exp %v", got, exp)
+	}
+	if !r.Quarantined() {
+		t.Fatal("expected file to be quarantined")
+	}
+
+	entries := r.Entries([]byte("cpu,host=B#!~#value"))
+	values, err := r.ReadAt(&entries[0], nil)
+	if err != nil {
+		t.Fatalf("unexpected error reading block: %v", err)
+	} else if exp := []tsm1.Value{b1}; !reflect.DeepEqual(values, exp) {
+		t.Fatalf("values mismatch: got %v, exp %v", values, exp)
+	}
+
+	// ReadAll excludes the corrupt block as well.
+	if values, err := r.ReadAll([]byte("cpu,host=A#!~#value")); err != nil {
+		t.Fatalf("unexpected error reading key: %v", err)
+	} else if len(values) != 0 {
+		t.Fatalf("expected no values, got %v", values)
+	}
+	if got, exp := reported, 1; got != exp {
+		t.Fatalf("reported blocks mismatch: got %v, exp %v", got, exp)
+	}
+	r.Close()
+
+	// The quarantine survives reopening the file.
+	r = MustOpenTSMReader(path)
+	if !r.Quarantined() {
+		t.Fatal("expected file to be quarantined after reopening")
+	}
+	r.Close()
+
+	n, err := tsm1.DropCorruptBlocks(path)
+	if err != nil {
+		t.Fatalf("unexpected error dropping corrupt blocks: %v", err)
+	} else if got, exp := n, 1; got != exp {
+		t.Fatalf("dropped blocks mismatch: got %v, exp %v", got, exp)
+	}
+
+	r = MustOpenTSMReader(path)
+	defer r.Close()
+	if r.Quarantined() {
+		t.Fatal("expected quarantine to be lifted")
+	}
+	if got, exp := r.KeyCount(), 1; got != exp {
+		t.Fatalf("keys length mismatch: got %v, exp %v", got, exp)
+	}
+	if _, err := os.Stat(path + "." + tsm1.QuarantineFileExtension); !os.IsNotExist(err) {
+		t.Fatalf("expected quarantine file to be removed, got %v", err)
+	}
+}
@@ -46,6 +46,40 @@ type TSMReader struct {
 
 	// deleteMu limits concurrent deletes
 	deleteMu sync.Mutex
+
+	// verifyChecksums is true if the checksum of each block is verified when
+	// the block is read.
+	verifyChecksums bool
+
+	// corruptBlockFn is called for each block failing checksum verification.
+	corruptBlockFn func(path string, err error)
+
+	// quarantineMu protects corrupt.
+	quarantineMu sync.Mutex
+
+	// corrupt holds the offsets of the blocks that failed checksum verification.
+	// The file is quarantined if it is not empty.
+	corrupt map[int64]struct{}
+}
+
+// TSMReaderOption is a functional option to modify a TSMReader.
+type TSMReaderOption func(t *TSMReader)
+
+// WithChecksumVerification sets whether the checksum of each block is verified
+// when it is read.  A block failing verification quarantines the file and is
+// excluded from reads.
+func WithChecksumVerification(enabled bool) TSMReaderOption {
+	return func(t *TSMReader) {
+		t.verifyChecksums = enabled
+	}
+}
+
+// WithCorruptBlockFn sets a function called with the path of the file and the
+// error for each block failing checksum verification.
+func WithCorruptBlockFn(fn func(path string, err error)) TSMReaderOption {
+	return func(t *TSMReader) {
+		t.corruptBlockFn = fn
+	}
 }
 
 // TSMIndex represent the index section of a TSM file.  The index records all
@@ -209,7 +243,7 @@ func (b *BlockIterator) Err() error {
 type blockAccessor interface {
 	init() (*indirectIndex, error)
 	read(key []byte, timestamp int64) ([]Value, error)
-	readAll(key []byte) ([]Value, error)
+	readAll(key []byte) ([]Value, []errBlockChecksum, error)
 	readBlock(entry *IndexEntry, values []Value) ([]Value, error)
 	readFloatBlock(entry *IndexEntry, values *[]FloatValue) ([]FloatValue, error)
 	readIntegerBlock(entry *IndexEntry, values *[]IntegerValue) ([]IntegerValue, error)
@@ -217,6 +251,7 @@ type blockAccessor interface {
 	readStringBlock(entry *IndexEntry, values *[]StringValue) ([]StringValue, error)
 	readBooleanBlock(entry *IndexEntry, values *[]BooleanValue) ([]BooleanValue, error)
 	readBytes(entry *IndexEntry, buf []byte) (uint32, []byte, error)
+	blockStats(entry *IndexEntry) (BlockStats, bool)
 	rename(path string) error
 	path() string
 	close() error
@@ -224,8 +259,11 @@ type blockAccessor interface {
 }
 
 // NewTSMReader returns a new TSMReader from the given file.
-func NewTSMReader(f *os.File) (*TSMReader, error) {
+func NewTSMReader(f *os.File, options ...TSMReaderOption) (*TSMReader, error) {
 	t := &TSMReader{}
+	for _, option := range options {
+		option(t)
+	}
 
 	stat, err := f.Stat()
 	if err != nil {
@@ -234,7 +272,8 @@ func NewTSMReader(f *os.File) (*TSMReader, error) {
 	t.size = stat.Size()
 	t.lastModified = stat.ModTime().UnixNano()
 	t.accessor = &mmapAccessor{
-		f: f,
+		f:               f,
+		verifyChecksums: t.verifyChecksums,
 	}
 
 	index, err := t.accessor.init()
@@ -249,6 +288,12 @@ func NewTSMReader(f *os.File) (*TSMReader, error) {
 		return nil, err
 	}
 
+	corrupt, err := readQuarantineFile(t.Path())
+	if err != nil {
+		return nil, err
+	}
+	t.corrupt = corrupt
+
 	return t, nil
 }
 
@@ -325,7 +370,7 @@ func (t *TSMReader) ReadAt(entry *IndexEntry, vals []Value) ([]Value, error) {
 	t.mu.RLock()
 	v, err := t.accessor.readBlock(entry, vals)
 	t.mu.RUnlock()
-	return v, err
+	return v, t.checkBlock(err)
 }
 
 // ReadFloatBlockAt returns the float values corresponding to the given index entry.
@@ -333,7 +378,7 @@ func (t *TSMReader) ReadFloatBlockAt(entry *IndexEntry, vals *[]FloatValue) ([]F
 	t.mu.RLock()
 	v, err := t.accessor.readFloatBlock(entry, vals)
 	t.mu.RUnlock()
-	return v, err
+	return v, t.checkBlock(err)
 }
 
 // ReadIntegerBlockAt returns the integer values corresponding to the given index entry.
@@ -341,7 +386,7 @@ func (t *TSMReader) ReadIntegerBlockAt(entry *IndexEntry, vals *[]IntegerValue)
 	t.mu.RLock()
 	v, err := t.accessor.readIntegerBlock(entry, vals)
 	t.mu.RUnlock()
-	return v, err
+	return v, t.checkBlock(err)
 }
 
 // ReadUnsignedBlockAt returns the unsigned integer values corresponding to the given index entry.
@@ -349,7 +394,7 @@ func (t *TSMReader) ReadUnsignedBlockAt(entry *IndexEntry, vals *[]UnsignedValue
 	t.mu.RLock()
 	v, err := t.accessor.readUnsignedBlock(entry, vals)
 	t.mu.RUnlock()
-	return v, err
+	return v, t.checkBlock(err)
 }
 
 // ReadStringBlockAt returns the string values corresponding to the given index entry.
@@ -357,7 +402,7 @@ func (t *TSMReader) ReadStringBlockAt(entry *IndexEntry, vals *[]StringValue) ([
 	t.mu.RLock()
 	v, err := t.accessor.readStringBlock(entry, vals)
 	t.mu.RUnlock()
-	return v, err
+	return v, t.checkBlock(err)
 }
 
 // ReadBooleanBlockAt returns the boolean values corresponding to the given index entry.
@@ -365,7 +410,7 @@ func (t *TSMReader) ReadBooleanBlockAt(entry *IndexEntry, vals *[]BooleanValue)
 	t.mu.RLock()
 	v, err := t.accessor.readBooleanBlock(entry, vals)
 	t.mu.RUnlock()
-	return v, err
+	return v, t.checkBlock(err)
 }
 
 // Read returns the values corresponding to the block at the given key and timestamp.
@@ -373,14 +418,18 @@ func (t *TSMReader) Read(key []byte, timestamp int64) ([]Value, error) {
 	t.mu.RLock()
 	v, err := t.accessor.read(key, timestamp)
 	t.mu.RUnlock()
-	return v, err
+	return v, t.checkBlock(err)
 }
 
-// ReadAll returns all values for a key in all blocks.
+// ReadAll returns all values for a key in all blocks.  Like other reads,
+// blocks failing checksum verification are excluded and quarantine the file.
 func (t *TSMReader) ReadAll(key []byte) ([]Value, error) {
 	t.mu.RLock()
-	v, err := t.accessor.readAll(key)
+	v, corrupt, err := t.accessor.readAll(key)
 	t.mu.RUnlock()
+	for _, e := range corrupt {
+		t.quarantine(e)
+	}
 	return v, err
 }
 
@@ -391,6 +440,15 @@ func (t *TSMReader) ReadBytes(e *IndexEntry, b []byte) (uint32, []byte, error) {
 	return n, v, err
 }
 
+// BlockStats returns the statistics of the values in the block.  Returns false
+// if the file does not store statistics.
+func (t *TSMReader) BlockStats(e *IndexEntry) (BlockStats, bool) {
+	t.mu.RLock()
+	stats, ok := t.accessor.blockStats(e)
+	t.mu.RUnlock()
+	return stats, ok
+}
+
 // Type returns the type of values stored at the given key.
 func (t *TSMReader) Type(key []byte) (byte, error) {
 	return t.index.Type(key)
@@ -456,6 +514,7 @@ func (t *TSMReader) remove() error {
 
 	if path != "" {
 		os.RemoveAll(path)
+		os.RemoveAll(quarantineFilePath(path))
 	}
 
 	if err := t.tombstoner.Delete(); err != nil {
@@ -1339,18 +1398,23 @@ type mmapAccessor struct {
 	f     *os.File
 	b     []byte
 	index *indirectIndex
+
+	// The position of the statistics of each block, if the file has them.
+	statsStart, statsEnd int
+
+	// verifyChecksums is true if blocks are verified before being decoded.
+	verifyChecksums bool
 }
 
 func (m *mmapAccessor) init() (*indirectIndex, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if err := verifyVersion(m.f); err != nil {
+	version, err := verifyVersion(m.f)
+	if err != nil {
 		return nil, err
 	}
 
-	var err error
-
 	if _, err := m.f.Seek(0, 0); err != nil {
 		return nil, err
 	}
@@ -1374,12 +1438,27 @@ func (m *mmapAccessor) init() (*indirectIndex, error) {
 		return nil, fmt.Errorf("mmapAccessor: invalid indexStart")
 	}
 
+	// The statistics of each block are stored between the index and the footer.
+	indexEnd := indexOfsPos
+	if version == VersionBlockStats {
+		if indexOfsPos < 8 {
+			return nil, fmt.Errorf("mmapAccessor: byte slice too small for block statistics")
+		}
+		statsOfsPos := indexOfsPos - 8
+		statsStart := binary.BigEndian.Uint64(m.b[statsOfsPos : statsOfsPos+8])
+		if statsStart < indexStart || statsStart > uint64(statsOfsPos) || (uint64(statsOfsPos)-statsStart)%blockStatsEntrySize != 0 {
+			return nil, fmt.Errorf("mmapAccessor: invalid statsStart")
+		}
+		m.statsStart, m.statsEnd = int(statsStart), statsOfsPos
+		indexEnd = int(statsStart)
+	}
+
 	// Hint to the kernal that we will be reading the file.  It would be better to hint
 	// that we will be reading the index section, but that doesn't seem to work ATM.
 	_ = madviseWillNeed(m.b)
 
 	m.index = NewIndirectIndex()
-	if err := m.index.UnmarshalBinary(m.b[indexStart:indexOfsPos]); err != nil {
+	if err := m.index.UnmarshalBinary(m.b[indexStart:indexEnd]); err != nil {
 		return nil, err
 	}
 
@@ -1476,7 +1555,9 @@ func (m *mmapAccessor) readBlock(entry *IndexEntry, values []Value) ([]Value, er
 	if int64(len(m.b)) < entry.Offset+int64(entry.Size) {
 		return nil, ErrTSMClosed
 	}
-	//TODO: Validate checksum
+	if err := m.verifyBlock(entry); err != nil {
+		return nil, err
+	}
 	var err error
 	values, err = DecodeBlock(m.b[entry.Offset+4:entry.Offset+int64(entry.Size)], values)
 	if err != nil {
@@ -1494,6 +1575,10 @@ func (m *mmapAccessor) readFloatBlock(entry *IndexEntry, values *[]FloatValue) (
 		m.mu.RUnlock()
 		return nil, ErrTSMClosed
 	}
+	if err := m.verifyBlock(entry); err != nil {
+		m.mu.RUnlock()
+		return nil, err
+	}
 
 	a, err := DecodeFloatBlock(m.b[entry.Offset+4:entry.Offset+int64(entry.Size)], values)
 	m.mu.RUnlock()
@@ -1513,6 +1598,10 @@ func (m *mmapAccessor) readIntegerBlock(entry *IndexEntry, values *[]IntegerValu
 		m.mu.RUnlock()
 		return nil, ErrTSMClosed
 	}
+	if err := m.verifyBlock(entry); err != nil {
+		m.mu.RUnlock()
+		return nil, err
+	}
 
 	a, err := DecodeIntegerBlock(m.b[entry.Offset+4:entry.Offset+int64(entry.Size)], values)
 	m.mu.RUnlock()
@@ -1532,6 +1621,10 @@ func (m *mmapAccessor) readUnsignedBlock(entry *IndexEntry, values *[]UnsignedVa
 		m.mu.RUnlock()
 		return nil, ErrTSMClosed
 	}
+	if err := m.verifyBlock(entry); err != nil {
+		m.mu.RUnlock()
+		return nil, err
+	}
 
 	a, err := DecodeUnsignedBlock(m.b[entry.Offset+4:entry.Offset+int64(entry.Size)], values)
 	m.mu.RUnlock()
@@ -1551,6 +1644,10 @@ func (m *mmapAccessor) readStringBlock(entry *IndexEntry, values *[]StringValue)
 		m.mu.RUnlock()
 		return nil, ErrTSMClosed
 	}
+	if err := m.verifyBlock(entry); err != nil {
+		m.mu.RUnlock()
+		return nil, err
+	}
 
 	a, err := DecodeStringBlock(m.b[entry.Offset+4:entry.Offset+int64(entry.Size)], values)
 	m.mu.RUnlock()
@@ -1570,6 +1667,10 @@ func (m *mmapAccessor) readBooleanBlock(entry *IndexEntry, values *[]BooleanValu
 		m.mu.RUnlock()
 		return nil, ErrTSMClosed
 	}
+	if err := m.verifyBlock(entry); err != nil {
+		m.mu.RUnlock()
+		return nil, err
+	}
 
 	a, err := DecodeBooleanBlock(m.b[entry.Offset+4:entry.Offset+int64(entry.Size)], values)
 	m.mu.RUnlock()
@@ -1597,13 +1698,14 @@ func (m *mmapAccessor) readBytes(entry *IndexEntry, b []byte) (uint32, []byte, e
 	return crc, block, nil
 }
 
-// readAll returns all values for a key in all blocks.
-func (m *mmapAccessor) readAll(key []byte) ([]Value, error) {
+// readAll returns all values for a key in all blocks, excluding the blocks
+// failing checksum verification, which are returned separately.
+func (m *mmapAccessor) readAll(key []byte) ([]Value, []errBlockChecksum, error) {
 	m.incAccess()
 
 	blocks := m.index.Entries(key)
 	if len(blocks) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	tombstones := m.index.TombstoneRange(key)
@@ -1614,6 +1716,7 @@ func (m *mmapAccessor) readAll(key []byte) ([]Value, error) {
 	var temp []Value
 	var err error
 	var values []Value
+	var corrupt []errBlockChecksum
 	for _, block := range blocks {
 		var skip bool
 		for _, t := range tombstones {
@@ -1627,12 +1730,15 @@ func (m *mmapAccessor) readAll(key []byte) ([]Value, error) {
 		if skip {
 			continue
 		}
-		//TODO: Validate checksum
+		if err := m.verifyBlock(&block); err != nil {
+			corrupt = append(corrupt, err.(errBlockChecksum))
+			continue
+		}
 		temp = temp[:0]
 		// The +4 is the 4 byte checksum length
 		temp, err = DecodeBlock(m.b[block.Offset+4:block.Offset+int64(block.Size)], temp)
 		if err != nil {
-			return nil, err
+			return nil, corrupt, err
 		}
 
 		// Filter out any values that were deleted
@@ -1643,7 +1749,21 @@ func (m *mmapAccessor) readAll(key []byte) ([]Value, error) {
 		values = append(values, temp...)
 	}
 
-	return values, nil
+	return values, corrupt, nil
+}
+
+// blockStats returns the statistics of the block.  Returns false if the file
+// does not store statistics for the block.
+func (m *mmapAccessor) blockStats(entry *IndexEntry) (BlockStats, bool) {
+	m.incAccess()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.statsEnd == 0 || len(m.b) < m.statsEnd {
+		return BlockStats{}, false
+	}
+	return findBlockStats(m.b[m.statsStart:m.statsEnd], entry.Offset)
 }
 
 func (m *mmapAccessor) path() string {
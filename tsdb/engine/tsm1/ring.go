@@ -2,6 +2,7 @@ package tsm1
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -216,8 +217,32 @@ func (r *ring) split(n int) []storer {
 	return storers
 }
 
+// coldPartitions returns the partitions of the ring ordered from the least to
+// the most written since they were last reset.
+func (r *ring) coldPartitions() []*partition {
+	a := make([]*partition, len(r.partitions))
+	copy(a, r.partitions)
+	sort.SliceStable(a, func(i, j int) bool {
+		return atomic.LoadUint64(&a[i].writes) < atomic.LoadUint64(&a[j].writes)
+	})
+	return a
+}
+
+// detach removes all entries from the partition p and returns them.
+func (r *ring) detach(p *partition) map[string]*entry {
+	store := p.detach()
+	if n := int64(len(store)); atomic.LoadInt64(&r.keysHint) >= n {
+		atomic.AddInt64(&r.keysHint, -n)
+	}
+	return store
+}
+
 // partition provides safe access to a map of series keys to entries.
 type partition struct {
+	// Number of writes since the partition was reset.  It is used to find the
+	// partitions that are spilled to disk first.
+	writes uint64
+
 	mu    sync.RWMutex
 	store map[string]*entry
 }
@@ -235,6 +260,8 @@ func (p *partition) entry(key []byte) *entry {
 // if it does not exist.
 // write is safe for use by multiple goroutines.
 func (p *partition) write(key []byte, values Values) (bool, error) {
+	atomic.AddUint64(&p.writes, 1)
+
 	p.mu.RLock()
 	e := p.store[string(key)]
 	p.mu.RUnlock()
@@ -301,6 +328,17 @@ func (p *partition) reset() {
 	p.mu.Lock()
 	p.store = newStore
 	p.mu.Unlock()
+	atomic.StoreUint64(&p.writes, 0)
+}
+
+// detach replaces the store of the partition with an empty one and returns
+// the previous store.
+func (p *partition) detach() map[string]*entry {
+	p.mu.Lock()
+	store := p.store
+	p.store = make(map[string]*entry)
+	p.mu.Unlock()
+	return store
 }
 
 func (p *partition) count() int {
@@ -1,26 +1,95 @@
 package tsm1
 
-// String encoding uses snappy compression to compress each string.  Each string is
+// String encoding uses snappy or zstd compression to compress each string.  Each string is
 // appended to byte slice prefixed with a variable byte length followed by the string
-// bytes.  The bytes are compressed using snappy compressor and a 1 byte header is used
+// bytes.  The bytes are compressed using the codec of the encoder and a 1 byte header is used
 // to indicate the type of encoding.
 
 import (
 	"encoding/binary"
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 )
 
 // Note: an uncompressed format is not yet implemented.
 
-// stringCompressedSnappy is a compressed encoding using Snappy compression
-const stringCompressedSnappy = 1
+const (
+	// stringCompressedSnappy is a compressed encoding using Snappy compression
+	stringCompressedSnappy = 1
+
+	// stringCompressedZstd is a compressed encoding using Zstandard compression
+	stringCompressedZstd = 2
+)
+
+// StringCodec identifies the compression used for the values of a string block.
+type StringCodec byte
+
+const (
+	// StringCodecSnappy compresses string values using Snappy.  It is fast
+	// and is the default codec.
+	StringCodecSnappy StringCodec = stringCompressedSnappy
+
+	// StringCodecZstd compresses string values using Zstandard.  It is slower
+	// than Snappy but compresses repetitive strings such as log lines better.
+	StringCodecZstd StringCodec = stringCompressedZstd
+)
+
+// ParseStringCodec returns the codec with the given name.
+func ParseStringCodec(name string) (StringCodec, error) {
+	switch name {
+	case "", "snappy":
+		return StringCodecSnappy, nil
+	case "zstd":
+		return StringCodecZstd, nil
+	default:
+		return 0, fmt.Errorf("unknown string codec: %q", name)
+	}
+}
+
+// String returns the name of the codec.
+func (c StringCodec) String() string {
+	switch c {
+	case StringCodecSnappy:
+		return "snappy"
+	case StringCodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("StringCodec(%d)", byte(c))
+	}
+}
+
+// The zstd encoder and decoder are safe for concurrent use and are expensive
+// to create, so they are shared by all string encoders and decoders and WAL
+// segments.
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+func initZstd() {
+	zstdOnce.Do(func() {
+		var err error
+		if zstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1)); err != nil {
+			panic(err)
+		}
+		if zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(runtime.GOMAXPROCS(0))); err != nil {
+			panic(err)
+		}
+	})
+}
 
 // StringEncoder encodes multiple strings into a byte slice.
 type StringEncoder struct {
 	// The encoded bytes
 	bytes []byte
+
+	// The codec used to compress the encoded bytes.
+	codec StringCodec
 }
 
 // NewStringEncoder returns a new StringEncoder with an initial buffer ready to hold sz bytes.
@@ -30,6 +99,12 @@ func NewStringEncoder(sz int) StringEncoder {
 	}
 }
 
+// SetCodec sets the codec used to compress the encoded strings.  The codec is
+// kept when the encoder is reset.
+func (e *StringEncoder) SetCodec(codec StringCodec) {
+	e.codec = codec
+}
+
 // Flush is no-op
 func (e *StringEncoder) Flush() {}
 
@@ -51,10 +126,18 @@ func (e *StringEncoder) Write(s string) {
 
 // Bytes returns a copy of the underlying buffer.
 func (e *StringEncoder) Bytes() ([]byte, error) {
-	// Compress the currently appended bytes using snappy and prefix with
-	// a 1 byte header for future extension
-	data := snappy.Encode(nil, e.bytes)
-	return append([]byte{stringCompressedSnappy << 4}, data...), nil
+	// Compress the currently appended bytes using the codec and prefix with
+	// a 1 byte header identifying the codec
+	switch e.codec {
+	case 0, StringCodecSnappy:
+		data := snappy.Encode(nil, e.bytes)
+		return append([]byte{stringCompressedSnappy << 4}, data...), nil
+	case StringCodecZstd:
+		initZstd()
+		return zstdEncoder.EncodeAll(e.bytes, []byte{stringCompressedZstd << 4}), nil
+	default:
+		return nil, fmt.Errorf("unknown string codec: %d", e.codec)
+	}
 }
 
 // StringDecoder decodes a byte slice into strings.
@@ -68,12 +151,19 @@ type StringDecoder struct {
 // SetBytes initializes the decoder with bytes to read from.
 // This must be called before calling any other method.
 func (e *StringDecoder) SetBytes(b []byte) error {
-	// First byte stores the encoding type, only have snappy format
-	// currently so ignore for now.
+	// First byte stores the encoding type.
 	var data []byte
 	if len(b) > 0 {
 		var err error
-		data, err = snappy.Decode(nil, b[1:])
+		switch b[0] >> 4 {
+		case stringCompressedSnappy:
+			data, err = snappy.Decode(nil, b[1:])
+		case stringCompressedZstd:
+			initZstd()
+			data, err = zstdDecoder.DecodeAll(b[1:], nil)
+		default:
+			err = fmt.Errorf("unknown encoding %v", b[0]>>4)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to decode string block: %v", err.Error())
 		}
@@ -86,6 +86,53 @@ func Test_StringEncoder_Multi_Compressed(t *testing.T) {
 	}
 }
 
+func Test_StringEncoder_Multi_Zstd(t *testing.T) {
+	enc := NewStringEncoder(1024)
+	enc.SetCodec(StringCodecZstd)
+
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = fmt.Sprintf("level=info msg=\"request completed\" id=%d", i)
+		enc.Write(values[i])
+	}
+
+	b, err := enc.Bytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if b[0]>>4 != stringCompressedZstd {
+		t.Fatalf("unexpected encoding: got %v, exp %v", b[0], stringCompressedZstd)
+	}
+
+	// The codec is kept when the encoder is reset.
+	enc.Reset()
+	enc.Write("v1")
+	if rb, err := enc.Bytes(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if rb[0]>>4 != stringCompressedZstd {
+		t.Fatalf("unexpected encoding after reset: got %v, exp %v", rb[0], stringCompressedZstd)
+	}
+
+	var dec StringDecoder
+	if err := dec.SetBytes(b); err != nil {
+		t.Fatalf("unexpected erorr creating string decoder: %v", err)
+	}
+
+	for i, v := range values {
+		if !dec.Next() {
+			t.Fatalf("unexpected next value: got false, exp true")
+		}
+		if v != dec.Read() {
+			t.Fatalf("unexpected value at pos %d: got %v, exp %v", i, dec.Read(), v)
+		}
+	}
+
+	if dec.Next() {
+		t.Fatalf("unexpected next value: got true, exp false")
+	}
+}
+
 func Test_StringEncoder_Quick(t *testing.T) {
 	quick.Check(func(values []string) bool {
 		expected := values
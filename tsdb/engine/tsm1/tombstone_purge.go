@@ -0,0 +1,202 @@
+package tsm1
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/influxdata/influxdb/pkg/limiter"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// PurgeTmpExtension is the extension of the temporary file a TSM file is
+// rewritten to when its tombstones are purged.
+const PurgeTmpExtension = "purge.tmp"
+
+// TombstoneSize returns the total size of the tombstone files of a TSM file.
+func TombstoneSize(f TSMFile) int64 {
+	var n int64
+	for _, ts := range f.TombstoneFiles() {
+		n += int64(ts.Size)
+	}
+	return n
+}
+
+// PurgeTombstones rewrites the TSM file at path without the data deleted by
+// its tombstones and removes the tombstones.  Blocks not covered by a
+// tombstone are copied as is.  The file must not be in use by a running
+// engine.  It returns the number of blocks that were rewritten or dropped.
+func PurgeTombstones(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+
+	if !r.HasTombstones() {
+		return 0, r.Close()
+	}
+
+	var tombstones []string
+	for _, ts := range r.TombstoneFiles() {
+		tombstones = append(tombstones, ts.Path)
+	}
+
+	tmp := fmt.Sprintf("%s.%s", path, PurgeTmpExtension)
+	n, err := writePurgedFile(tmp, r, 0, nil, false, nil)
+	if closeErr := r.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == ErrNoValues {
+		// Everything in the file was deleted.
+		tombstones = append(tombstones, path)
+	} else if err != nil {
+		os.Remove(tmp)
+		return 0, err
+	} else if err := os.Rename(tmp, path); err != nil {
+		return 0, err
+	}
+
+	for _, ts := range tombstones {
+		if err := os.Remove(ts); err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// writePurgedFile writes the blocks of r without the data deleted by its
+// tombstones to a new TSM file at path.  It returns ErrNoValues and removes
+// the file if nothing is left.
+func writePurgedFile(path string, r *TSMReader, codec StringCodec, rate limiter.Rate, blockStats bool, interrupt chan struct{}) (int, error) {
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return 0, errCompactionInProgress{err: err}
+	}
+
+	var w io.Writer = fd
+	if rate != nil {
+		w = limiter.NewWriterWithRate(fd, rate)
+	}
+
+	tw, err := NewTSMWriter(w, WithBlockStatistics(blockStats))
+	if err != nil {
+		fd.Close()
+		return 0, err
+	}
+
+	n, err := purgeTombstones(r, tw, codec, interrupt)
+	if err == nil {
+		err = tw.WriteIndex()
+	}
+	if closeErr := tw.Close(); err == nil {
+		err = closeErr
+	}
+	if err == ErrNoValues {
+		os.Remove(path)
+		return n, err
+	} else if err != nil {
+		os.Remove(path)
+		return 0, err
+	}
+	return n, nil
+}
+
+// purgeTombstones writes the blocks of r to w.  Blocks overlapping a deleted
+// range are decoded and written without the deleted values, other blocks are
+// copied.  It returns the number of blocks that were rewritten or dropped.  A
+// block failing checksum verification quarantines r and fails the purge, so
+// that it is neither dropped nor copied with a new checksum.
+func purgeTombstones(r *TSMReader, w TSMWriter, codec StringCodec, interrupt chan struct{}) (int, error) {
+	var (
+		entries []IndexEntry
+		values  []Value
+		n       int
+	)
+
+	for i := 0; i < r.KeyCount(); i++ {
+		select {
+		case <-interrupt:
+			return 0, errCompactionAborted{}
+		default:
+		}
+
+		key, _, _ := r.Key(i, &entries)
+		if len(key) == 0 {
+			continue
+		}
+
+		tombstones := r.TombstoneRange(key)
+		for j := range entries {
+			e := &entries[j]
+			checksum, b, err := r.ReadBytes(e, nil)
+			if err != nil {
+				return 0, err
+			}
+			if !VerifyBlockChecksum(checksum, b) {
+				err := errBlockChecksum{offset: e.Offset, size: e.Size}
+				r.quarantine(err)
+				return 0, err
+			}
+
+			if !overlapsTimeRanges(tombstones, e.MinTime, e.MaxTime) {
+				if err := w.WriteBlock(key, e.MinTime, e.MaxTime, b); err != nil {
+					return 0, err
+				}
+				continue
+			}
+
+			n++
+			v, err := DecodeBlock(b, values[:0])
+			if err != nil {
+				return 0, err
+			}
+			values = v
+
+			remaining := Values(v)
+			for _, t := range tombstones {
+				remaining = remaining.Exclude(t.Min, t.Max)
+			}
+
+			for len(remaining) > 0 {
+				end := len(remaining)
+				if end > tsdb.DefaultMaxPointsPerBlock {
+					end = tsdb.DefaultMaxPointsPerBlock
+				}
+
+				var b []byte
+				if _, ok := remaining[0].(StringValue); ok && codec != 0 {
+					b, err = encodeStringBlockCodec(nil, remaining[:end], codec)
+				} else {
+					b, err = remaining[:end].Encode(nil)
+				}
+				if err != nil {
+					return 0, err
+				}
+
+				minTime, maxTime := remaining[0].UnixNano(), remaining[end-1].UnixNano()
+				if err := w.WriteBlock(key, minTime, maxTime, b); err != nil {
+					return 0, err
+				}
+				remaining = remaining[end:]
+			}
+		}
+	}
+	return n, nil
+}
+
+// overlapsTimeRanges returns true if any of the ranges overlaps min and max.
+func overlapsTimeRanges(ranges []TimeRange, min, max int64) bool {
+	for _, t := range ranges {
+		if t.Overlaps(min, max) {
+			return true
+		}
+	}
+	return false
+}
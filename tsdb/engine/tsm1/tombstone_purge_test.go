@@ -0,0 +1,177 @@
+package tsm1_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+func TestPurgeTombstones(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	a1 := tsm1.NewValue(1, 1.1)
+	a2 := tsm1.NewValue(2, 1.2)
+	a3 := tsm1.NewValue(3, 1.3)
+	b1 := tsm1.NewValue(1, 2.1)
+	c1 := tsm1.NewValue(1, 3.1)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{a1, a2, a3},
+		"cpu,host=B#!~#value": []tsm1.Value{b1},
+		"cpu,host=C#!~#value": []tsm1.Value{c1},
+	})
+
+	ts := tsm1.Tombstoner{Path: f1}
+	ts.AddRange([][]byte{[]byte("cpu,host=A#!~#value")}, 2, 2)
+	ts.Add([][]byte{[]byte("cpu,host=B#!~#value")})
+	if err := ts.Flush(); err != nil {
+		t.Fatalf("unexpected error flushing tombstone: %v", err)
+	}
+
+	n, err := tsm1.PurgeTombstones(f1)
+	if err != nil {
+		t.Fatalf("unexpected error purging tombstones: %v", err)
+	}
+	if got, exp := n, 1; got != exp {
+		t.Fatalf("rewritten blocks mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := MustOpenTSMReader(f1)
+	defer r.Close()
+
+	if r.HasTombstones() {
+		t.Fatal("expected tombstones to be removed")
+	}
+	if got, exp := r.KeyCount(), 2; got != exp {
+		t.Fatalf("keys length mismatch: got %v, exp %v", got, exp)
+	}
+
+	for _, p := range []struct {
+		key    string
+		points []tsm1.Value
+	}{
+		{"cpu,host=A#!~#value", []tsm1.Value{a1, a3}},
+		{"cpu,host=C#!~#value", []tsm1.Value{c1}},
+	} {
+		values, err := r.ReadAll([]byte(p.key))
+		if err != nil {
+			t.Fatalf("unexpected error reading %s: %v", p.key, err)
+		}
+		if !reflect.DeepEqual(values, p.points) {
+			t.Fatalf("values mismatch for %s: got %v, exp %v", p.key, values, p.points)
+		}
+	}
+}
+
+// Ensure a purge fails on a corrupt block and quarantines the file instead
+// of dropping the block.
+func TestPurgeTombstones_CorruptBlock(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(1, 1.1), tsm1.NewValue(2, 1.2)},
+		"cpu,host=B#!~#value": []tsm1.Value{tsm1.NewValue(1, 2.1)},
+	})
+	corruptBlock(t, f1)
+
+	ts := tsm1.Tombstoner{Path: f1}
+	ts.AddRange([][]byte{[]byte("cpu,host=A#!~#value")}, 2, 2)
+	if err := ts.Flush(); err != nil {
+		t.Fatalf("unexpected error flushing tombstone: %v", err)
+	}
+
+	if _, err := tsm1.PurgeTombstones(f1); err == nil {
+		t.Fatal("expected error purging tombstones of a corrupt block")
+	}
+
+	r := MustOpenTSMReader(f1)
+	defer r.Close()
+
+	if !r.HasTombstones() {
+		t.Fatal("expected tombstones to be kept")
+	}
+	if !r.Quarantined() {
+		t.Fatal("expected file to be quarantined")
+	}
+	if got, exp := r.KeyCount(), 2; got != exp {
+		t.Fatalf("keys length mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+func TestFileStore_ReplacePurged(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	a1 := tsm1.NewValue(1, 1.1)
+	a2 := tsm1.NewValue(2, 1.2)
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{a1, a2},
+	})
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{
+		"cpu,host=B#!~#value": []tsm1.Value{a1},
+	})
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		t.Fatalf("unexpected error opening file store: %v", err)
+	}
+	defer fs.Close()
+
+	if err := fs.DeleteRange([][]byte{[]byte("cpu,host=A#!~#value")}, 1, 1); err != nil {
+		t.Fatalf("unexpected error deleting range: %v", err)
+	}
+	if err := fs.Delete([][]byte{[]byte("cpu,host=B#!~#value")}); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: fs,
+	}
+	compactor.Open()
+
+	tmp, n, err := compactor.PurgeTombstones(f1)
+	if err != nil {
+		t.Fatalf("unexpected error purging tombstones: %v", err)
+	} else if got, exp := n, 1; got != exp {
+		t.Fatalf("rewritten blocks mismatch: got %v, exp %v", got, exp)
+	}
+	if err := fs.ReplacePurged(f1, tmp); err != nil {
+		t.Fatalf("unexpected error replacing file: %v", err)
+	}
+
+	// All the data of the second file was deleted.
+	tmp, _, err = compactor.PurgeTombstones(f2)
+	if err != nil {
+		t.Fatalf("unexpected error purging tombstones: %v", err)
+	} else if tmp != "" {
+		t.Fatalf("expected no file, got %s", tmp)
+	}
+	if err := fs.ReplacePurged(f2, tmp); err != nil {
+		t.Fatalf("unexpected error replacing file: %v", err)
+	}
+
+	if got, exp := fs.Count(), 1; got != exp {
+		t.Fatalf("file count mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := fs.TSMReader(f1)
+	if r == nil {
+		t.Fatalf("file %s not found", f1)
+	}
+	if r.HasTombstones() {
+		t.Fatal("expected tombstones to be removed")
+	}
+
+	values, err := r.ReadAll([]byte("cpu,host=A#!~#value"))
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if exp := []tsm1.Value{a2}; !reflect.DeepEqual(values, exp) {
+		t.Fatalf("values mismatch: got %v, exp %v", values, exp)
+	}
+}
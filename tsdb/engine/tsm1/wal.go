@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"math"
 	"os"
@@ -34,9 +35,21 @@ const (
 	// WALFilePrefix is the prefix on all wal segment files.
 	WALFilePrefix = "_"
 
+	// bulkLoadWALDirectory is the directory of a shard's WAL holding the
+	// segments of the buffered bulk loaded values.
+	bulkLoadWALDirectory = "bulk"
+
 	// walEncodeBufSize is the size of the wal entry encoding buffer
 	walEncodeBufSize = 4 * 1024 * 1024
 
+	// walFrameSize is the size of the uncompressed entries at which a zstd
+	// frame is written even if no fsync is pending.
+	walFrameSize = 1024 * 1024
+
+	// walZstdFrameType is written in place of an entry type before a frame of
+	// zstd compressed entries.
+	walZstdFrameType = 0x80
+
 	float64EntryType  = 1
 	integerEntryType  = 2
 	booleanEntryType  = 3
@@ -58,6 +71,31 @@ const (
 	DeleteRangeWALEntryType WalEntryType = 0x03
 )
 
+// WALCompression is the compression format of the entries of a WAL segment.
+type WALCompression byte
+
+const (
+	// WALCompressionSnappy compresses each entry individually with snappy.
+	WALCompressionSnappy WALCompression = iota
+
+	// WALCompressionZstd compresses the entries written between fsyncs
+	// together into zstd frames protected by a CRC-32 checksum.
+	WALCompressionZstd
+)
+
+// ParseWALCompression returns the WALCompression named name.  An empty name
+// returns WALCompressionSnappy.
+func ParseWALCompression(name string) (WALCompression, error) {
+	switch name {
+	case "", "snappy":
+		return WALCompressionSnappy, nil
+	case "zstd":
+		return WALCompressionZstd, nil
+	default:
+		return 0, fmt.Errorf("unknown wal compression: %q", name)
+	}
+}
+
 var (
 	// ErrWALClosed is returned when attempting to write to a closed WAL file.
 	ErrWALClosed = fmt.Errorf("WAL closed")
@@ -103,6 +141,14 @@ type WAL struct {
 	// is opened if a non-default value is required.
 	syncDelay time.Duration
 
+	// syncObserver, if set, is called with the duration of every fsync.  This
+	// must be set before the WAL is opened.
+	syncObserver func(time.Duration)
+
+	// compression is the format new segments are written with.  This must be
+	// set before the WAL is opened.
+	compression WALCompression
+
 	// WALOutput is the writer used by the logger.
 	logger       *zap.Logger // Logger to be used for important messages
 	traceLogger  *zap.Logger // Logger to be used when trace-logging is on.
@@ -220,7 +266,7 @@ func (l *WAL) Open() error {
 			if _, err := fd.Seek(0, io.SeekEnd); err != nil {
 				return err
 			}
-			l.currentSegmentWriter = NewWALSegmentWriter(fd)
+			l.currentSegmentWriter = newWALSegmentWriter(fd, l.compression)
 
 			// Reset the current segment size stat
 			atomic.StoreInt64(&l.stats.CurrentBytes, stat.Size())
@@ -297,7 +343,14 @@ func (l *WAL) scheduleSync() {
 // sync fsyncs the current wal segments and notifies any waiters.  Callers must ensure
 // a write lock on the WAL is obtained before calling sync.
 func (l *WAL) sync() {
+	start := time.Now()
 	err := l.currentSegmentWriter.sync()
+	if l.syncObserver != nil {
+		l.syncObserver(time.Since(start))
+	}
+
+	// Entries of zstd frames are only written when the frame is flushed.
+	atomic.StoreInt64(&l.stats.CurrentBytes, int64(l.currentSegmentWriter.size))
 	for len(l.syncWaiters) > 0 {
 		errC := <-l.syncWaiters
 		errC <- err
@@ -406,10 +459,16 @@ func (l *WAL) writeToLog(entry WALEntry) (int, error) {
 		return -1, err
 	}
 
-	encBuf := bytesPool.Get(snappy.MaxEncodedLen(len(b)))
-
-	compressed := snappy.Encode(encBuf, b)
-	bytesPool.Put(bytes)
+	// Entries of zstd frames are compressed together when the frame is
+	// written, so they are only copied into the frame.
+	var compressed, encBuf []byte
+	if l.compression == WALCompressionSnappy {
+		encBuf = bytesPool.Get(snappy.MaxEncodedLen(len(b)))
+		compressed = snappy.Encode(encBuf, b)
+		bytesPool.Put(bytes)
+	} else {
+		encBuf, compressed = bytes, b
+	}
 
 	syncErr := make(chan error)
 
@@ -430,7 +489,13 @@ func (l *WAL) writeToLog(entry WALEntry) (int, error) {
 		}
 
 		// write and sync
-		if err := l.currentSegmentWriter.Write(entry.Type(), compressed); err != nil {
+		var err error
+		if l.compression == WALCompressionSnappy {
+			err = l.currentSegmentWriter.Write(entry.Type(), compressed)
+		} else {
+			err = l.currentSegmentWriter.writeFrameEntry(entry.Type(), compressed)
+		}
+		if err != nil {
 			return -1, fmt.Errorf("error writing WAL entry: %v", err)
 		}
 
@@ -479,7 +544,7 @@ func (l *WAL) rollSegment() error {
 func (l *WAL) CloseSegment() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	if l.currentSegmentWriter == nil || l.currentSegmentWriter.size > 0 {
+	if l.currentSegmentWriter == nil || !l.currentSegmentWriter.empty() {
 		if err := l.newSegmentFile(); err != nil {
 			// A drop database or RP call could trigger this error if writes were in-flight
 			// when the drop statement executes.
@@ -572,7 +637,7 @@ func (l *WAL) newSegmentFile() error {
 	if err != nil {
 		return err
 	}
-	l.currentSegmentWriter = NewWALSegmentWriter(fd)
+	l.currentSegmentWriter = newWALSegmentWriter(fd, l.compression)
 
 	// Reset the current segment size stat
 	atomic.StoreInt64(&l.stats.CurrentBytes, 0)
@@ -1029,10 +1094,22 @@ type WALSegmentWriter struct {
 	bw   *bufio.Writer
 	w    io.WriteCloser
 	size int
+
+	// frame holds the uncompressed entries of the zstd frame that has not
+	// been written yet, and buf the compressed frame.
+	frame []byte
+	buf   []byte
 }
 
 // NewWALSegmentWriter returns a new WALSegmentWriter writing to w.
 func NewWALSegmentWriter(w io.WriteCloser) *WALSegmentWriter {
+	return newWALSegmentWriter(w, WALCompressionSnappy)
+}
+
+func newWALSegmentWriter(w io.WriteCloser, compression WALCompression) *WALSegmentWriter {
+	if compression == WALCompressionZstd {
+		initZstd()
+	}
 	return &WALSegmentWriter{
 		bw: bufio.NewWriterSize(w, 16*1024),
 		w:  w,
@@ -1065,10 +1142,61 @@ func (w *WALSegmentWriter) Write(entryType WalEntryType, compressed []byte) erro
 	return nil
 }
 
+// writeFrameEntry adds entryType and the buffer containing uncompressed entry
+// data to the pending zstd frame.  The frame is written when it is flushed or
+// grows too large.
+func (w *WALSegmentWriter) writeFrameEntry(entryType WalEntryType, b []byte) error {
+	var buf [5]byte
+	buf[0] = byte(entryType)
+	binary.BigEndian.PutUint32(buf[1:5], uint32(len(b)))
+
+	w.frame = append(w.frame, buf[:]...)
+	w.frame = append(w.frame, b...)
+
+	if len(w.frame) >= walFrameSize {
+		return w.flushFrame()
+	}
+	return nil
+}
+
+// flushFrame compresses the pending entries and writes them as a frame.  A
+// frame is made of walZstdFrameType, the length of the compressed entries, the
+// CRC-32 checksum of the compressed entries and the compressed entries.
+func (w *WALSegmentWriter) flushFrame() error {
+	if len(w.frame) == 0 {
+		return nil
+	}
+
+	w.buf = zstdEncoder.EncodeAll(w.frame, w.buf[:0])
+	w.frame = w.frame[:0]
+
+	var buf [9]byte
+	buf[0] = walZstdFrameType
+	binary.BigEndian.PutUint32(buf[1:5], uint32(len(w.buf)))
+	binary.BigEndian.PutUint32(buf[5:9], crc32.ChecksumIEEE(w.buf))
+
+	if _, err := w.bw.Write(buf[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.bw.Write(w.buf); err != nil {
+		return err
+	}
+
+	w.size += len(buf) + len(w.buf)
+
+	return nil
+}
+
+// empty returns true if no entries have been written to the segment.
+func (w *WALSegmentWriter) empty() bool {
+	return w.size == 0 && len(w.frame) == 0
+}
+
 // Sync flushes the file systems in-memory copy of recently written data to disk,
 // if w is writing to an os.File.
 func (w *WALSegmentWriter) sync() error {
-	if err := w.bw.Flush(); err != nil {
+	if err := w.Flush(); err != nil {
 		return err
 	}
 
@@ -1079,6 +1207,9 @@ func (w *WALSegmentWriter) sync() error {
 }
 
 func (w *WALSegmentWriter) Flush() error {
+	if err := w.flushFrame(); err != nil {
+		return err
+	}
 	return w.bw.Flush()
 }
 
@@ -1089,13 +1220,19 @@ func (w *WALSegmentWriter) close() error {
 	return w.w.Close()
 }
 
-// WALSegmentReader reads WAL segments.
+// WALSegmentReader reads WAL segments.  Entries compressed individually with
+// snappy and frames of zstd compressed entries can be read from the same
+// segment.
 type WALSegmentReader struct {
 	rc    io.ReadCloser
 	r     *bufio.Reader
 	entry WALEntry
 	n     int64
 	err   error
+
+	// frame holds the entries of the last frame read that have not been
+	// returned yet.
+	frame []WALEntry
 }
 
 // NewWALSegmentReader returns a new WALSegmentReader reading from r.
@@ -1112,10 +1249,17 @@ func (r *WALSegmentReader) Reset(rc io.ReadCloser) {
 	r.entry = nil
 	r.n = 0
 	r.err = nil
+	r.frame = nil
 }
 
 // Next indicates if there is a value to read.
 func (r *WALSegmentReader) Next() bool {
+	// Return the remaining entries of the last frame first.
+	if len(r.frame) > 0 {
+		r.entry, r.frame = r.frame[0], r.frame[1:]
+		return true
+	}
+
 	var nReadOK int
 
 	// read the type and the length of the entry
@@ -1136,6 +1280,11 @@ func (r *WALSegmentReader) Next() bool {
 	entryType := lv[0]
 	length := binary.BigEndian.Uint32(lv[1:5])
 
+	if entryType == walZstdFrameType {
+		r.err = r.readFrame(length, nReadOK)
+		return true
+	}
+
 	b := *(getBuf(int(length)))
 	defer putBuf(&b)
 
@@ -1162,17 +1311,8 @@ func (r *WALSegmentReader) Next() bool {
 	}
 
 	// and marshal it and send it to the cache
-	switch WalEntryType(entryType) {
-	case WriteWALEntryType:
-		r.entry = &WriteWALEntry{
-			Values: make(map[string][]Value),
-		}
-	case DeleteWALEntryType:
-		r.entry = &DeleteWALEntry{}
-	case DeleteRangeWALEntryType:
-		r.entry = &DeleteRangeWALEntry{}
-	default:
-		r.err = fmt.Errorf("unknown wal entry type: %v", entryType)
+	r.entry, r.err = newWALEntry(WalEntryType(entryType))
+	if r.err != nil {
 		return true
 	}
 	r.err = r.entry.UnmarshalBinary(data)
@@ -1184,6 +1324,82 @@ func (r *WALSegmentReader) Next() bool {
 	return true
 }
 
+// readFrame reads a zstd frame of length compressed bytes and decodes all of
+// its entries.  The frame only counts as read if all of its entries are valid.
+func (r *WALSegmentReader) readFrame(length uint32, nReadOK int) error {
+	var checksum [4]byte
+	n, err := io.ReadFull(r.r, checksum[:])
+	if err != nil {
+		return err
+	}
+	nReadOK += n
+
+	b := *(getBuf(int(length)))
+	defer putBuf(&b)
+
+	n, err = io.ReadFull(r.r, b[:length])
+	if err != nil {
+		return err
+	}
+	nReadOK += n
+
+	if crc32.ChecksumIEEE(b[:length]) != binary.BigEndian.Uint32(checksum[:]) {
+		return ErrWALCorrupt
+	}
+
+	// Entries may reference the decompressed data, so it is not pooled.
+	initZstd()
+	data, err := zstdDecoder.DecodeAll(b[:length], nil)
+	if err != nil {
+		return err
+	}
+
+	var entries []WALEntry
+	for len(data) > 0 {
+		if len(data) < 5 {
+			return ErrWALCorrupt
+		}
+		sz := int(binary.BigEndian.Uint32(data[1:5]))
+		if len(data) < 5+sz {
+			return ErrWALCorrupt
+		}
+
+		entry, err := newWALEntry(WalEntryType(data[0]))
+		if err != nil {
+			return err
+		}
+		if err := entry.UnmarshalBinary(data[5 : 5+sz]); err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		data = data[5+sz:]
+	}
+	if len(entries) == 0 {
+		return ErrWALCorrupt
+	}
+
+	// Read and decode of this frame was successful.
+	r.n += int64(nReadOK)
+	r.entry, r.frame = entries[0], entries[1:]
+	return nil
+}
+
+// newWALEntry returns an empty entry of type entryType.
+func newWALEntry(entryType WalEntryType) (WALEntry, error) {
+	switch entryType {
+	case WriteWALEntryType:
+		return &WriteWALEntry{
+			Values: make(map[string][]Value),
+		}, nil
+	case DeleteWALEntryType:
+		return &DeleteWALEntry{}, nil
+	case DeleteRangeWALEntryType:
+		return &DeleteRangeWALEntry{}, nil
+	default:
+		return nil, fmt.Errorf("unknown wal entry type: %v", entryType)
+	}
+}
+
 // Read returns the next entry in the reader.
 func (r *WALSegmentReader) Read() (WALEntry, error) {
 	if r.err != nil {
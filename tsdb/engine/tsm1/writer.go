@@ -60,6 +60,29 @@ The last section is the footer that stores the offset of the start of the index.
 │Index Ofs│
 │ 8 bytes │
 └─────────┘
+
+Version 2 files store statistics of the values of each block between the index
+and the footer.  Each entry holds the offset of the block it describes, the
+number of values in the block and the min, max and sum of the values.  The
+min, max and sum are the bits of a float64, int64 or uint64 depending on the
+type of the block and are zero for string and boolean blocks.  Entries are
+ordered by the offset of their block.
+
+┌───────────────────────────────────────────────────┐
+│                    Statistics                     │
+├─────────┬─────────┬─────────┬─────────┬─────────┬─┤
+│ Offset  │  Count  │   Min   │   Max   │   Sum   │…│
+│ 8 bytes │ 4 bytes │ 8 bytes │ 8 bytes │ 8 bytes │ │
+└─────────┴─────────┴─────────┴─────────┴─────────┴─┘
+
+The footer of a version 2 file also stores the offset of the statistics.
+
+┌───────────────────┐
+│      Footer       │
+├─────────┬─────────┤
+│Stats Ofs│Index Ofs│
+│ 8 bytes │ 8 bytes │
+└─────────┴─────────┘
 */
 
 import (
@@ -83,6 +106,10 @@ const (
 	// Version indicates the version of the TSM file format.
 	Version byte = 1
 
+	// VersionBlockStats is the version of the TSM file format that stores
+	// statistics of the values of each block.
+	VersionBlockStats byte = 2
+
 	// Size in bytes of an index entry
 	indexEntrySize = 28
 
@@ -548,17 +575,40 @@ type tsmWriter struct {
 
 	// The bytes written count of when we last fsync'd
 	lastSync int64
+
+	// stats holds the encoded statistics of each block if they are written.
+	stats *blockStatsWriter
+}
+
+// TSMWriterOption is a functional option to modify a TSMWriter.
+type TSMWriterOption func(t *tsmWriter)
+
+// WithBlockStatistics sets whether statistics of the values of each block are
+// written to the file.  Files with statistics can only be read by versions
+// that support VersionBlockStats.
+func WithBlockStatistics(enabled bool) TSMWriterOption {
+	return func(t *tsmWriter) {
+		if enabled {
+			t.stats = &blockStatsWriter{}
+		} else {
+			t.stats = nil
+		}
+	}
 }
 
 // NewTSMWriter returns a new TSMWriter writing to w.
-func NewTSMWriter(w io.Writer) (TSMWriter, error) {
+func NewTSMWriter(w io.Writer, options ...TSMWriterOption) (TSMWriter, error) {
 	index := NewIndexWriter()
-	return &tsmWriter{wrapped: w, w: bufio.NewWriterSize(w, 1024*1024), index: index}, nil
+	t := &tsmWriter{wrapped: w, w: bufio.NewWriterSize(w, 1024*1024), index: index}
+	for _, option := range options {
+		option(t)
+	}
+	return t, nil
 }
 
 // NewTSMWriterWithDiskBuffer returns a new TSMWriter writing to w and will use a disk
 // based buffer for the TSM index if possible.
-func NewTSMWriterWithDiskBuffer(w io.Writer) (TSMWriter, error) {
+func NewTSMWriterWithDiskBuffer(w io.Writer, options ...TSMWriterOption) (TSMWriter, error) {
 	var index IndexWriter
 	// Make sure is a File so we can write the temp index alongside it.
 	if fw, ok := w.(syncer); ok {
@@ -572,13 +622,20 @@ func NewTSMWriterWithDiskBuffer(w io.Writer) (TSMWriter, error) {
 		index = NewIndexWriter()
 	}
 
-	return &tsmWriter{wrapped: w, w: bufio.NewWriterSize(w, 1024*1024), index: index}, nil
+	t := &tsmWriter{wrapped: w, w: bufio.NewWriterSize(w, 1024*1024), index: index}
+	for _, option := range options {
+		option(t)
+	}
+	return t, nil
 }
 
 func (t *tsmWriter) writeHeader() error {
 	var buf [5]byte
 	binary.BigEndian.PutUint32(buf[0:4], MagicNumber)
 	buf[4] = Version
+	if t.stats != nil {
+		buf[4] = VersionBlockStats
+	}
 
 	n, err := t.w.Write(buf[:])
 	if err != nil {
@@ -632,6 +689,13 @@ func (t *tsmWriter) Write(key []byte, values Values) error {
 
 	// Record this block in index
 	t.index.Add(key, blockType, values[0].UnixNano(), values[len(values)-1].UnixNano(), t.n, uint32(n))
+	if t.stats != nil {
+		stats, err := t.stats.decode(block)
+		if err != nil {
+			return err
+		}
+		t.stats.add(t.n, stats)
+	}
 
 	// Increment file position pointer
 	t.n += int64(n)
@@ -684,6 +748,13 @@ func (t *tsmWriter) WriteBlock(key []byte, minTime, maxTime int64, block []byte)
 
 	// Record this block in index
 	t.index.Add(key, blockType, minTime, maxTime, t.n, uint32(n))
+	if t.stats != nil {
+		stats, err := t.stats.decode(block)
+		if err != nil {
+			return err
+		}
+		t.stats.add(t.n, stats)
+	}
 
 	// Increment file position pointer (checksum + block len)
 	t.n += int64(n)
@@ -719,15 +790,30 @@ func (t *tsmWriter) WriteIndex() error {
 	}
 
 	// Write the index
-	if _, err := t.index.WriteTo(t.w); err != nil {
+	n, err := t.index.WriteTo(t.w)
+	if err != nil {
 		return err
 	}
 
+	// Write the block statistics and their position
+	if t.stats != nil {
+		statsPos := indexPos + n
+		if _, err := t.w.Write(t.stats.buf); err != nil {
+			return err
+		}
+
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(statsPos))
+		if _, err := t.w.Write(buf[:]); err != nil {
+			return err
+		}
+	}
+
 	var buf [8]byte
 	binary.BigEndian.PutUint64(buf[:], uint64(indexPos))
 
 	// Write the index index position
-	_, err := t.w.Write(buf[:])
+	_, err = t.w.Write(buf[:])
 	return err
 }
 
@@ -780,31 +866,34 @@ func (t *tsmWriter) Remove() error {
 }
 
 func (t *tsmWriter) Size() uint32 {
+	if t.stats != nil {
+		return uint32(t.n) + t.index.Size() + uint32(len(t.stats.buf))
+	}
 	return uint32(t.n) + t.index.Size()
 }
 
 // verifyVersion verifies that the reader's bytes are a TSM byte
-// stream of the correct version (1)
-func verifyVersion(r io.ReadSeeker) error {
+// stream of a supported version (1 or 2) and returns the version.
+func verifyVersion(r io.ReadSeeker) (byte, error) {
 	_, err := r.Seek(0, 0)
 	if err != nil {
-		return fmt.Errorf("init: failed to seek: %v", err)
+		return 0, fmt.Errorf("init: failed to seek: %v", err)
 	}
 	var b [4]byte
 	_, err = io.ReadFull(r, b[:])
 	if err != nil {
-		return fmt.Errorf("init: error reading magic number of file: %v", err)
+		return 0, fmt.Errorf("init: error reading magic number of file: %v", err)
 	}
 	if binary.BigEndian.Uint32(b[:]) != MagicNumber {
-		return fmt.Errorf("can only read from tsm file")
+		return 0, fmt.Errorf("can only read from tsm file")
 	}
 	_, err = io.ReadFull(r, b[:1])
 	if err != nil {
-		return fmt.Errorf("init: error reading version: %v", err)
+		return 0, fmt.Errorf("init: error reading version: %v", err)
 	}
-	if b[0] != Version {
-		return fmt.Errorf("init: file is version %b. expected %b", b[0], Version)
+	if b[0] != Version && b[0] != VersionBlockStats {
+		return 0, fmt.Errorf("init: file is version %b. expected %b or %b", b[0], Version, VersionBlockStats)
 	}
 
-	return nil
+	return b[0], nil
 }
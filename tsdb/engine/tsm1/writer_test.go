@@ -583,3 +583,118 @@ func TestTSMWriter_Write_MaxKey(t *testing.T) {
 		t.Fatalf("expected max key length error writing key: %v", err)
 	}
 }
+
+func TestTSMWriter_Write_BlockStatistics(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+
+	w, err := tsm1.NewTSMWriter(f, tsm1.WithBlockStatistics(true))
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+
+	floats := []tsm1.Value{tsm1.NewValue(0, 1.5), tsm1.NewValue(1, -2.0), tsm1.NewValue(2, 4.0)}
+	if err := w.Write([]byte("cpu"), floats); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	integers := []tsm1.Value{tsm1.NewValue(0, int64(3)), tsm1.NewValue(1, int64(-7))}
+	block, err := tsm1.Values(integers).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error encoding: %v", err)
+	}
+	if err := w.WriteBlock([]byte("mem"), 0, 1, block); err != nil {
+		t.Fatalf("unexpected error writing block: %v", err)
+	}
+
+	strs := []tsm1.Value{tsm1.NewValue(0, "a")}
+	if err := w.Write([]byte("status"), strs); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	fd, err := os.Open(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(fd)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	stats := func(key string) tsm1.BlockStats {
+		entries := r.Entries([]byte(key))
+		if len(entries) != 1 {
+			t.Fatalf("unexpected number of entries for %s: %d", key, len(entries))
+		}
+		s, ok := r.BlockStats(&entries[0])
+		if !ok {
+			t.Fatalf("expected statistics for %s", key)
+		}
+		return s
+	}
+
+	if s := stats("cpu"); s.Count != 3 || s.FloatMin() != -2.0 || s.FloatMax() != 4.0 || s.FloatSum() != 3.5 {
+		t.Fatalf("unexpected float statistics: %+v", s)
+	}
+	if s := stats("mem"); s.Count != 2 || s.IntegerMin() != -7 || s.IntegerMax() != 3 || s.IntegerSum() != -4 {
+		t.Fatalf("unexpected integer statistics: %+v", s)
+	}
+	if s := stats("status"); s.Count != 1 {
+		t.Fatalf("unexpected string statistics: %+v", s)
+	}
+
+	// The values are still readable.
+	readValues, err := r.ReadAll([]byte("cpu"))
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if len(readValues) != len(floats) {
+		t.Fatalf("read values length mismatch: got %v, exp %v", len(readValues), len(floats))
+	}
+}
+
+func TestTSMWriter_Write_NoBlockStatistics(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+	if err := w.Write([]byte("cpu"), []tsm1.Value{tsm1.NewValue(0, 1.0)}); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	fd, err := os.Open(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(fd)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	entries := r.Entries([]byte("cpu"))
+	if _, ok := r.BlockStats(&entries[0]); ok {
+		t.Fatal("expected no statistics")
+	}
+}
@@ -68,6 +68,13 @@ type Index interface {
 	Rebuild()
 }
 
+// TagValueMatcher is implemented by indexes which can narrow the values of a
+// tag key that may match a regular expression, e.g. with a trigram index.
+// The returned values are candidates that still have to be matched.
+type TagValueMatcher interface {
+	MatchTagValueIterator(name, key []byte, re *regexp.Regexp) (TagValueIterator, error)
+}
+
 // SeriesElem represents a generic series element.
 type SeriesElem interface {
 	Name() []byte
@@ -1546,6 +1553,180 @@ func (is IndexSet) tagValueIterator(name, key []byte) (TagValueIterator, error)
 	return MergeTagValueIterators(a...), nil
 }
 
+// matchTagValueIterator returns an iterator over the values of a tag key that
+// may match re. Indexes implementing TagValueMatcher narrow their values
+// before they are matched; the values of other indexes are all returned. It
+// guarantees to never take any locks on the underlying series file.
+func (is IndexSet) matchTagValueIterator(name, key []byte, re *regexp.Regexp) (TagValueIterator, error) {
+	a := make([]TagValueIterator, 0, len(is.Indexes))
+	for _, idx := range is.Indexes {
+		var itr TagValueIterator
+		var err error
+		if m, ok := idx.(TagValueMatcher); ok {
+			itr, err = m.MatchTagValueIterator(name, key, re)
+		} else {
+			itr, err = idx.TagValueIterator(name, key)
+		}
+
+		if err != nil {
+			TagValueIterators(a).Close()
+			return nil, err
+		} else if itr != nil {
+			a = append(a, itr)
+		}
+	}
+	return MergeTagValueIterators(a...), nil
+}
+
+// measurementSeriesIDSetByExpr returns the set of series for a measurement
+// that match expr. A nil set is returned if expr is nil, indicating that
+// all series match.
+//
+// measurementSeriesIDSetByExpr guarantees to never take any locks on the
+// series file.
+func (is IndexSet) measurementSeriesIDSetByExpr(name []byte, expr influxql.Expr) (*SeriesIDSet, error) {
+	if expr == nil {
+		return nil, nil
+	}
+
+	itr, err := is.measurementSeriesByExprIterator(name, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := NewSeriesIDSet()
+	if itr == nil {
+		return ids, nil
+	}
+	defer itr.Close()
+
+	for {
+		e, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if e.SeriesID == 0 {
+			break
+		}
+		ids.AddNoLock(e.SeriesID)
+	}
+	return ids, nil
+}
+
+// measurementTagKeysBySeriesIDSet returns the sorted tag keys of a measurement
+// which belong to at least one series in ids accepted by filter. If ids is nil,
+// the tag keys of all undeleted series are returned. A nil filter accepts all
+// series.
+func (is IndexSet) measurementTagKeysBySeriesIDSet(name []byte, ids *SeriesIDSet, filter seriesIDFilter) ([][]byte, error) {
+	itr, err := is.tagKeyIterator(name)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return nil, nil
+	}
+	defer itr.Close()
+
+	var keys [][]byte
+	for {
+		key, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if key == nil {
+			break
+		}
+
+		sitr, err := is.tagKeySeriesIDIterator(name, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok, err := seriesIDIteratorContainsAny(sitr, ids, filter); err != nil {
+			return nil, err
+		} else if ok {
+			// Copy the key as it may reference memory owned by the index.
+			keys = append(keys, append([]byte(nil), key...))
+		}
+	}
+	return keys, nil
+}
+
+// tagValuesBySeriesIDSet returns the sorted values of a tag key which belong to
+// at least one series in ids accepted by filter. If ids is nil, the tag values
+// of all undeleted series are returned. A nil filter accepts all series.
+func (is IndexSet) tagValuesBySeriesIDSet(name, key []byte, ids *SeriesIDSet, filter seriesIDFilter) ([][]byte, error) {
+	itr, err := is.tagValueIterator(name, key)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return nil, nil
+	}
+	defer itr.Close()
+
+	var values [][]byte
+	for {
+		value, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if value == nil {
+			break
+		}
+
+		sitr, err := is.tagValueSeriesIDIterator(name, key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok, err := seriesIDIteratorContainsAny(sitr, ids, filter); err != nil {
+			return nil, err
+		} else if ok {
+			// Copy the value as it may reference memory owned by the index.
+			values = append(values, append([]byte(nil), value...))
+		}
+	}
+	return values, nil
+}
+
+// measurementHasSeries returns true if the measurement has at least one series
+// in ids accepted by filter. If ids is nil, all undeleted series are considered.
+func (is IndexSet) measurementHasSeries(name []byte, ids *SeriesIDSet, filter seriesIDFilter) (bool, error) {
+	if filter == nil {
+		return ids == nil || ids.Cardinality() > 0, nil
+	}
+
+	itr, err := is.measurementSeriesIDIterator(name)
+	if err != nil {
+		return false, err
+	}
+	return seriesIDIteratorContainsAny(itr, ids, filter)
+}
+
+// seriesIDFilter returns true if the series with the id is accepted.
+type seriesIDFilter func(id uint64) (bool, error)
+
+// seriesIDIteratorContainsAny returns true if itr returns at least one series
+// in ids accepted by filter. If ids is nil, any series is in ids, and a nil
+// filter accepts all series. The iterator is closed on return.
+func seriesIDIteratorContainsAny(itr SeriesIDIterator, ids *SeriesIDSet, filter seriesIDFilter) (bool, error) {
+	if itr == nil {
+		return false, nil
+	}
+	defer itr.Close()
+
+	for {
+		e, err := itr.Next()
+		if err != nil {
+			return false, err
+		} else if e.SeriesID == 0 {
+			return false, nil
+		} else if ids != nil && !ids.ContainsNoLock(e.SeriesID) {
+			continue
+		} else if filter == nil {
+			return true, nil
+		} else if ok, err := filter(e.SeriesID); err != nil || ok {
+			return ok, err
+		}
+	}
+}
+
 // TagKeyHasAuthorizedSeries determines if there exists an authorized series for
 // the provided measurement name and tag key.
 func (is IndexSet) TagKeyHasAuthorizedSeries(auth query.Authorizer, name, tagKey []byte) (bool, error) {
@@ -2043,7 +2224,7 @@ func (is IndexSet) matchTagValueEqualEmptySeriesIDIterator(name, key []byte, val
 }
 
 func (is IndexSet) matchTagValueEqualNotEmptySeriesIDIterator(name, key []byte, value *regexp.Regexp) (SeriesIDIterator, error) {
-	vitr, err := is.tagValueIterator(name, key)
+	vitr, err := is.matchTagValueIterator(name, key, value)
 	if err != nil {
 		return nil, err
 	} else if vitr == nil {
@@ -2105,7 +2286,7 @@ func (is IndexSet) matchTagValueNotEqualEmptySeriesIDIterator(name, key []byte,
 }
 
 func (is IndexSet) matchTagValueNotEqualNotEmptySeriesIDIterator(name, key []byte, value *regexp.Regexp) (SeriesIDIterator, error) {
-	vitr, err := is.tagValueIterator(name, key)
+	vitr, err := is.matchTagValueIterator(name, key, value)
 	if err != nil {
 		return nil, err
 	} else if vitr == nil {
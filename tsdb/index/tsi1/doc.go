@@ -159,6 +159,13 @@ that value. Series iterators can be built around a single tag key value or
 multiple iterators can be merged with set operators such as union or
 intersection.
 
+Keys with many values also carry a trigram index after the hash index of their
+values. It maps each trigram of the values to the sorted offsets of the values
+containing it so that regex matches only need to check the values containing
+the trigrams required by the expression. Tag blocks with trigram indexes are
+written as version 2 blocks, other tag blocks as version 1 blocks so that they
+remain readable by versions without trigram indexes.
+
 
 Measurement block
 
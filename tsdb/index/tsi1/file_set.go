@@ -383,6 +383,19 @@ func (fs *FileSet) TagValueIterator(name, key []byte) TagValueIterator {
 	return MergeTagValueIterators(a...)
 }
 
+// MatchTagValueIterator returns a value iterator over the values of a tag key
+// that may match q. The values must still be matched by the caller.
+func (fs *FileSet) MatchTagValueIterator(name, key []byte, q *trigramQuery) TagValueIterator {
+	a := make([]TagValueIterator, 0, len(fs.files))
+	for _, f := range fs.files {
+		itr := f.MatchTagValueIterator(name, key, q)
+		if itr != nil {
+			a = append(a, itr)
+		}
+	}
+	return MergeTagValueIterators(a...)
+}
+
 // TagValueSeriesIDIterator returns a series iterator for a single tag value.
 func (fs *FileSet) TagValueSeriesIDIterator(name, key, value []byte) tsdb.SeriesIDIterator {
 	a := make([]tsdb.SeriesIDIterator, 0, len(fs.files))
@@ -438,6 +451,7 @@ type File interface {
 
 	TagValue(name, key, value []byte) TagValueElem
 	TagValueIterator(name, key []byte) TagValueIterator
+	MatchTagValueIterator(name, key []byte, q *trigramQuery) TagValueIterator
 
 	// Series iteration.
 	MeasurementSeriesIDIterator(name []byte) tsdb.SeriesIDIterator
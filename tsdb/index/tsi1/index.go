@@ -14,6 +14,7 @@ import (
 
 	"github.com/cespare/xxhash"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/bytesutil"
 	"github.com/influxdata/influxdb/pkg/estimator"
 	"github.com/influxdata/influxdb/pkg/estimator/hll"
 	"github.com/influxdata/influxdb/pkg/slices"
@@ -39,8 +40,12 @@ func init() {
 		DefaultPartitionN = uint64(i)
 	}
 
-	tsdb.RegisterIndex(IndexName, func(_ uint64, db, path string, _ *tsdb.SeriesIDSet, sfile *tsdb.SeriesFile, _ tsdb.EngineOptions) tsdb.Index {
-		idx := NewIndex(sfile, db, WithPath(path))
+	tsdb.RegisterIndex(IndexName, func(_ uint64, db, path string, _ *tsdb.SeriesIDSet, sfile *tsdb.SeriesFile, opt tsdb.EngineOptions) tsdb.Index {
+		idx := NewIndex(sfile, db,
+			WithPath(path),
+			WithSeriesLimiter(opt.SeriesLimiter),
+			WithTrigramIndexThreshold(opt.Config.TSITrigramIndexThreshold),
+		)
 		return idx
 	})
 }
@@ -85,6 +90,22 @@ var WithMaximumLogFileSize = func(size int64) IndexOption {
 	}
 }
 
+// WithTrigramIndexThreshold sets the minimum number of values of a tag key
+// written with a trigram index. A value of 0 disables trigram indexes.
+var WithTrigramIndexThreshold = func(n int) IndexOption {
+	return func(i *Index) {
+		i.trigramThreshold = n
+	}
+}
+
+// WithSeriesLimiter sets the limiter enforcing the series limits of the
+// database when series are created.
+var WithSeriesLimiter = func(l *tsdb.SeriesLimiter) IndexOption {
+	return func(i *Index) {
+		i.seriesLimiter = l
+	}
+}
+
 // Index represents a collection of layered index files and WAL.
 type Index struct {
 	mu         sync.RWMutex
@@ -92,10 +113,12 @@ type Index struct {
 	opened     bool
 
 	// The following may be set when initializing an Index.
-	path               string      // Root directory of the index partitions.
-	disableCompactions bool        // Initially disables compactions on the index.
-	maxLogFileSize     int64       // Maximum size of a LogFile before it's compacted.
-	logger             *zap.Logger // Index's logger.
+	path               string              // Root directory of the index partitions.
+	disableCompactions bool                // Initially disables compactions on the index.
+	maxLogFileSize     int64               // Maximum size of a LogFile before it's compacted.
+	trigramThreshold   int                 // Minimum number of values of a tag key with a trigram index.
+	logger             *zap.Logger         // Index's logger.
+	seriesLimiter      *tsdb.SeriesLimiter // Series limits shared by the shards of the database.
 
 	// The following must be set when initializing an Index.
 	sfile    *tsdb.SeriesFile // series lookup file
@@ -111,12 +134,13 @@ type Index struct {
 // NewIndex returns a new instance of Index.
 func NewIndex(sfile *tsdb.SeriesFile, database string, options ...IndexOption) *Index {
 	idx := &Index{
-		maxLogFileSize: DefaultMaxLogFileSize,
-		logger:         zap.NewNop(),
-		version:        Version,
-		sfile:          sfile,
-		database:       database,
-		PartitionN:     DefaultPartitionN,
+		maxLogFileSize:   DefaultMaxLogFileSize,
+		trigramThreshold: DefaultTrigramIndexThreshold,
+		logger:           zap.NewNop(),
+		version:          Version,
+		sfile:            sfile,
+		database:         database,
+		PartitionN:       DefaultPartitionN,
 	}
 
 	for _, option := range options {
@@ -178,6 +202,7 @@ func (i *Index) Open() error {
 	for j := 0; j < len(i.partitions); j++ {
 		p := NewPartition(i.sfile, filepath.Join(i.path, fmt.Sprint(j)))
 		p.MaxLogFileSize = i.maxLogFileSize
+		p.TrigramIndexThreshold = i.trigramThreshold
 		p.Database = i.database
 		p.logger = i.logger.With(zap.String("tsi1_partition", fmt.Sprint(j+1)))
 		i.partitions[j] = p
@@ -212,6 +237,15 @@ func (i *Index) Open() error {
 		}
 	}
 
+	// Count the existing series against the limits of the database.
+	if i.seriesLimiter != nil {
+		i.SeriesIDSet().ForEach(func(id uint64) {
+			if key := i.sfile.SeriesKey(id); key != nil {
+				i.seriesLimiter.Add(key)
+			}
+		})
+	}
+
 	// Mark opened.
 	i.opened = true
 	i.logger.Info(fmt.Sprintf("index opened with %d partitions", partitionN))
@@ -509,6 +543,12 @@ func (i *Index) CreateSeriesListIfNotExists(keys [][]byte, names [][]byte, tagsS
 		return errors.New("names/tags length mismatch in index")
 	}
 
+	// Drop new series exceeding the series limits.
+	var limitErr error
+	if i.seriesLimiter != nil {
+		keys, names, tagsSlice, limitErr = i.limitSeries(keys, names, tagsSlice)
+	}
+
 	// We need to move different series into collections for each partition
 	// to process.
 	pNames := make([][][]byte, i.PartitionN)
@@ -546,14 +586,68 @@ func (i *Index) CreateSeriesListIfNotExists(keys [][]byte, names [][]byte, tagsS
 			return err
 		}
 	}
-	return nil
+	return limitErr
 }
 
 // CreateSeriesIfNotExists creates a series if it doesn't exist or is deleted.
 func (i *Index) CreateSeriesIfNotExists(key, name []byte, tags models.Tags) error {
+	if i.seriesLimiter != nil {
+		return i.CreateSeriesListIfNotExists([][]byte{key}, [][]byte{name}, []models.Tags{tags})
+	}
 	return i.partition(key).createSeriesListIfNotExists([][]byte{name}, []models.Tags{tags})
 }
 
+// limitSeries removes the series that do not exist in the series file and
+// exceed the series limits of the database from keys, names and tagsSlice.
+// It returns a partial write error for the removed series.
+func (i *Index) limitSeries(keys, names [][]byte, tagsSlice []models.Tags) ([][]byte, [][]byte, []models.Tags, error) {
+	var (
+		newIdx  []int
+		newKeys [][]byte
+		buf     []byte
+	)
+	for j := range names {
+		if i.sfile.SeriesID(names[j], tagsSlice[j], buf) != 0 {
+			continue
+		}
+		newIdx = append(newIdx, j)
+		newKeys = append(newKeys, tsdb.AppendSeriesKey(nil, names[j], tagsSlice[j]))
+	}
+	if len(newKeys) == 0 {
+		return keys, names, tagsSlice, nil
+	}
+
+	rejected, reason := i.seriesLimiter.Reserve(newKeys)
+	if len(rejected) == 0 {
+		return keys, names, tagsSlice, nil
+	}
+
+	drop := make(map[int]struct{}, len(rejected))
+	for _, j := range rejected {
+		drop[newIdx[j]] = struct{}{}
+	}
+
+	// The slices of the caller are left intact.
+	n := len(keys) - len(drop)
+	accKeys, accNames, accTags := make([][]byte, 0, n), make([][]byte, 0, n), make([]models.Tags, 0, n)
+	var droppedKeys [][]byte
+	for j := range keys {
+		if _, ok := drop[j]; ok {
+			droppedKeys = append(droppedKeys, keys[j])
+			continue
+		}
+		accKeys, accNames, accTags = append(accKeys, keys[j]), append(accNames, names[j]), append(accTags, tagsSlice[j])
+	}
+
+	dropped := len(droppedKeys) // number dropped before deduping
+	bytesutil.SortDedup(droppedKeys)
+	return accKeys, accNames, accTags, &tsdb.PartialWriteError{
+		Reason:      reason,
+		Dropped:     dropped,
+		DroppedKeys: droppedKeys,
+	}
+}
+
 // InitializeSeries is a no-op. This only applies to the in-memory index.
 func (i *Index) InitializeSeries(keys, names [][]byte, tags []models.Tags) error {
 	return nil
@@ -767,6 +861,21 @@ func (i *Index) TagValueIterator(name, key []byte) (tsdb.TagValueIterator, error
 	return tsdb.MergeTagValueIterators(a...), nil
 }
 
+// MatchTagValueIterator returns an iterator over the values of a single key
+// that may match re. Values are narrowed by the trigram indexes of the tag
+// blocks so the caller must still match the returned values against re.
+func (i *Index) MatchTagValueIterator(name, key []byte, re *regexp.Regexp) (tsdb.TagValueIterator, error) {
+	q := newTrigramQuery(re)
+	a := make([]tsdb.TagValueIterator, 0, len(i.partitions))
+	for _, p := range i.partitions {
+		itr := p.MatchTagValueIterator(name, key, q)
+		if itr != nil {
+			a = append(a, itr)
+		}
+	}
+	return tsdb.MergeTagValueIterators(a...), nil
+}
+
 // TagKeySeriesIDIterator returns a series iterator for all values across a single key.
 func (i *Index) TagKeySeriesIDIterator(name, key []byte) (tsdb.SeriesIDIterator, error) {
 	a := make([]tsdb.SeriesIDIterator, 0, len(i.partitions))
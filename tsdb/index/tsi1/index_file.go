@@ -275,6 +275,23 @@ func (f *IndexFile) TagValueIterator(name, key []byte) TagValueIterator {
 	return ke.TagValueIterator()
 }
 
+// MatchTagValueIterator returns a value iterator over the values of a tag key
+// that may match q. Values are narrowed by the trigram index of the key, if
+// available.
+func (f *IndexFile) MatchTagValueIterator(name, key []byte, q *trigramQuery) TagValueIterator {
+	tblk := f.tblks[string(name)]
+	if tblk == nil {
+		return nil
+	}
+
+	// Find key element.
+	var ke TagBlockKeyElem
+	if !tblk.DecodeTagKeyElem(key, &ke) {
+		return nil
+	}
+	return ke.matchTagValueIterator(q)
+}
+
 // TagKeySeriesIDIterator returns a series iterator for a tag key and a flag
 // indicating if a tombstone exists on the measurement or key.
 func (f *IndexFile) TagKeySeriesIDIterator(name, key []byte) tsdb.SeriesIDIterator {
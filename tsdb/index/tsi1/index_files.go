@@ -151,8 +151,9 @@ func (p IndexFiles) TagValueSeriesIDIterator(name, key, value []byte) tsdb.Serie
 	return tsdb.MergeSeriesIDIterators(a...)
 }
 
-// CompactTo merges all index files and writes them to w.
-func (p IndexFiles) CompactTo(w io.Writer, sfile *tsdb.SeriesFile, m, k uint64, cancel <-chan struct{}) (n int64, err error) {
+// CompactTo merges all index files and writes them to w. Tag keys with at
+// least trigramThreshold values are written with a trigram index.
+func (p IndexFiles) CompactTo(w io.Writer, sfile *tsdb.SeriesFile, m, k uint64, trigramThreshold int, cancel <-chan struct{}) (n int64, err error) {
 	var t IndexFileTrailer
 
 	// Check for cancellation.
@@ -168,6 +169,7 @@ func (p IndexFiles) CompactTo(w io.Writer, sfile *tsdb.SeriesFile, m, k uint64,
 	// Setup context object to track shared data for this compaction.
 	var info indexCompactInfo
 	info.cancel = cancel
+	info.trigramThreshold = trigramThreshold
 	info.tagSets = make(map[string]indexTagSetPos)
 
 	// Write magic number.
@@ -296,6 +298,7 @@ func (p IndexFiles) writeTagsetTo(w io.Writer, name []byte, info *indexCompactIn
 
 	var seriesN int
 	enc := NewTagBlockEncoder(w)
+	enc.TrigramIndexThreshold = info.trigramThreshold
 	for ke := kitr.Next(); ke != nil; ke = kitr.Next() {
 		// Encode key.
 		if err := enc.EncodeKey(ke.Key(), ke.Deleted()); err != nil {
@@ -451,7 +454,8 @@ type IndexFilesInfo struct {
 // indexCompactInfo is a context object used for tracking position information
 // during the compaction of index files.
 type indexCompactInfo struct {
-	cancel <-chan struct{}
+	cancel           <-chan struct{}
+	trigramThreshold int
 
 	// Tracks offset/size for each measurement's tagset.
 	tagSets map[string]indexTagSetPos
@@ -7,9 +7,11 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/index/tsi1"
 )
 
@@ -308,6 +310,61 @@ func TestIndex_DiskSizeBytes(t *testing.T) {
 	})
 }
 
+// Ensure new series exceeding the series limits of a measurement are dropped.
+func TestIndex_SeriesLimits(t *testing.T) {
+	config := tsdb.NewConfig()
+	config.MaxSeriesPerMeasurement = 2
+	config.SeriesLimits = map[string]map[string]int{"db0": {"mem": 0}}
+
+	idx := NewIndex(tsi1.DefaultPartitionN)
+	idx.Index = tsi1.NewIndex(idx.SeriesFile.SeriesFile, "db0", tsi1.WithPath(idx.Index.Path()),
+		tsi1.WithSeriesLimiter(tsdb.NewSeriesLimiter("db0", config)))
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+
+	err := idx.CreateSeriesSliceIfNotExists([]Series{
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "A"})},
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "B"})},
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "C"})},
+		{Name: []byte("mem"), Tags: models.NewTags(map[string]string{"host": "A"})},
+		{Name: []byte("mem"), Tags: models.NewTags(map[string]string{"host": "B"})},
+		{Name: []byte("mem"), Tags: models.NewTags(map[string]string{"host": "C"})},
+	})
+	if perr, ok := err.(*tsdb.PartialWriteError); !ok {
+		t.Fatalf("expected partial write error, got %v", err)
+	} else if perr.Dropped != 1 || !strings.Contains(perr.Reason, `measurement="cpu"`) {
+		t.Fatalf("unexpected partial write error: %v", perr)
+	}
+
+	if got, exp := idx.SeriesN(), int64(5); got != exp {
+		t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+	}
+
+	// Existing series can still be written.
+	if err := idx.CreateSeriesSliceIfNotExists([]Series{
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "A"})},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Existing series are counted when a new limiter is used.
+	if err := idx.Index.Close(); err != nil {
+		t.Fatal(err)
+	}
+	idx.Index = tsi1.NewIndex(idx.SeriesFile.SeriesFile, "db0", tsi1.WithPath(idx.Index.Path()),
+		tsi1.WithSeriesLimiter(tsdb.NewSeriesLimiter("db0", config)))
+	if err := idx.Index.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.CreateSeriesSliceIfNotExists([]Series{
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "D"})},
+	}); err == nil {
+		t.Fatal("expected partial write error")
+	}
+}
+
 // Index is a test wrapper for tsi1.Index.
 type Index struct {
 	*tsi1.Index
@@ -378,6 +378,12 @@ func (f *LogFile) TagValueIterator(name, key []byte) TagValueIterator {
 	return tk.TagValueIterator()
 }
 
+// MatchTagValueIterator returns a value iterator over the values of a tag key
+// that may match q. Log files are not indexed so all values are returned.
+func (f *LogFile) MatchTagValueIterator(name, key []byte, q *trigramQuery) TagValueIterator {
+	return f.TagValueIterator(name, key)
+}
+
 // DeleteTagKey adds a tombstone for a tag key to the log file.
 func (f *LogFile) DeleteTagKey(name, key []byte) error {
 	f.mu.Lock()
@@ -766,8 +772,9 @@ func (f *LogFile) MeasurementSeriesIDIterator(name []byte) tsdb.SeriesIDIterator
 	return newLogSeriesIDIterator(mm.series)
 }
 
-// CompactTo compacts the log file and writes it to w.
-func (f *LogFile) CompactTo(w io.Writer, m, k uint64, cancel <-chan struct{}) (n int64, err error) {
+// CompactTo compacts the log file and writes it to w. Tag keys with at least
+// trigramThreshold values are written with a trigram index.
+func (f *LogFile) CompactTo(w io.Writer, m, k uint64, trigramThreshold int, cancel <-chan struct{}) (n int64, err error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -785,6 +792,7 @@ func (f *LogFile) CompactTo(w io.Writer, m, k uint64, cancel <-chan struct{}) (n
 	var t IndexFileTrailer
 	info := newLogFileCompactInfo()
 	info.cancel = cancel
+	info.trigramThreshold = trigramThreshold
 
 	// Write magic number.
 	if err := writeTo(bw, []byte(FileSignature), &n); err != nil {
@@ -883,6 +891,7 @@ func (f *LogFile) writeTagsetTo(w io.Writer, name string, info *logFileCompactIn
 	}
 
 	enc := NewTagBlockEncoder(w)
+	enc.TrigramIndexThreshold = info.trigramThreshold
 	var valueN int
 	for _, k := range mm.keys() {
 		tag := mm.tagSet[k]
@@ -963,8 +972,9 @@ func (f *LogFile) writeMeasurementBlockTo(w io.Writer, names []string, info *log
 
 // logFileCompactInfo is a context object to track compaction position info.
 type logFileCompactInfo struct {
-	cancel <-chan struct{}
-	mms    map[string]*logFileMeasurementCompactInfo
+	cancel           <-chan struct{}
+	trigramThreshold int
+	mms              map[string]*logFileMeasurementCompactInfo
 }
 
 // newLogFileCompactInfo returns a new instance of logFileCompactInfo.
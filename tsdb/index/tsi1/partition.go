@@ -75,6 +75,9 @@ type Partition struct {
 	// Log file compaction thresholds.
 	MaxLogFileSize int64
 
+	// Minimum number of values of a tag key written with a trigram index.
+	TrigramIndexThreshold int
+
 	// Frequency of compaction checks.
 	compactionInterrupt chan struct{}
 	compactionsDisabled int
@@ -97,7 +100,8 @@ func NewPartition(sfile *tsdb.SeriesFile, path string) *Partition {
 		seriesIDSet: tsdb.NewSeriesIDSet(),
 
 		// Default compaction thresholds.
-		MaxLogFileSize: DefaultMaxLogFileSize,
+		MaxLogFileSize:        DefaultMaxLogFileSize,
+		TrigramIndexThreshold: DefaultTrigramIndexThreshold,
 
 		// compactionEnabled: true,
 		compactionInterrupt: make(chan struct{}),
@@ -710,6 +714,22 @@ func (i *Partition) TagValueIterator(name, key []byte) tsdb.TagValueIterator {
 	return newFileSetTagValueIterator(fs, NewTSDBTagValueIteratorAdapter(itr))
 }
 
+// MatchTagValueIterator returns an iterator over the values of a single key
+// that may match q.
+func (i *Partition) MatchTagValueIterator(name, key []byte, q *trigramQuery) tsdb.TagValueIterator {
+	fs, err := i.RetainFileSet()
+	if err != nil {
+		return nil
+	}
+
+	itr := fs.MatchTagValueIterator(name, key, q)
+	if itr == nil {
+		fs.Release()
+		return nil
+	}
+	return newFileSetTagValueIterator(fs, NewTSDBTagValueIteratorAdapter(itr))
+}
+
 // TagKeySeriesIDIterator returns a series iterator for all values across a single key.
 func (i *Partition) TagKeySeriesIDIterator(name, key []byte) tsdb.SeriesIDIterator {
 	fs, err := i.RetainFileSet()
@@ -922,7 +942,7 @@ func (i *Partition) compactToLevel(files []*IndexFile, level int, interrupt <-ch
 
 	// Compact all index files to new index file.
 	lvl := i.levels[level]
-	n, err := IndexFiles(files).CompactTo(f, i.sfile, lvl.M, lvl.K, interrupt)
+	n, err := IndexFiles(files).CompactTo(f, i.sfile, lvl.M, lvl.K, i.TrigramIndexThreshold, interrupt)
 	if err != nil {
 		log.Error("Cannot compact index files", zap.Error(err))
 		return
@@ -1063,7 +1083,7 @@ func (i *Partition) compactLogFile(logFile *LogFile) {
 
 	// Compact log file to new index file.
 	lvl := i.levels[1]
-	n, err := logFile.CompactTo(f, lvl.M, lvl.K, interrupt)
+	n, err := logFile.CompactTo(f, lvl.M, lvl.K, i.TrigramIndexThreshold, interrupt)
 	if err != nil {
 		log.Error("Cannot compact log file", zap.Error(err), zap.String("path", logFile.Path()))
 		return
@@ -6,16 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/influxdata/influxdb/pkg/rhh"
 )
 
 // TagBlockVersion is the version of the tag block.
-const TagBlockVersion = 1
+// Version 2 adds the optional trigram index of the values of a tag key.
+// Blocks without trigram indexes are written as version 1 blocks.
+const TagBlockVersion = 2
 
 // Tag key flag constants.
 const (
-	TagKeyTombstoneFlag = 0x01
+	TagKeyTombstoneFlag    = 0x01
+	TagKeyTrigramIndexFlag = 0x02
 )
 
 // Tag value flag constants.
@@ -32,8 +36,18 @@ const (
 	// TagBlock value block fields.
 	TagValueNSize      = 8
 	TagValueOffsetSize = 8
+
+	// TagBlock trigram index fields.
+	TagTrigramNSize      = 8
+	TagTrigramOffsetSize = 8
+	TagTrigramEntrySize  = TrigramSize + TagTrigramOffsetSize
 )
 
+// DefaultTrigramIndexThreshold is the default minimum number of values of a
+// tag key for the key to carry a trigram index.  It must match
+// tsdb.DefaultTSITrigramIndexThreshold.
+const DefaultTrigramIndexThreshold = 1000
+
 // TagBlock errors.
 var (
 	ErrUnsupportedTagBlockVersion = errors.New("unsupported tag block version")
@@ -83,6 +97,7 @@ func (blk *TagBlock) UnmarshalBinary(data []byte) error {
 
 	// Save entire block.
 	blk.data = data
+	blk.version = t.Version
 
 	return nil
 }
@@ -251,6 +266,29 @@ func (itr *tagBlockValueIterator) Next() TagValueElem {
 	return &itr.e
 }
 
+// tagBlockValueOffsetIterator represents an iterator over the values of a tag
+// key at a sorted list of offsets within the key's value data.
+type tagBlockValueOffsetIterator struct {
+	data    []byte
+	offsets []uint64
+	e       TagBlockValueElem
+}
+
+// Next returns the next element in the iterator.
+func (itr *tagBlockValueOffsetIterator) Next() TagValueElem {
+	// Exit when there are no offsets left.
+	if len(itr.offsets) == 0 {
+		return nil
+	}
+
+	// Unmarshal next element & move offsets forward.
+	itr.e.unmarshal(itr.data[itr.offsets[0]:])
+	itr.offsets = itr.offsets[1:]
+
+	assert(len(itr.e.Value()) > 0, "invalid zero-length tag value")
+	return &itr.e
+}
+
 // TagBlockKeyElem represents a tag key element in a TagBlock.
 type TagBlockKeyElem struct {
 	flag byte
@@ -270,6 +308,13 @@ type TagBlockKeyElem struct {
 		buf    []byte
 	}
 
+	// Value trigram index data
+	trigramIndex struct {
+		offset uint64
+		size   uint64
+		buf    []byte
+	}
+
 	size int
 }
 
@@ -284,6 +329,59 @@ func (e *TagBlockKeyElem) TagValueIterator() TagValueIterator {
 	return &tagBlockValueIterator{data: e.data.buf}
 }
 
+// HasTrigramIndex returns true if the key's values have a trigram index.
+func (e *TagBlockKeyElem) HasTrigramIndex() bool { return (e.flag & TagKeyTrigramIndexFlag) != 0 }
+
+// matchTagValueIterator returns an iterator over the key's values that may
+// match q.  All the values are returned if the key has no trigram index.
+func (e *TagBlockKeyElem) matchTagValueIterator(q *trigramQuery) TagValueIterator {
+	if !e.HasTrigramIndex() {
+		return e.TagValueIterator()
+	}
+
+	offsets, all := q.eval(e.trigramOffsets)
+	if all {
+		return e.TagValueIterator()
+	}
+	return &tagBlockValueOffsetIterator{data: e.data.buf, offsets: offsets}
+}
+
+// trigramOffsets returns the sorted offsets of the values containing trigram
+// within the key's value data.
+func (e *TagBlockKeyElem) trigramOffsets(trigram string) []uint64 {
+	buf := e.trigramIndex.buf
+	n := int(binary.BigEndian.Uint64(buf[:TagTrigramNSize]))
+	entries := buf[TagTrigramNSize:]
+
+	// Binary search the sorted trigram entries.
+	i := sort.Search(n, func(i int) bool {
+		return string(entries[i*TagTrigramEntrySize:i*TagTrigramEntrySize+TrigramSize]) >= trigram
+	})
+	if i == n {
+		return nil
+	}
+	entry := entries[i*TagTrigramEntrySize:]
+	if string(entry[:TrigramSize]) != trigram {
+		return nil
+	}
+
+	// Decode the delta encoded value offsets.
+	data := buf[binary.BigEndian.Uint64(entry[TrigramSize:]):]
+	count, sz := binary.Uvarint(data)
+	data = data[sz:]
+
+	a := make([]uint64, 0, count)
+	var prev uint64
+	for i := uint64(0); i < count; i++ {
+		delta, sz := binary.Uvarint(data)
+		data = data[sz:]
+
+		prev += delta
+		a = append(a, prev)
+	}
+	return a
+}
+
 // unmarshal unmarshals buf into e.
 // The data argument represents the entire block data.
 func (e *TagBlockKeyElem) unmarshal(buf, data []byte) {
@@ -308,6 +406,15 @@ func (e *TagBlockKeyElem) unmarshal(buf, data []byte) {
 	e.hashIndex.buf = data[e.hashIndex.offset:]
 	e.hashIndex.buf = e.hashIndex.buf[:e.hashIndex.size]
 
+	// Parse & slice trigram index, if available.
+	if e.HasTrigramIndex() {
+		e.trigramIndex.offset, buf = binary.BigEndian.Uint64(buf), buf[8:]
+		e.trigramIndex.size, buf = binary.BigEndian.Uint64(buf), buf[8:]
+
+		e.trigramIndex.buf = data[e.trigramIndex.offset:]
+		e.trigramIndex.buf = e.trigramIndex.buf[:e.trigramIndex.size]
+	}
+
 	// Parse key.
 	n, sz := binary.Uvarint(buf)
 	e.key, buf = buf[sz:sz+int(n)], buf[int(n)+sz:]
@@ -449,7 +556,7 @@ func (t *TagBlockTrailer) WriteTo(w io.Writer) (n int64, err error) {
 	// Write total size & encoding version.
 	if err := writeUint64To(w, uint64(t.Size), &n); err != nil {
 		return n, err
-	} else if err := writeUint16To(w, IndexFileVersion, &n); err != nil {
+	} else if err := writeUint16To(w, uint16(t.Version), &n); err != nil {
 		return n, err
 	}
 
@@ -460,9 +567,9 @@ func (t *TagBlockTrailer) WriteTo(w io.Writer) (n int64, err error) {
 func ReadTagBlockTrailer(data []byte) (TagBlockTrailer, error) {
 	var t TagBlockTrailer
 
-	// Read version.
+	// Read version. Version 1 blocks are version 2 blocks without trigram indexes.
 	t.Version = int(binary.BigEndian.Uint16(data[len(data)-2:]))
-	if t.Version != TagBlockVersion {
+	if t.Version < 1 || t.Version > TagBlockVersion {
 		return t, ErrUnsupportedTagBlockVersion
 	}
 
@@ -502,16 +609,33 @@ type TagBlockEncoder struct {
 	// Track tag keys.
 	keys      []tagKeyEncodeEntry
 	prevValue []byte
+
+	// Track values & trigrams of the current key.
+	valueN   int
+	trigrams map[string]*trigramPostings
+
+	// Minimum number of values of a key for the key to carry a trigram
+	// index. Defaults to DefaultTrigramIndexThreshold. A value of 0 disables
+	// trigram indexes.
+	TrigramIndexThreshold int
+}
+
+// trigramPostings holds the delta encoded offsets of the values containing a
+// trigram.
+type trigramPostings struct {
+	n    uint64
+	prev int64
+	buf  []byte
 }
 
 // NewTagBlockEncoder returns a new TagBlockEncoder.
 func NewTagBlockEncoder(w io.Writer) *TagBlockEncoder {
 	return &TagBlockEncoder{
-		w:       w,
-		offsets: rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor}),
-		trailer: TagBlockTrailer{
-			Version: TagBlockVersion,
-		},
+		w:        w,
+		offsets:  rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor}),
+		trigrams: make(map[string]*trigramPostings),
+
+		TrigramIndexThreshold: DefaultTrigramIndexThreshold,
 	}
 }
 
@@ -574,6 +698,10 @@ func (enc *TagBlockEncoder) EncodeValue(value []byte, deleted bool, seriesIDs []
 	// Save offset to hash map.
 	enc.offsets.Put(value, enc.n)
 
+	// Save offset within the key's value data for each trigram.
+	enc.addTrigrams(value, enc.n-enc.keys[len(enc.keys)-1].data.offset)
+	enc.valueN++
+
 	// Write flag.
 	if err := writeUint8To(enc.w, encodeTagValueFlag(deleted), &enc.n); err != nil {
 		return err
@@ -638,6 +766,15 @@ func (enc *TagBlockEncoder) Close() error {
 		return err
 	}
 
+	// Write version 1 blocks unless a key carries a trigram index.
+	enc.trailer.Version = 1
+	for i := range enc.keys {
+		if enc.keys[i].trigramIndex.size > 0 {
+			enc.trailer.Version = TagBlockVersion
+			break
+		}
+	}
+
 	// Compute total size w/ trailer.
 	enc.trailer.Size = enc.n + TagBlockTrailerSize
 
@@ -691,6 +828,77 @@ func (enc *TagBlockEncoder) flushValueHashIndex() error {
 	// Clear offsets.
 	enc.offsets = rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor})
 
+	// Encode trigram index if the key has enough values.
+	if enc.TrigramIndexThreshold > 0 && enc.valueN >= enc.TrigramIndexThreshold {
+		if err := enc.flushValueTrigramIndex(key); err != nil {
+			return err
+		}
+	}
+
+	// Clear trigrams.
+	enc.valueN = 0
+	enc.trigrams = make(map[string]*trigramPostings)
+
+	return nil
+}
+
+// addTrigrams adds the offset of value to the postings of its trigrams.
+func (enc *TagBlockEncoder) addTrigrams(value []byte, offset int64) {
+	var buf [binary.MaxVarintLen64]byte
+	for _, trigram := range appendTrigrams(nil, value) {
+		p := enc.trigrams[trigram]
+		if p == nil {
+			p = &trigramPostings{}
+			enc.trigrams[trigram] = p
+		}
+
+		i := binary.PutUvarint(buf[:], uint64(offset-p.prev))
+		p.buf = append(p.buf, buf[:i]...)
+		p.prev = offset
+		p.n++
+	}
+}
+
+// flushValueTrigramIndex writes the trigram index of the values of key.
+func (enc *TagBlockEncoder) flushValueTrigramIndex(key *tagKeyEncodeEntry) error {
+	trigrams := make([]string, 0, len(enc.trigrams))
+	for trigram := range enc.trigrams {
+		trigrams = append(trigrams, trigram)
+	}
+	sort.Strings(trigrams)
+
+	// Encode trigram count.
+	key.trigramIndex.offset = enc.n
+	if err := writeUint64To(enc.w, uint64(len(trigrams)), &enc.n); err != nil {
+		return err
+	}
+
+	// Encode trigram entries with the offset of their postings, relative
+	// to the start of the trigram index.
+	var buf [binary.MaxVarintLen64]byte
+	offset := int64(TagTrigramNSize + len(trigrams)*TagTrigramEntrySize)
+	for _, trigram := range trigrams {
+		if err := writeTo(enc.w, []byte(trigram), &enc.n); err != nil {
+			return err
+		} else if err := writeUint64To(enc.w, uint64(offset), &enc.n); err != nil {
+			return err
+		}
+
+		p := enc.trigrams[trigram]
+		offset += int64(binary.PutUvarint(buf[:], p.n) + len(p.buf))
+	}
+
+	// Encode postings.
+	for _, trigram := range trigrams {
+		p := enc.trigrams[trigram]
+		if err := writeUvarintTo(enc.w, p.n, &enc.n); err != nil {
+			return err
+		} else if err := writeTo(enc.w, p.buf, &enc.n); err != nil {
+			return err
+		}
+	}
+	key.trigramIndex.size = enc.n - key.trigramIndex.offset
+
 	return nil
 }
 
@@ -706,7 +914,8 @@ func (enc *TagBlockEncoder) encodeTagKeyBlock() error {
 		// Save current offset so we can use it in the hash index.
 		offsets.Put(entry.key, enc.n)
 
-		if err := writeUint8To(enc.w, encodeTagKeyFlag(entry.deleted), &enc.n); err != nil {
+		hasTrigramIndex := entry.trigramIndex.size > 0
+		if err := writeUint8To(enc.w, encodeTagKeyFlag(entry.deleted, hasTrigramIndex), &enc.n); err != nil {
 			return err
 		}
 
@@ -724,6 +933,15 @@ func (enc *TagBlockEncoder) encodeTagKeyBlock() error {
 			return err
 		}
 
+		// Write value trigram index offset & size, if available.
+		if hasTrigramIndex {
+			if err := writeUint64To(enc.w, uint64(entry.trigramIndex.offset), &enc.n); err != nil {
+				return err
+			} else if err := writeUint64To(enc.w, uint64(entry.trigramIndex.size), &enc.n); err != nil {
+				return err
+			}
+		}
+
 		// Write key length and data.
 		if err := writeUvarintTo(enc.w, uint64(len(entry.key)), &enc.n); err != nil {
 			return err
@@ -764,13 +982,20 @@ type tagKeyEncodeEntry struct {
 		offset int64
 		size   int64
 	}
+	trigramIndex struct {
+		offset int64
+		size   int64
+	}
 }
 
-func encodeTagKeyFlag(deleted bool) byte {
+func encodeTagKeyFlag(deleted, hasTrigramIndex bool) byte {
 	var flag byte
 	if deleted {
 		flag |= TagKeyTombstoneFlag
 	}
+	if hasTrigramIndex {
+		flag |= TagKeyTrigramIndexFlag
+	}
 	return flag
 }
 
@@ -40,10 +40,12 @@ func TestTagBlockWriter(t *testing.T) {
 		t.Fatalf("bytes written mismatch: %d, expected %d", enc.N(), buf.Len())
 	}
 
-	// Unmarshal into a block.
+	// Unmarshal into a block.  Blocks without trigram indexes are version 1.
 	var blk tsi1.TagBlock
 	if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
 		t.Fatal(err)
+	} else if blk.Version() != 1 {
+		t.Fatalf("unexpected version: %d", blk.Version())
 	}
 
 	// Verify data.
@@ -75,6 +77,53 @@ func TestTagBlockWriter(t *testing.T) {
 	}
 }
 
+// Ensure tag keys with enough values carry a trigram index.
+func TestTagBlockWriter_TrigramIndex(t *testing.T) {
+	var buf bytes.Buffer
+	enc := tsi1.NewTagBlockEncoder(&buf)
+	enc.TrigramIndexThreshold = 3
+
+	if err := enc.EncodeKey([]byte("host"), false); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"web-1-dev", "web-1-prod", "web-2-prod"} {
+		if err := enc.EncodeValue([]byte(v), false, []uint64{1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.EncodeKey([]byte("region"), false); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("us-east"), false, []uint64{1}); err != nil {
+		t.Fatal(err)
+	} else if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	} else if int(enc.N()) != buf.Len() {
+		t.Fatalf("bytes written mismatch: %d, expected %d", enc.N(), buf.Len())
+	}
+
+	var blk tsi1.TagBlock
+	if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if blk.Version() != tsi1.TagBlockVersion {
+		t.Fatalf("unexpected version: %d", blk.Version())
+	}
+
+	if e := blk.TagKeyElem([]byte("host")).(*tsi1.TagBlockKeyElem); !e.HasTrigramIndex() {
+		t.Fatal("expected trigram index")
+	}
+	if e := blk.TagKeyElem([]byte("region")).(*tsi1.TagBlockKeyElem); e.HasTrigramIndex() {
+		t.Fatal("unexpected trigram index")
+	}
+
+	// Keys after an indexed key are still decoded.
+	if e := blk.TagValueElem([]byte("region"), []byte("us-east")); e == nil {
+		t.Fatal("expected element")
+	}
+	if e := blk.TagValueElem([]byte("host"), []byte("web-2-prod")); e == nil {
+		t.Fatal("expected element")
+	}
+}
+
 var benchmarkTagBlock10x1000 *tsi1.TagBlock
 var benchmarkTagBlock100x1000 *tsi1.TagBlock
 var benchmarkTagBlock1000x1000 *tsi1.TagBlock
@@ -0,0 +1,190 @@
+package tsi1
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+)
+
+// TrigramSize is the number of bytes of a trigram.
+const TrigramSize = 3
+
+// trigramQuery represents the trigrams required by the values matching a
+// regular expression.  A value can only match if it contains all the trigrams
+// of an AND query and matches all of its sub-queries, or if it matches one of
+// the sub-queries of an OR query.  A nil query matches every value.
+type trigramQuery struct {
+	or       bool
+	trigrams []string
+	subs     []*trigramQuery
+}
+
+// newTrigramQuery returns the trigram query of the values matching re, or nil
+// if any value may match.
+func newTrigramQuery(re *regexp.Regexp) *trigramQuery {
+	expr, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	return regexpTrigramQuery(expr.Simplify())
+}
+
+// regexpTrigramQuery returns the trigram query of the values containing a
+// match of re.  Only literal strings contribute trigrams; any other operator
+// that does not require its operand to match leaves the query unconstrained.
+func regexpTrigramQuery(re *syntax.Regexp) *trigramQuery {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return nil
+		}
+		trigrams := appendTrigrams(nil, []byte(string(re.Rune)))
+		if len(trigrams) == 0 {
+			return nil
+		}
+		return &trigramQuery{trigrams: trigrams}
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return regexpTrigramQuery(re.Sub[0])
+
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil
+		}
+		return regexpTrigramQuery(re.Sub[0])
+
+	case syntax.OpConcat:
+		q := &trigramQuery{}
+		for _, sub := range re.Sub {
+			s := regexpTrigramQuery(sub)
+			if s == nil {
+				continue
+			} else if s.or {
+				q.subs = append(q.subs, s)
+			} else {
+				q.trigrams = append(q.trigrams, s.trigrams...)
+				q.subs = append(q.subs, s.subs...)
+			}
+		}
+		if len(q.trigrams) == 0 && len(q.subs) == 0 {
+			return nil
+		}
+		return q
+
+	case syntax.OpAlternate:
+		q := &trigramQuery{or: true}
+		for _, sub := range re.Sub {
+			s := regexpTrigramQuery(sub)
+			if s == nil {
+				return nil
+			}
+			q.subs = append(q.subs, s)
+		}
+		return q
+	}
+	return nil
+}
+
+// eval returns the sorted offsets of the values that may match the query
+// given the sorted offsets of the values containing each trigram.  The all
+// flag is returned if the query does not narrow the values.
+func (q *trigramQuery) eval(lookup func(trigram string) []uint64) (offsets []uint64, all bool) {
+	if q == nil {
+		return nil, true
+	}
+
+	if q.or {
+		for _, sub := range q.subs {
+			a, all := sub.eval(lookup)
+			if all {
+				return nil, true
+			}
+			offsets = unionOffsets(offsets, a)
+		}
+		return offsets, false
+	}
+
+	all = true
+	for _, trigram := range q.trigrams {
+		a := lookup(trigram)
+		if all {
+			offsets, all = a, false
+		} else {
+			offsets = intersectOffsets(offsets, a)
+		}
+		if len(offsets) == 0 {
+			return nil, false
+		}
+	}
+	for _, sub := range q.subs {
+		a, subAll := sub.eval(lookup)
+		if subAll {
+			continue
+		} else if all {
+			offsets, all = a, false
+		} else {
+			offsets = intersectOffsets(offsets, a)
+		}
+		if len(offsets) == 0 {
+			return nil, false
+		}
+	}
+	return offsets, all
+}
+
+// appendTrigrams appends the distinct trigrams of v to a in sorted order.
+func appendTrigrams(a []string, v []byte) []string {
+	n := len(a)
+	for i := 0; i+TrigramSize <= len(v); i++ {
+		a = append(a, string(v[i:i+TrigramSize]))
+	}
+
+	sort.Strings(a[n:])
+
+	// Remove duplicates.
+	if len(a)-n < 2 {
+		return a
+	}
+	j := n + 1
+	for i := n + 1; i < len(a); i++ {
+		if a[i] != a[j-1] {
+			a[j] = a[i]
+			j++
+		}
+	}
+	return a[:j]
+}
+
+// intersectOffsets returns the offsets of both sorted a and b.
+func intersectOffsets(a, b []uint64) []uint64 {
+	other := make([]uint64, 0, len(a))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] < b[0] {
+			a = a[1:]
+		} else if a[0] > b[0] {
+			b = b[1:]
+		} else {
+			other = append(other, a[0])
+			a, b = a[1:], b[1:]
+		}
+	}
+	return other
+}
+
+// unionOffsets returns the offsets of either sorted a or b.
+func unionOffsets(a, b []uint64) []uint64 {
+	other := make([]uint64, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		if len(b) == 0 || (len(a) > 0 && a[0] < b[0]) {
+			other = append(other, a[0])
+			a = a[1:]
+		} else if len(a) == 0 || b[0] < a[0] {
+			other = append(other, b[0])
+			b = b[1:]
+		} else {
+			other = append(other, a[0])
+			a, b = a[1:], b[1:]
+		}
+	}
+	return other
+}
@@ -0,0 +1,64 @@
+package tsi1
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// Ensure values of an indexed tag key are narrowed to the values that may
+// match a regular expression.
+func TestTagBlockKeyElem_MatchTagValueIterator(t *testing.T) {
+	values := []string{"db-1-prod", "web-1-dev", "web-1-prod", "web-2-prod", "worker"}
+
+	var buf bytes.Buffer
+	enc := NewTagBlockEncoder(&buf)
+	enc.TrigramIndexThreshold = 1
+	if err := enc.EncodeKey([]byte("host"), false); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range values {
+		if err := enc.EncodeValue([]byte(v), false, []uint64{1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var blk TagBlock
+	if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	var ke TagBlockKeyElem
+	if !blk.DecodeTagKeyElem([]byte("host"), &ke) {
+		t.Fatal("expected key")
+	}
+
+	for _, tt := range []struct {
+		re  string
+		exp []string
+	}{
+		{re: `web-.*-prod`, exp: []string{"web-1-prod", "web-2-prod"}},
+		{re: `^web-1`, exp: []string{"web-1-dev", "web-1-prod"}},
+		{re: `(dev|work)`, exp: []string{"web-1-dev", "worker"}},
+		{re: `(?:prod)+$`, exp: []string{"db-1-prod", "web-1-prod", "web-2-prod"}},
+		{re: `cache`, exp: nil},
+
+		// Expressions without required trigrams return all values.
+		{re: `web|db`, exp: values},
+		{re: `(?i)WEB`, exp: values},
+		{re: `.*`, exp: values},
+		{re: `(prod)?`, exp: values},
+	} {
+		var got []string
+		itr := ke.matchTagValueIterator(newTrigramQuery(regexp.MustCompile(tt.re)))
+		for e := itr.Next(); e != nil; e = itr.Next() {
+			got = append(got, string(e.Value()))
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("%s: unexpected values: got %q, exp %q", tt.re, got, tt.exp)
+		}
+	}
+}
@@ -133,6 +133,91 @@ func TestIndexSet_MeasurementNamesByExpr(t *testing.T) {
 	}
 }
 
+// Ensure regular expressions match the values of a tsi1 tag key carrying a
+// trigram index.
+func TestIndexSet_MeasurementSeriesByExprIterator_TrigramIndex(t *testing.T) {
+	idx := MustNewIndex(tsi1.IndexName)
+	index := idx.Index.(*tsi1.Index)
+	// Compact the whole batch into a single index file so its tag block has
+	// more values than the trigram index threshold.
+	index.PartitionN = 1
+	tsi1.WithMaximumLogFileSize(1 << 10)(index)
+	idx.MustOpen()
+	defer idx.Close()
+
+	// Regex comparisons check if the key is a field, like the shard does.
+	fields, err := tsdb.NewMeasurementFieldSet(filepath.Join(idx.rootPath, "fields.idx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx.SetFieldSet(fields)
+
+	const n = 2 * tsi1.DefaultTrigramIndexThreshold
+	var keys, names [][]byte
+	var tags []models.Tags
+	for i := 0; i < n; i++ {
+		tagset := models.NewTags(map[string]string{"host": fmt.Sprintf("server-%04d", i)})
+		keys = append(keys, []byte(fmt.Sprintf("cpu,%s", tagset.HashKey())))
+		names = append(names, []byte("cpu"))
+		tags = append(tags, tagset)
+	}
+	if err := idx.CreateSeriesListIfNotExists(keys, names, tags); err != nil {
+		t.Fatal(err)
+	}
+	index.Wait()
+
+	if files, err := filepath.Glob(filepath.Join(idx.rootPath, "index", "*", "*.tsi")); err != nil {
+		t.Fatal(err)
+	} else if len(files) == 0 {
+		t.Fatal("expected log file to be compacted")
+	}
+
+	for _, tt := range []struct {
+		expr string
+		n    int
+	}{
+		{expr: `host =~ /^server-01[0-9][0-9]$/`, n: 100},
+		{expr: `host =~ /server-(0042|1999)/`, n: 2},
+		{expr: `host =~ /erver-00/`, n: 100},
+		{expr: `host =~ /^server-5/`, n: 0},
+		{expr: `host !~ /^server-01[0-9][0-9]$/`, n: n - 100},
+		{expr: `host !~ /server-(0042|1999)/`, n: n - 2},
+		{expr: `host !~ /^server-5/`, n: n},
+	} {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr := influxql.MustParseExpr(tt.expr)
+			re := expr.(*influxql.BinaryExpr).RHS.(*influxql.RegexLiteral).Val
+			matches := expr.(*influxql.BinaryExpr).Op == influxql.EQREGEX
+
+			itr, err := idx.IndexSet().MeasurementSeriesByExprIterator([]byte("cpu"), expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// A nil iterator matches no series.
+			var got int
+			for itr != nil {
+				e, err := itr.Next()
+				if err != nil {
+					t.Fatal(err)
+				} else if e.SeriesID == 0 {
+					itr.Close()
+					break
+				}
+
+				_, tags := tsdb.ParseSeriesKey(idx.sfile.SeriesKey(e.SeriesID))
+				if host := tags.GetString("host"); re.MatchString(host) != matches {
+					t.Fatalf("unexpected host: %s", host)
+				}
+				got++
+			}
+			if got != tt.n {
+				t.Fatalf("got %d series, expected %d", got, tt.n)
+			}
+		})
+	}
+}
+
 func TestIndex_Sketches(t *testing.T) {
 	checkCardinalities := func(t *testing.T, index *Index, state string, series, tseries, measurements, tmeasurements int) {
 		// Get sketches and check cardinality...
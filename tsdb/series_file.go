@@ -125,6 +125,40 @@ func (f *SeriesFile) DisableCompactions() {
 	}
 }
 
+// CompactSegments rewrites the segments of each partition in which at least
+// threshold of the insert entries belong to deleted series.  Partitions are
+// compacted one at a time and the series file remains online throughout.
+//
+// Replaced segments are unmapped once all references to the series file are
+// released, so it must not be called while holding a reference.
+func (f *SeriesFile) CompactSegments(threshold float64) error {
+	for _, p := range f.partitions {
+		release := f.Retain()
+		ratio, err := p.DeletedSeriesRatio()
+		release()
+		if err != nil {
+			return err
+		} else if ratio == 0 || ratio < threshold {
+			continue
+		}
+
+		// Skip partitions that cannot be compacted right now.
+		if err := p.CompactSegments(); err == ErrSeriesPartitionCompactionDisabled || err == ErrSeriesPartitionCompactionRunning {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// Unmap replaced segments once no reader can reference their data.
+		retired := p.retiredSegments()
+		f.Wait()
+		if err := p.closeRetiredSegments(retired); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Wait waits for all Retains to be released.
 func (f *SeriesFile) Wait() {
 	f.refs.Lock()
@@ -1,9 +1,11 @@
 package tsdb_test
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/influxdata/influxdb/logger"
@@ -86,6 +88,101 @@ func TestSeriesFileCompactor(t *testing.T) {
 	}
 }
 
+// Ensure segment compaction removes deleted series while keeping the rest.
+func TestSeriesFile_CompactSegments(t *testing.T) {
+	sfile := MustOpenSeriesFile()
+	defer sfile.Close()
+
+	var names [][]byte
+	var tagsSlice []models.Tags
+	for i := 0; i < 1000; i++ {
+		names = append(names, []byte(fmt.Sprintf("m%d", i)))
+		tagsSlice = append(tagsSlice, models.NewTags(map[string]string{"foo": "bar"}))
+	}
+	ids, err := sfile.CreateSeriesListIfNotExists(names, tagsSlice, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Delete every other series & track the highest id in the first series' partition.
+	var maxID uint64
+	for i, id := range ids {
+		if sfile.SeriesIDPartitionID(id) == sfile.SeriesIDPartitionID(ids[0]) && id > maxID {
+			maxID = id
+		}
+		if i%2 == 0 {
+			if err := sfile.DeleteSeriesID(id); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if err := sfile.CompactSegments(0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Verify series before and after reopening the series file.
+	for n := 0; n < 2; n++ {
+		for i := range names {
+			id := sfile.SeriesID(names[i], tagsSlice[i], nil)
+			if i%2 == 0 && id != 0 {
+				t.Fatalf("deleted series exists: %s", names[i])
+			} else if i%2 == 1 && id != ids[i] {
+				t.Fatalf("unexpected series id for %s: got %d, exp %d", names[i], id, ids[i])
+			} else if i%2 == 1 {
+				if name, _ := sfile.Series(id); !bytes.Equal(name, names[i]) {
+					t.Fatalf("unexpected series name: %s", name)
+				}
+			}
+		}
+
+		if err := sfile.SeriesFile.Close(); err != nil {
+			t.Fatal(err)
+		}
+		sfile.SeriesFile = tsdb.NewSeriesFile(sfile.Path())
+		if err := sfile.Open(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Verify only live series and the highest id of each partition remain on disk.
+	var insertN int
+	for _, p := range sfile.Partitions() {
+		fis, err := ioutil.ReadDir(p.Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, fi := range fis {
+			segmentID, err := tsdb.ParseSeriesSegmentFilename(fi.Name())
+			if err != nil {
+				continue
+			}
+			segment := tsdb.NewSeriesSegment(segmentID, filepath.Join(p.Path(), fi.Name()))
+			if err := segment.Open(); err != nil {
+				t.Fatal(err)
+			}
+			segment.ForEachEntry(func(flag uint8, _ uint64, _ int64, _ []byte) error {
+				if flag == tsdb.SeriesEntryInsertFlag {
+					insertN++
+				}
+				return nil
+			})
+			segment.Close()
+		}
+	}
+	if insertN < len(names)/2 || insertN > len(names)/2+tsdb.SeriesFilePartitionN {
+		t.Fatalf("unexpected insert entry count: %d", insertN)
+	}
+
+	// Verify new series are assigned new ids.
+	newIDs, err := sfile.CreateSeriesListIfNotExists([][]byte{names[0]}, []models.Tags{tagsSlice[0]}, nil)
+	if err != nil {
+		t.Fatal(err)
+	} else if newIDs[0] <= maxID {
+		t.Fatalf("series id reused: %d", newIDs[0])
+	}
+}
+
 // Series represents name/tagset pairs that are used in testing.
 type Series struct {
 	Name    []byte
@@ -0,0 +1,305 @@
+package tsdb
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/estimator/hll"
+)
+
+// SeriesLimitsDatabaseKey is the key of the series-limits of a database
+// limiting all of its series instead of the series of a measurement.
+const SeriesLimitsDatabaseKey = "*"
+
+// Statistics gathered by the series limiter.
+const (
+	statSeriesLimit        = "limit"         // configured maximum number of series
+	statSeriesLimitSeries  = "numSeries"     // estimated number of series
+	statSeriesLimitDropped = "seriesDropped" // number of new series rejected
+)
+
+// SeriesLimiter enforces the series limits of a database when series are
+// created.  The numbers of series of the database and of its limited
+// measurements are estimated with HyperLogLog sketches shared by the indexes
+// of all the shards of the database.  Adding a series that was already
+// counted does not change the estimates, so each index adds its existing
+// series when it is opened.  As sketches cannot forget series, the estimates
+// are rebuilt from the remaining series when series are dropped.
+type SeriesLimiter struct {
+	mu           sync.Mutex
+	database     string
+	config       Config
+	series       *seriesLimit
+	measurements map[string]*seriesLimit
+
+	// Sketches being rebuilt, which also count the series added meanwhile.
+	rebuildMu sync.Mutex
+	rebuild   *seriesLimitSketches
+}
+
+// seriesLimitSketches holds new sketches of the database and of its limited
+// measurements.
+type seriesLimitSketches struct {
+	series       *hll.Plus
+	measurements map[*seriesLimit]*hll.Plus
+}
+
+// add counts key in the sketches of the database and of measurement m, if
+// not nil.
+func (s *seriesLimitSketches) add(key []byte, m *seriesLimit) {
+	s.series.Add(key)
+	if m == nil {
+		return
+	}
+	sketch := s.measurements[m]
+	if sketch == nil {
+		sketch = hll.NewDefaultPlus()
+		s.measurements[m] = sketch
+	}
+	sketch.Add(key)
+}
+
+// seriesLimit holds the estimated series of a database or measurement.
+type seriesLimit struct {
+	limit   int
+	sketch  *hll.Plus
+	dropped int64
+}
+
+func newSeriesLimit(limit int) *seriesLimit {
+	return &seriesLimit{limit: limit, sketch: hll.NewDefaultPlus()}
+}
+
+// exceeded returns true if n series reach the limit.
+func (l *seriesLimit) exceeded(n uint64) bool {
+	return l.limit > 0 && n >= uint64(l.limit)
+}
+
+// NewSeriesLimiter returns a new limiter for the series limits of database
+// in config.
+func NewSeriesLimiter(database string, config Config) *SeriesLimiter {
+	return &SeriesLimiter{
+		database:     database,
+		config:       config,
+		series:       newSeriesLimit(config.SeriesLimit(database, "")),
+		measurements: make(map[string]*seriesLimit),
+	}
+}
+
+// measurement returns the series of the measurement, or nil if its series are
+// not limited.  It must be called under lock.
+func (l *SeriesLimiter) measurement(name []byte) *seriesLimit {
+	if m := l.measurements[string(name)]; m != nil {
+		return m
+	}
+
+	limit := l.config.SeriesLimit(l.database, string(name))
+	if limit <= 0 {
+		return nil
+	}
+	m := newSeriesLimit(limit)
+	l.measurements[string(name)] = m
+	return m
+}
+
+// Add counts the existing series with the encoded series key.
+func (l *SeriesLimiter) Add(key []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.add(key, l.measurement(seriesKeyMeasurement(key)))
+}
+
+// add counts key in the sketches of the database and of measurement m, if
+// not nil.  It must be called under lock.
+func (l *SeriesLimiter) add(key []byte, m *seriesLimit) {
+	l.series.sketch.Add(key)
+	if m != nil {
+		m.sketch.Add(key)
+	}
+	if l.rebuild != nil {
+		l.rebuild.add(key, m)
+	}
+}
+
+// Rebuild replaces the estimates with new ones counting the series passed by
+// fn to add, usually all the series of the indexes of the database, and the
+// series added until fn returns.  The estimates are kept if fn fails.
+func (l *SeriesLimiter) Rebuild(fn func(add func(key []byte)) error) error {
+	l.rebuildMu.Lock()
+	defer l.rebuildMu.Unlock()
+
+	sketches := &seriesLimitSketches{
+		series:       hll.NewDefaultPlus(),
+		measurements: make(map[*seriesLimit]*hll.Plus),
+	}
+	l.mu.Lock()
+	l.rebuild = sketches
+	l.mu.Unlock()
+
+	err := fn(func(key []byte) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		sketches.add(key, l.measurement(seriesKeyMeasurement(key)))
+	})
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rebuild = nil
+	if err != nil {
+		return err
+	}
+
+	l.series.sketch = sketches.series
+	for _, m := range l.measurements {
+		if sketch := sketches.measurements[m]; sketch != nil {
+			m.sketch = sketch
+		} else {
+			m.sketch = hll.NewDefaultPlus()
+		}
+	}
+	return nil
+}
+
+// Reserve counts the new series with the encoded series keys unless they
+// exceed the limit of the database or of their measurement.  It returns the
+// indexes of the rejected keys and the reason the first key was rejected.
+func (l *SeriesLimiter) Reserve(keys [][]byte) (rejected []int, reason string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Estimates are only read once and incremented for each accepted series.
+	seriesN := l.series.sketch.Count()
+	measurementN := make(map[*seriesLimit]uint64)
+
+	// Duplicate keys share the fate of their first occurrence.
+	accepted := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		if ok, seen := accepted[string(key)]; seen {
+			if !ok {
+				rejected = append(rejected, i)
+			}
+			continue
+		}
+
+		name := seriesKeyMeasurement(key)
+		m := l.measurement(name)
+		if m != nil {
+			if _, ok := measurementN[m]; !ok {
+				measurementN[m] = m.sketch.Count()
+			}
+		}
+
+		if l.series.exceeded(seriesN) {
+			l.series.dropped++
+			if reason == "" {
+				reason = fmt.Sprintf("max-series-per-database limit exceeded (%d/%d): database=%q measurement=%q",
+					seriesN, l.series.limit, l.database, name)
+			}
+		} else if m != nil && m.exceeded(measurementN[m]) {
+			m.dropped++
+			if reason == "" {
+				reason = fmt.Sprintf("max-series-per-measurement limit exceeded (%d/%d): database=%q measurement=%q",
+					measurementN[m], m.limit, l.database, name)
+			}
+		} else {
+			accepted[string(key)] = true
+			l.add(key, m)
+			seriesN++
+			if m != nil {
+				measurementN[m]++
+			}
+			continue
+		}
+
+		accepted[string(key)] = false
+		rejected = append(rejected, i)
+	}
+	return rejected, reason
+}
+
+// Statistics returns the limits and the estimated series of the database and
+// of its limited measurements.
+func (l *SeriesLimiter) Statistics(tags map[string]string) []models.Statistic {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	statistics := make([]models.Statistic, 0, len(l.measurements)+1)
+	if l.series.limit > 0 {
+		statistics = append(statistics, l.series.statistic(models.StatisticTags{
+			"database": l.database,
+		}.Merge(tags)))
+	}
+	for name, m := range l.measurements {
+		statistics = append(statistics, m.statistic(models.StatisticTags{
+			"database":    l.database,
+			"measurement": name,
+		}.Merge(tags)))
+	}
+	return statistics
+}
+
+// SeriesLimitInfo holds the series limit of a database or of one of its
+// measurements and the estimated number of its series.
+type SeriesLimitInfo struct {
+	// Measurement is the name of the measurement, or SeriesLimitsDatabaseKey
+	// for the limit of all the series of the database.
+	Measurement string
+	Limit       int
+	SeriesN     int64
+	Dropped     int64
+}
+
+// Limits returns the limit and estimated series of the database, if it is
+// limited, followed by those of its limited measurements sorted by name.
+// Measurements with a limit in the series-limits setting are included before
+// any of their series are written.
+func (l *SeriesLimiter) Limits() []SeriesLimitInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var a []SeriesLimitInfo
+	for name, m := range l.measurements {
+		a = append(a, m.info(name))
+	}
+	for name, limit := range l.config.SeriesLimits[l.database] {
+		if _, ok := l.measurements[name]; !ok && name != SeriesLimitsDatabaseKey && limit > 0 {
+			a = append(a, SeriesLimitInfo{Measurement: name, Limit: limit})
+		}
+	}
+	sort.Slice(a, func(i, j int) bool { return a[i].Measurement < a[j].Measurement })
+
+	if l.series.limit > 0 {
+		a = append([]SeriesLimitInfo{l.series.info(SeriesLimitsDatabaseKey)}, a...)
+	}
+	return a
+}
+
+func (l *seriesLimit) info(name string) SeriesLimitInfo {
+	return SeriesLimitInfo{
+		Measurement: name,
+		Limit:       l.limit,
+		SeriesN:     int64(l.sketch.Count()),
+		Dropped:     l.dropped,
+	}
+}
+
+func (l *seriesLimit) statistic(tags map[string]string) models.Statistic {
+	return models.Statistic{
+		Name: "seriesLimits",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statSeriesLimit:        l.limit,
+			statSeriesLimitSeries:  int64(l.sketch.Count()),
+			statSeriesLimitDropped: l.dropped,
+		},
+	}
+}
+
+// seriesKeyMeasurement returns the measurement name of an encoded series key.
+func seriesKeyMeasurement(key []byte) []byte {
+	_, data := ReadSeriesKeyLen(key)
+	name, _ := ReadSeriesKeyMeasurement(data)
+	return name
+}
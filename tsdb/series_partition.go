@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
@@ -18,6 +19,8 @@ import (
 var (
 	ErrSeriesPartitionClosed              = errors.New("tsdb: series partition closed")
 	ErrSeriesPartitionCompactionCancelled = errors.New("tsdb: series partition compaction cancelled")
+	ErrSeriesPartitionCompactionDisabled  = errors.New("tsdb: series partition compactions disabled")
+	ErrSeriesPartitionCompactionRunning   = errors.New("tsdb: series partition compaction already running")
 )
 
 // DefaultSeriesPartitionCompactThreshold is the number of series IDs to hold in the in-memory
@@ -36,12 +39,21 @@ type SeriesPartition struct {
 	once    sync.Once
 
 	segments []*SeriesSegment
+	retired  []*SeriesSegment // replaced segments, unmapped once released
 	index    *SeriesIndex
 	seq      uint64 // series id sequence
 
 	compacting          bool
 	compactionsDisabled int
 
+	// Number of insert entries and of reclaimable entries of deleted series
+	// in the segments.  Entries before countOffset are only counted once
+	// countsLoaded is set by DeletedSeriesRatio.
+	countOffset  int64
+	countsLoaded bool
+	insertN      uint64
+	deletedN     uint64
+
 	CompactThreshold int
 
 	Logger *zap.Logger
@@ -72,14 +84,21 @@ func (p *SeriesPartition) Open() error {
 
 	// Open components.
 	if err := func() (err error) {
+		// Remove files left by interrupted compactions.
+		if err := p.removeCompactionFiles(); err != nil {
+			return err
+		}
+
 		if err := p.openSegments(); err != nil {
 			return err
 		}
 
 		// Init last segment for writes.
-		if err := p.activeSegment().InitForWrite(); err != nil {
+		segment := p.activeSegment()
+		if err := segment.InitForWrite(); err != nil {
 			return err
 		}
+		p.countOffset = JoinSeriesOffset(segment.ID(), uint32(segment.Size()))
 
 		p.index = NewSeriesIndex(p.IndexPath())
 		if err := p.index.Open(); err != nil {
@@ -97,6 +116,21 @@ func (p *SeriesPartition) Open() error {
 	return nil
 }
 
+// removeCompactionFiles removes the temporary segment and index files of
+// compactions that did not complete.
+func (p *SeriesPartition) removeCompactionFiles() error {
+	paths, err := filepath.Glob(filepath.Join(p.path, "*.compacting*"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *SeriesPartition) openSegments() error {
 	fis, err := ioutil.ReadDir(p.path)
 	if err != nil {
@@ -144,7 +178,12 @@ func (p *SeriesPartition) Close() (err error) {
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.closeNoLock()
+}
 
+// closeNoLock unmaps the data files.  The partition lock must be held.
+func (p *SeriesPartition) closeNoLock() (err error) {
+	p.once.Do(func() { close(p.closing) })
 	p.closed = true
 
 	for _, s := range p.segments {
@@ -154,6 +193,13 @@ func (p *SeriesPartition) Close() (err error) {
 	}
 	p.segments = nil
 
+	for _, s := range p.retired {
+		if e := s.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	p.retired = nil
+
 	if p.index != nil {
 		if e := p.index.Close(); e != nil && err == nil {
 			err = e
@@ -302,6 +348,7 @@ func (p *SeriesPartition) DeleteSeriesID(id uint64) error {
 	if err != nil {
 		return err
 	}
+	p.deletedN++
 
 	// Mark tombstone in memory.
 	p.index.Delete(id)
@@ -369,6 +416,115 @@ func (p *SeriesPartition) SeriesCount() uint64 {
 	return n
 }
 
+// CompactSegments rewrites the partition's segments without the entries of
+// deleted series and rebuilds the index.  The partition remains online while
+// the segments are rewritten.
+func (p *SeriesPartition) CompactSegments() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrSeriesPartitionClosed
+	} else if !p.compactionsEnabled() {
+		p.mu.Unlock()
+		return ErrSeriesPartitionCompactionDisabled
+	} else if p.compacting {
+		p.mu.Unlock()
+		return ErrSeriesPartitionCompactionRunning
+	}
+	p.compacting = true
+	p.wg.Add(1)
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		p.compacting = false
+		p.mu.Unlock()
+		p.wg.Done()
+	}()
+
+	log, logEnd := logger.NewOperation(p.Logger, "Series partition segment compaction", "series_partition_segment_compaction", zap.String("path", p.path))
+	defer logEnd()
+
+	compactor := NewSeriesPartitionCompactor()
+	compactor.cancel = p.closing
+	if err := compactor.CompactSegments(p); err != nil {
+		log.Error("series partition segment compaction failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DeletedSeriesRatio returns the fraction of insert entries in the segments
+// that belong to deleted series and can be removed by CompactSegments.  The
+// segments are scanned on the first call so the series file must be retained.
+func (p *SeriesPartition) DeletedSeriesRatio() (float64, error) {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return 0, ErrSeriesPartitionClosed
+	}
+	loaded, end := p.countsLoaded, p.countOffset
+	segments := CloneSeriesSegments(p.segments)
+	p.mu.RUnlock()
+
+	// Count entries written before the partition was opened or compacted.
+	if !loaded {
+		stats, err := readSeriesEntryStats(segments, end, p.closing)
+		if err != nil {
+			return 0, err
+		}
+
+		p.mu.Lock()
+		if !p.countsLoaded && p.countOffset == end {
+			p.insertN += stats.insertN
+			p.deletedN += stats.deletedN
+			p.countsLoaded = true
+		}
+		p.mu.Unlock()
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if !p.countsLoaded || p.insertN == 0 {
+		return 0, nil
+	}
+	return float64(p.deletedN) / float64(p.insertN), nil
+}
+
+// retiredSegments returns the segments replaced by compactions.
+func (p *SeriesPartition) retiredSegments() []*SeriesSegment {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]*SeriesSegment(nil), p.retired...)
+}
+
+// closeRetiredSegments unmaps segments replaced by compactions.  No reader may
+// still reference their data.
+func (p *SeriesPartition) closeRetiredSegments(segments []*SeriesSegment) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	retired := p.retired[:0]
+	for _, s := range p.retired {
+		if !containsSeriesSegment(segments, s) {
+			retired = append(retired, s)
+		} else if e := s.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	p.retired = retired
+	return err
+}
+
+func containsSeriesSegment(a []*SeriesSegment, segment *SeriesSegment) bool {
+	for _, s := range a {
+		if s == segment {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *SeriesPartition) DisableCompactions() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -382,7 +538,7 @@ func (p *SeriesPartition) EnableCompactions() {
 	if p.compactionsEnabled() {
 		return
 	}
-	p.compactionsDisabled++
+	p.compactionsDisabled--
 }
 
 func (p *SeriesPartition) compactionsEnabled() bool {
@@ -413,6 +569,7 @@ func (p *SeriesPartition) insert(key []byte) (id uint64, offset int64, err error
 	}
 
 	p.seq += SeriesFilePartitionN
+	p.insertN++
 	return id, offset, nil
 }
 
@@ -528,6 +685,365 @@ func (c *SeriesPartitionCompactor) Compact(p *SeriesPartition) error {
 	return nil
 }
 
+// CompactSegments rewrites the segments without the entries of deleted series
+// and rebuilds the series partition index from them.
+//
+// Entries written before the compaction starts are rewritten without holding
+// the partition lock.  Entries written since are copied under lock when the
+// segments are swapped.  Tombstones are retained, as is the entry of the highest
+// series id so the id sequence cannot go backwards on reopen.  Replaced segments
+// remain mapped until released since returned series keys reference their data.
+func (c *SeriesPartitionCompactor) CompactSegments(p *SeriesPartition) error {
+	// Snapshot the segments & the end of their flushed entries.
+	p.mu.Lock()
+	segment := p.activeSegment()
+	if err := segment.Flush(); err != nil {
+		p.mu.Unlock()
+		return err
+	}
+	end := JoinSeriesOffset(segment.ID(), uint32(segment.Size()))
+	segments := CloneSeriesSegments(p.segments)
+	p.mu.Unlock()
+
+	// Skip compaction if no entry can be removed.
+	stats, err := readSeriesEntryStats(segments, end, c.cancel)
+	if err != nil {
+		return err
+	} else if stats.deletedN == 0 {
+		return nil
+	}
+
+	// Rewrite segments to a temporary location.
+	w := newSeriesSegmentCompactWriter(p.path, segments)
+	if err := c.compactSegmentsTo(w, segments, end, stats); err != nil {
+		w.remove()
+		return err
+	}
+
+	// Rebuild the index from the compacted segments.
+	seriesN := stats.insertN - stats.deletedN
+	if _, ok := stats.deleted[stats.maxSeriesID]; ok {
+		seriesN--
+	}
+	index := &SeriesIndex{maxOffset: math.MaxInt64, tombstones: stats.deleted}
+	indexPath := p.IndexPath() + ".compacting"
+	if err := c.compactIndexTo(index, seriesN, w.segments, indexPath); err != nil {
+		w.remove()
+		return err
+	}
+
+	// Swap compacted segments & index under lock & replay since compaction.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		w.remove()
+		return ErrSeriesPartitionClosed
+	}
+
+	// Copy entries written since the compaction started.
+	if err := c.copySegmentsTo(w, p.segments, end); err != nil {
+		w.remove()
+		return err
+	}
+
+	// The partition cannot be restored once the index is removed so close it
+	// if the swap fails.  An interrupted swap is recovered on reopen.
+	if err := c.swapSegments(p, w, indexPath); err != nil {
+		p.Logger.Error("Cannot swap compacted series segments", zap.Error(err))
+		p.closeNoLock()
+		return err
+	}
+	return nil
+}
+
+// compactSegmentsTo writes the entries before end that are not deleted to w.
+func (c *SeriesPartitionCompactor) compactSegmentsTo(w *seriesSegmentCompactWriter, segments []*SeriesSegment, end int64, stats seriesEntryStats) error {
+	var entryN int
+	errDone := errors.New("done")
+	for _, segment := range segments {
+		if err := segment.ForEachEntry(func(flag uint8, id uint64, offset int64, key []byte) error {
+			// Stop at the offset where the compaction began.
+			if offset >= end {
+				return errDone
+			}
+
+			// Check for cancellation periodically.
+			if entryN++; entryN%1000 == 0 {
+				select {
+				case <-c.cancel:
+					return ErrSeriesPartitionCompactionCancelled
+				default:
+				}
+			}
+
+			// Skip insert entries of deleted series.
+			switch flag {
+			case SeriesEntryInsertFlag:
+				if _, ok := stats.deleted[id]; ok && id != stats.maxSeriesID {
+					return nil
+				}
+			case SeriesEntryTombstoneFlag:
+			default:
+				return fmt.Errorf("unexpected series partition log entry flag: %d", flag)
+			}
+			return w.write(flag, id, key)
+		}); err == errDone {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+// copySegmentsTo writes all entries from end onwards to w.
+func (c *SeriesPartitionCompactor) copySegmentsTo(w *seriesSegmentCompactWriter, segments []*SeriesSegment, end int64) error {
+	w.reserve(segments)
+
+	for _, segment := range segments {
+		if err := segment.Flush(); err != nil {
+			return err
+		}
+
+		if err := segment.ForEachEntry(func(flag uint8, id uint64, offset int64, key []byte) error {
+			if offset < end {
+				return nil
+			}
+			return w.write(flag, id, key)
+		}); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+// swapSegments replaces the partition's segments & index with the compacted
+// ones.  The partition lock must be held.
+func (c *SeriesPartitionCompactor) swapSegments(p *SeriesPartition, w *seriesSegmentCompactWriter, indexPath string) error {
+	// Remove the index first so an interrupted swap rebuilds it on open.
+	if err := p.index.Close(); err != nil {
+		return err
+	} else if err := os.Remove(p.IndexPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Replace segments in ascending order.  Entries only move to earlier
+	// positions so an interrupted swap can duplicate entries but never lose them.
+	for i, segment := range p.segments {
+		if err := segment.CloseForWrite(); err != nil {
+			return err
+		}
+
+		path := filepath.Join(p.path, fmt.Sprintf("%04x", segment.ID()))
+		if i < len(w.segments) {
+			if err := os.Rename(w.segments[i].path, path); err != nil {
+				return err
+			}
+			w.segments[i].path = path
+		} else if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	p.retired = append(p.retired, p.segments...)
+	p.segments = w.segments
+
+	// Init last segment for writes & reset entry counts.
+	segment := p.activeSegment()
+	if err := segment.InitForWrite(); err != nil {
+		return err
+	}
+	p.countOffset = JoinSeriesOffset(segment.ID(), uint32(segment.Size()))
+	p.countsLoaded, p.insertN, p.deletedN = false, 0, 0
+
+	// Reopen index with new file & replay entries since compaction.
+	if err := os.Rename(indexPath, p.IndexPath()); err != nil {
+		return err
+	} else if err := p.index.Open(); err != nil {
+		return err
+	} else if err := p.index.Recover(p.segments); err != nil {
+		return err
+	}
+	return nil
+}
+
+// seriesEntryStats summarizes the entries of a set of segments.
+type seriesEntryStats struct {
+	insertN     uint64              // insert entries
+	deletedN    uint64              // removable insert entries of deleted series
+	maxSeriesID uint64              // highest inserted series id
+	deleted     map[uint64]struct{} // tombstoned series ids
+}
+
+// readSeriesEntryStats returns stats for the entries of segments before end.
+// The insert entry of the highest series id is never counted as removable.
+func readSeriesEntryStats(segments []*SeriesSegment, end int64, cancel <-chan struct{}) (seriesEntryStats, error) {
+	stats := seriesEntryStats{deleted: make(map[uint64]struct{})}
+
+	var entryN int
+	forEachEntry := func(fn func(flag uint8, id uint64)) error {
+		errDone := errors.New("done")
+		for _, segment := range segments {
+			if err := segment.ForEachEntry(func(flag uint8, id uint64, offset int64, _ []byte) error {
+				if offset >= end {
+					return errDone
+				}
+
+				// Check for cancellation periodically.
+				if entryN++; entryN%1000 == 0 {
+					select {
+					case <-cancel:
+						return ErrSeriesPartitionCompactionCancelled
+					default:
+					}
+				}
+
+				fn(flag, id)
+				return nil
+			}); err == errDone {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Collect tombstones & the highest series id.
+	if err := forEachEntry(func(flag uint8, id uint64) {
+		switch flag {
+		case SeriesEntryInsertFlag:
+			stats.insertN++
+			if id > stats.maxSeriesID {
+				stats.maxSeriesID = id
+			}
+		case SeriesEntryTombstoneFlag:
+			stats.deleted[id] = struct{}{}
+		}
+	}); err != nil {
+		return stats, err
+	}
+
+	// Count insert entries of deleted series.
+	if err := forEachEntry(func(flag uint8, id uint64) {
+		if _, ok := stats.deleted[id]; ok && flag == SeriesEntryInsertFlag && id != stats.maxSeriesID {
+			stats.deletedN++
+		}
+	}); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// seriesSegmentCompactWriter writes compacted segments to temporary paths.
+// Segments reuse the ids of the segments they replace in order, which ensures
+// entries fit since they can only move to earlier positions.
+type seriesSegmentCompactWriter struct {
+	path     string
+	ids      []uint16
+	segments []*SeriesSegment
+	buf      []byte
+}
+
+func newSeriesSegmentCompactWriter(path string, segments []*SeriesSegment) *seriesSegmentCompactWriter {
+	w := &seriesSegmentCompactWriter{path: path}
+	w.reserve(segments)
+	return w
+}
+
+// reserve makes the ids of segments available to the compacted segments.
+func (w *seriesSegmentCompactWriter) reserve(segments []*SeriesSegment) {
+	for _, segment := range segments {
+		if n := len(w.ids); n > 0 && segment.ID() <= w.ids[n-1] {
+			continue
+		}
+		w.ids = append(w.ids, segment.ID())
+	}
+}
+
+// write appends an entry to the last compacted segment, creating a new segment
+// if it does not fit.
+func (w *seriesSegmentCompactWriter) write(flag uint8, id uint64, key []byte) error {
+	w.buf = AppendSeriesEntry(w.buf[:0], flag, id, key)
+
+	segment := w.activeSegment()
+	if segment != nil && segment.w == nil {
+		if err := segment.InitForWrite(); err != nil {
+			return err
+		}
+	}
+
+	if segment == nil || !segment.CanWrite(w.buf) {
+		if segment != nil {
+			if err := w.flush(); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if segment, err = w.createSegment(); err != nil {
+			return err
+		}
+	}
+
+	_, err := segment.WriteLogEntry(w.buf)
+	return err
+}
+
+func (w *seriesSegmentCompactWriter) activeSegment() *SeriesSegment {
+	if len(w.segments) == 0 {
+		return nil
+	}
+	return w.segments[len(w.segments)-1]
+}
+
+func (w *seriesSegmentCompactWriter) createSegment() (*SeriesSegment, error) {
+	if len(w.segments) >= len(w.ids) {
+		return nil, errors.New("tsdb: compacted series segments exceed replaced segments")
+	}
+
+	id := w.ids[len(w.segments)]
+	segment, err := CreateSeriesSegment(id, filepath.Join(w.path, fmt.Sprintf("%04x.compacting", id)))
+	if err != nil {
+		return nil, err
+	}
+	w.segments = append(w.segments, segment)
+
+	if err := segment.InitForWrite(); err != nil {
+		return nil, err
+	}
+	return segment, nil
+}
+
+// flush syncs the last compacted segment to disk and closes its writer.  An
+// empty segment is created if no entry has been written.
+func (w *seriesSegmentCompactWriter) flush() error {
+	segment := w.activeSegment()
+	if segment == nil {
+		var err error
+		if segment, err = w.createSegment(); err != nil {
+			return err
+		}
+	}
+
+	if segment.w == nil {
+		return nil
+	} else if err := segment.Flush(); err != nil {
+		return err
+	} else if err := segment.file.Sync(); err != nil {
+		return err
+	}
+	return segment.CloseForWrite()
+}
+
+// remove closes & removes the compacted segments.
+func (w *seriesSegmentCompactWriter) remove() {
+	for _, segment := range w.segments {
+		segment.Close()
+		os.Remove(segment.path)
+	}
+}
+
 func (c *SeriesPartitionCompactor) compactIndexTo(index *SeriesIndex, seriesN uint64, segments []*SeriesSegment, path string) error {
 	hdr := NewSeriesIndexHeader()
 	hdr.Count = seriesN
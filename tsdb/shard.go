@@ -64,6 +64,14 @@ var (
 	// queries or writes.
 	ErrShardDisabled = errors.New("shard is disabled")
 
+	// errShardMoveCanceled is returned when the move of a shard to another
+	// directory is canceled.
+	errShardMoveCanceled = errors.New("shard move canceled")
+
+	// ErrIndexRebuildInProgress is returned when the index of a shard is
+	// rebuilt while it is already being rebuilt.
+	ErrIndexRebuildInProgress = errors.New("index rebuild already in progress")
+
 	// ErrUnknownFieldsFormat is returned when the fields index file is not identifiable by
 	// the file's magic number.
 	ErrUnknownFieldsFormat = errors.New("unknown field index format")
@@ -135,6 +143,9 @@ type Shard struct {
 	index   Index
 	enabled bool
 
+	// rebuilding is set while the index is rebuilt.
+	rebuilding int32
+
 	// expvar-based stats.
 	stats       *ShardStatistics
 	defaultTags models.StatisticTags
@@ -290,8 +301,13 @@ func (s *Shard) Open() error {
 
 		seriesIDSet := NewSeriesIDSet()
 
-		// Initialize underlying index.
+		// Complete or discard an index rebuild interrupted by a crash.
 		ipath := filepath.Join(s.path, "index")
+		if err := recoverIndexRebuild(ipath); err != nil {
+			return err
+		}
+
+		// Initialize underlying index.
 		idx, err := NewIndex(s.id, s.database, ipath, seriesIDSet, s.sfile, s.options)
 		if err != nil {
 			return err
@@ -397,6 +413,16 @@ func (s *Shard) LastModified() time.Time {
 	return engine.LastModified()
 }
 
+// MaxTime returns the highest timestamp of the values in the shard, or
+// math.MaxInt64 if it is not known because values have not been snapshotted.
+func (s *Shard) MaxTime() (int64, error) {
+	engine, err := s.engine()
+	if err != nil {
+		return 0, err
+	}
+	return engine.MaxTime(), nil
+}
+
 // Index returns a reference to the underlying index. It returns an error if
 // the index is nil.
 func (s *Shard) Index() (Index, error) {
@@ -417,6 +443,17 @@ func (s *Shard) IsIdle() bool {
 	return engine.IsIdle()
 }
 
+// IsFullyCompacted returns true if the shard is open, not receiving writes and
+// fully compacted.  Unlike IsIdle, a shard that is not open is not reported as
+// compacted.
+func (s *Shard) IsFullyCompacted() bool {
+	engine, err := s.engine()
+	if err != nil {
+		return false
+	}
+	return engine.IsIdle()
+}
+
 func (s *Shard) Free() error {
 	engine, err := s.engine()
 	if err != nil {
@@ -452,6 +489,209 @@ func (s *Shard) DiskSize() (int64, error) {
 	return size, nil
 }
 
+// IsCold returns true if the files of the shard have been moved to the cold
+// directory.
+func (s *Shard) IsCold() bool {
+	fi, err := os.Lstat(s.path)
+	return err == nil && fi.Mode()&os.ModeSymlink != 0
+}
+
+// MoveTo moves the files of the shard to dir and replaces the shard directory
+// with a symlink to dir.  The files are copied while the shard is open, and
+// copied again to pick up the files replaced by compactions during the first
+// copy.  The shard is then closed to copy the files changed since, if any, and
+// to swap the directories, and reopened from dir.  Writes and queries to the
+// shard fail while it is closed, which includes reloading its index on reopen
+// and, with the inmem index, rebuilding the index from the TSM files.
+//
+// The move is abandoned and errShardMoveCanceled is returned if cancel is
+// closed or the shard is no longer fully compacted after the first copy.
+func (s *Shard) MoveTo(dir string, cancel <-chan struct{}) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0700); err != nil {
+		return err
+	}
+
+	// Copy the files to a temporary directory so that a partially copied
+	// shard is never found at dir.
+	tmp := dir + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	} else if err := syncShardDir(s.path, tmp, cancel); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+
+	// Keep the time the shard is closed short by not moving a shard that
+	// received writes or was compacted during the copy.
+	if !s.IsFullyCompacted() {
+		os.RemoveAll(tmp)
+		return errShardMoveCanceled
+	} else if err := syncShardDir(s.path, tmp, cancel); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+
+	s.mu.Lock()
+	enabled := s.enabled
+	err = s.close()
+	if err == nil {
+		err = syncShardDir(s.path, tmp, nil)
+	}
+	if err == nil {
+		err = moveShardDir(s.path, tmp, dir)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		os.RemoveAll(tmp)
+	}
+
+	// Reopen the shard from its new location, or its old location if the
+	// files could not be moved.
+	if oerr := s.Open(); err == nil {
+		err = oerr
+	}
+	s.SetEnabled(enabled)
+	return err
+}
+
+// moveShardDir replaces dst with the copy of the shard directory src at tmp,
+// and src with a symlink to dst.  src is kept at src.hot until the symlink
+// has been created, so that recoverShardMove can restore it after a crash.
+func moveShardDir(src, tmp, dst string) error {
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	} else if err := file.RenameFile(tmp, dst); err != nil {
+		return err
+	}
+
+	old := src + ".hot"
+	if err := os.Rename(src, old); err != nil {
+		return err
+	}
+	if err := os.Symlink(dst, src); err != nil {
+		os.Rename(old, src)
+		return err
+	}
+	if err := file.SyncDir(filepath.Dir(src)); err != nil {
+		return err
+	}
+	return os.RemoveAll(old)
+}
+
+// recoverShardMove completes or reverts a move of the shard directory path to
+// the cold directory dst that was interrupted by a crash.
+func recoverShardMove(path, dst string) error {
+	old := path + ".hot"
+	if _, err := os.Stat(old); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(path); err == nil {
+		// The symlink was created, so only the original files are left.
+		return os.RemoveAll(old)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	// The symlink was not created, so the shard is still in its original
+	// directory and the copy is discarded.
+	if dst != "" {
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+	}
+	return os.Rename(old, path)
+}
+
+// syncShardDir copies the files of src to dst that are missing or differ in
+// size or modification time, and removes the files of dst not in src.  Files
+// removed from src while they are copied are skipped.  Returns
+// errShardMoveCanceled if cancel is closed.
+func syncShardDir(src, dst string, cancel <-chan struct{}) error {
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return err
+	}
+
+	fis, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{}, len(fis))
+	for _, fi := range fis {
+		select {
+		case <-cancel:
+			return errShardMoveCanceled
+		default:
+		}
+
+		names[fi.Name()] = struct{}{}
+		srcPath, dstPath := filepath.Join(src, fi.Name()), filepath.Join(dst, fi.Name())
+		if fi.IsDir() {
+			if err := syncShardDir(srcPath, dstPath, cancel); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if dfi, err := os.Stat(dstPath); err == nil && dfi.Size() == fi.Size() && dfi.ModTime().Equal(fi.ModTime()) {
+			continue
+		} else if err := os.RemoveAll(dstPath); err != nil {
+			return err
+		}
+
+		if err := copyFile(srcPath, dstPath); os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		} else if err := os.Chtimes(dstPath, fi.ModTime(), fi.ModTime()); err != nil {
+			return err
+		}
+	}
+
+	dfis, err := ioutil.ReadDir(dst)
+	if err != nil {
+		return err
+	}
+	for _, fi := range dfis {
+		if _, ok := names[fi.Name()]; !ok {
+			if err := os.RemoveAll(filepath.Join(dst, fi.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return file.SyncDir(dst)
+}
+
+// copyFile copies the file src to dst and syncs dst.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	} else if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
 // FieldCreate holds information for a field to create on a measurement.
 type FieldCreate struct {
 	Measurement []byte
@@ -460,6 +700,18 @@ type FieldCreate struct {
 
 // WritePoints will write the raw data points and any new metadata to the index in the shard.
 func (s *Shard) WritePoints(points []models.Point) error {
+	return s.writePoints(points, Engine.WritePoints)
+}
+
+// BulkLoadPoints validates points like WritePoints, then has the engine sort
+// and write them directly to new TSM files instead of its WAL and cache.
+func (s *Shard) BulkLoadPoints(points []models.Point) error {
+	return s.writePoints(points, Engine.BulkLoadPoints)
+}
+
+// writePoints creates the series and fields of points, then writes points to
+// the engine with write.
+func (s *Shard) writePoints(points []models.Point, write func(Engine, []models.Point) error) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -488,7 +740,7 @@ func (s *Shard) WritePoints(points []models.Point) error {
 	}
 
 	// Write to the engine.
-	if err := engine.WritePoints(points); err != nil {
+	if err := write(engine, points); err != nil {
 		atomic.AddInt64(&s.stats.WritePointsErr, int64(len(points)))
 		atomic.AddInt64(&s.stats.WriteReqErr, 1)
 		return fmt.Errorf("engine: %s", err)
@@ -680,7 +932,10 @@ func (s *Shard) createFieldsAndMeasurements(fieldsToCreate []*FieldCreate) error
 
 // DeleteSeriesRange deletes all values from for seriesKeys between min and max (inclusive)
 func (s *Shard) DeleteSeriesRange(itr SeriesIterator, min, max int64) error {
-	engine, err := s.engine()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	engine, err := s.engineNoLock()
 	if err != nil {
 		return err
 	}
@@ -689,13 +944,156 @@ func (s *Shard) DeleteSeriesRange(itr SeriesIterator, min, max int64) error {
 
 // DeleteMeasurement deletes a measurement and all underlying series.
 func (s *Shard) DeleteMeasurement(name []byte) error {
-	engine, err := s.engine()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	engine, err := s.engineNoLock()
 	if err != nil {
 		return err
 	}
 	return engine.DeleteMeasurement(name)
 }
 
+// RebuildIndex builds a new tsi1 index from the series keys of the shard's
+// TSM files and cache, and replaces the current index with it.  The shard
+// keeps serving with the current index during the build; the series created
+// and dropped in the meantime are applied to both indexes.  progress is
+// called with the number of series added to the new index, and the rebuild
+// is aborted if it returns an error.  Writes and deletes are blocked while
+// the indexes are swapped.
+func (s *Shard) RebuildIndex(progress func(seriesN int) error) error {
+	if !atomic.CompareAndSwapInt32(&s.rebuilding, 0, 1) {
+		return ErrIndexRebuildInProgress
+	}
+	defer atomic.StoreInt32(&s.rebuilding, 0)
+
+	engine, err := s.engine()
+	if err != nil {
+		return err
+	}
+
+	// Build the new index next to the current one.
+	path := filepath.Join(s.path, "index")
+	tmpPath := path + ".rebuild"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+
+	opt := s.options
+	opt.IndexVersion = "tsi1"
+	idx, err := NewIndex(s.id, s.database, tmpPath, NewSeriesIDSet(), s.sfile, opt)
+	if err != nil {
+		return err
+	} else if err := idx.Open(); err != nil {
+		return err
+	}
+	idx.WithLogger(s.baseLogger)
+	idx.SetFieldSet(engine.MeasurementFieldSet())
+
+	start := time.Now()
+	s.logger.Info("Rebuilding index", zap.String("path", path))
+	if err := engine.BuildIndex(idx, progress); err != nil {
+		idx.Close()
+		os.RemoveAll(tmpPath)
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.swapIndex(engine, idx, path, tmpPath); err != nil {
+		return err
+	}
+	s.logger.Info("Rebuilt index", zap.String("path", path), zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// swapIndex replaces the current index with idx built at tmpPath by the
+// engine.  The new index is moved to path and reopened.  If the current
+// index cannot be replaced after it was closed, the shard is closed.  It must
+// be called under the shard's write lock.
+func (s *Shard) swapIndex(engine Engine, idx Index, path, tmpPath string) error {
+	abort := func(err error) error {
+		idx.Close()
+		os.RemoveAll(tmpPath)
+		return err
+	}
+
+	// The shard may have been closed or reopened during the build.
+	if s._engine != engine {
+		engine.StopBuildingIndex()
+		return abort(ErrEngineClosed)
+	} else if err := engine.StopBuildingIndex(); err != nil {
+		return abort(err)
+	} else if err := idx.Close(); err != nil {
+		return abort(err)
+	}
+
+	if err := func() error {
+		// Move the current index aside before moving the new one in place so
+		// that recoverIndexRebuild can complete an interrupted swap.
+		oldPath := path + ".old"
+		if err := s.index.Close(); err != nil {
+			return err
+		} else if err := os.RemoveAll(oldPath); err != nil {
+			return err
+		} else if err := os.Rename(path, oldPath); err != nil && !os.IsNotExist(err) {
+			return err
+		} else if err := os.Rename(tmpPath, path); err != nil {
+			return err
+		} else if err := os.RemoveAll(oldPath); err != nil {
+			return err
+		}
+
+		// The existing index directory is opened as a tsi1 index.
+		idx, err := NewIndex(s.id, s.database, path, NewSeriesIDSet(), s.sfile, s.options)
+		if err != nil {
+			return err
+		} else if err := idx.Open(); err != nil {
+			return err
+		}
+		idx.WithLogger(s.baseLogger)
+
+		engine.SetIndex(idx)
+		s.index = idx
+		return nil
+	}(); err != nil {
+		s.close()
+		return NewShardError(s.id, err)
+	}
+	return nil
+}
+
+// recoverIndexRebuild restores the index directory at path after a crash
+// during RebuildIndex.  A rebuilt index is only moved in place once complete,
+// after the current index was moved aside, so the rebuilt index is kept if
+// the current index is gone.  Otherwise incomplete builds are removed.
+func recoverIndexRebuild(path string) error {
+	oldPath, tmpPath := path+".old", path+".rebuild"
+
+	if _, err := os.Stat(oldPath); err == nil {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			// Prefer the complete rebuilt index, then the previous index.
+			if err := os.Rename(tmpPath, path); os.IsNotExist(err) {
+				if err := os.Rename(oldPath, path); err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.RemoveAll(oldPath); err != nil {
+		return err
+	}
+	return os.RemoveAll(tmpPath)
+}
+
 // SeriesN returns the unique number of series in the shard.
 func (s *Shard) SeriesN() int64 {
 	engine, err := s.engine()
@@ -817,6 +1215,57 @@ func (s *Shard) CreateCursor(ctx context.Context, r *CursorRequest) (Cursor, err
 	return engine.CreateCursor(ctx, r)
 }
 
+// IteratorCostFiles returns the cost of an iterator over measurement. If the
+// engine implements IteratorFileCoster, only the files that are not in files
+// are counted and their paths are added to it, so the files shared by several
+// iterators are counted once, and series without values in the shard are not
+// counted.
+func (s *Shard) IteratorCostFiles(measurement string, opt query.IteratorOptions, files map[string]struct{}) (query.IteratorCost, error) {
+	engine, err := s.engine()
+	if err != nil {
+		return query.IteratorCost{}, err
+	}
+
+	if c, ok := engine.(IteratorFileCoster); ok {
+		return c.IteratorCostFiles(measurement, opt, files)
+	}
+	return engine.IteratorCost(measurement, opt)
+}
+
+// HasFieldValues returns true if the field of the series has values between
+// min and max.  If field is empty, the fields of the measurement of the series
+// are checked.  Engines which cannot check this without reading the values
+// report that the series has values.
+func (s *Shard) HasFieldValues(seriesKey []byte, field string, min, max int64) (bool, error) {
+	engine, err := s.engine()
+	if err != nil {
+		return false, err
+	}
+
+	c, ok := engine.(FieldValueChecker)
+	if !ok {
+		return true, nil
+	} else if field != "" {
+		return c.HasFieldValues(seriesKey, field, min, max), nil
+	}
+
+	name, err := models.ParseName(seriesKey)
+	if err != nil {
+		return false, err
+	}
+
+	mf := engine.MeasurementFields(name)
+	if mf == nil {
+		return false, nil
+	}
+	for _, f := range mf.FieldKeys() {
+		if c.HasFieldValues(seriesKey, f, min, max) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // FieldDimensions returns unique sets of fields and dimensions across a list of sources.
 func (s *Shard) FieldDimensions(measurements []string) (fields map[string]influxql.DataType, dimensions map[string]struct{}, err error) {
 	engine, err := s.engine()
@@ -1055,6 +1504,17 @@ func (s *Shard) Import(r io.Reader, basePath string) error {
 	return s._engine.Import(r, basePath)
 }
 
+// IngestTSMFiles validates the externally produced TSM files at paths and
+// adds them to the shard along with their missing series and fields.  The
+// values of the files must lie within minTime and maxTime.
+func (s *Shard) IngestTSMFiles(paths []string, minTime, maxTime int64) error {
+	engine, err := s.engine()
+	if err != nil {
+		return err
+	}
+	return engine.IngestTSMFiles(paths, minTime, maxTime)
+}
+
 // CreateSnapshot will return a path to a temp directory
 // containing hard links to the underlying shard files.
 func (s *Shard) CreateSnapshot() (string, error) {
@@ -3,6 +3,7 @@ package tsdb_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -27,6 +28,7 @@ import (
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/tsdb"
 	_ "github.com/influxdata/influxdb/tsdb/engine"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
 	_ "github.com/influxdata/influxdb/tsdb/index"
 	"github.com/influxdata/influxdb/tsdb/index/inmem"
 	"github.com/influxdata/influxql"
@@ -209,6 +211,103 @@ func TestMaxSeriesLimit(t *testing.T) {
 	sh.Close()
 }
 
+// Ensure the index of a shard can be rebuilt while it is written.
+func TestShard_RebuildIndex(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			dir, cleanup := MustTempDir()
+			defer cleanup()
+
+			sfile := MustOpenSeriesFile()
+			defer sfile.Close()
+
+			opt := tsdb.NewEngineOptions()
+			opt.IndexVersion = index
+			opt.Config.WALDir = filepath.Join(dir, "wal")
+			if index == "inmem" {
+				opt.InmemIndex = inmem.NewIndex(path.Base(dir), sfile.SeriesFile)
+			}
+			opt.SeriesIDSets = seriesIDSets([]*tsdb.SeriesIDSet{tsdb.NewSeriesIDSet()})
+
+			sh := tsdb.NewShard(1,
+				filepath.Join(dir, "data", "db0", "rp0", "1"),
+				filepath.Join(dir, "wal", "db0", "rp0", "1"),
+				sfile.SeriesFile,
+				opt,
+			)
+			if err := sh.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer sh.Close()
+
+			point := func(host string) models.Point {
+				return models.MustNewPoint(
+					"cpu",
+					models.NewTags(map[string]string{"host": host}),
+					map[string]interface{}{"value": 1.0},
+					time.Unix(1, 0),
+				)
+			}
+			if err := sh.WritePoints([]models.Point{point("A"), point("B")}); err != nil {
+				t.Fatal(err)
+			}
+
+			// An aborted rebuild keeps the current index.
+			errAbort := errors.New("abort")
+			if err := sh.RebuildIndex(func(int) error { return errAbort }); err != errAbort {
+				t.Fatalf("unexpected error: %v", err)
+			} else if got, exp := sh.IndexType(), index; got != exp {
+				t.Fatalf("unexpected index type: got %s, exp %s", got, exp)
+			}
+
+			// Series created during the rebuild are added to the new index.
+			if err := sh.RebuildIndex(func(seriesN int) error {
+				if seriesN != 2 {
+					t.Errorf("unexpected progress: %d", seriesN)
+				}
+				return sh.WritePoints([]models.Point{point("C")})
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 2; i++ {
+				if got, exp := sh.IndexType(), "tsi1"; got != exp {
+					t.Fatalf("unexpected index type: got %s, exp %s", got, exp)
+				} else if got, exp := sh.SeriesN(), int64(3); got != exp {
+					t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+				}
+
+				// The rebuilt index is used after reopening the shard.
+				if err := sh.Close(); err != nil {
+					t.Fatal(err)
+				} else if err := sh.Open(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// A swap interrupted after moving the current index aside is
+			// completed when the shard is opened.
+			ipath := filepath.Join(sh.Path(), "index")
+			if err := sh.Close(); err != nil {
+				t.Fatal(err)
+			} else if err := os.Rename(ipath, ipath+".rebuild"); err != nil {
+				t.Fatal(err)
+			} else if err := os.Mkdir(ipath+".old", 0777); err != nil {
+				t.Fatal(err)
+			} else if err := sh.Open(); err != nil {
+				t.Fatal(err)
+			} else if got, exp := sh.SeriesN(), int64(3); got != exp {
+				t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+			}
+			for _, p := range []string{ipath + ".old", ipath + ".rebuild"} {
+				if _, err := os.Stat(p); !os.IsNotExist(err) {
+					t.Fatalf("unexpected file %s: %v", p, err)
+				}
+			}
+		})
+	}
+}
+
 func TestShard_MaxTagValuesLimit(t *testing.T) {
 	tmpDir, _ := ioutil.TempDir("", "shard_test")
 	defer os.RemoveAll(tmpDir)
@@ -642,6 +741,43 @@ func TestShard_WritePoints_FieldConflictConcurrentQuery(t *testing.T) {
 	}
 }
 
+// Ensures that TSM files with fields conflicting with the fields written to
+// the shard are not ingested.
+func TestShard_IngestTSMFiles_FieldTypeConflict(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			sh := MustNewOpenShard(index)
+			defer sh.Close()
+
+			sh.MustWritePointsString(`mem,host=A value=1i 1`)
+
+			path := filepath.Join(sh.path, "upload.tsm")
+			f, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, err := tsm1.NewTSMWriter(f)
+			if err != nil {
+				t.Fatal(err)
+			} else if err := w.Write(tsm1.SeriesFieldKeyBytes("mem,host=B", "value"), []tsm1.Value{tsm1.NewValue(1000000000, 1.1)}); err != nil {
+				t.Fatal(err)
+			} else if err := w.WriteIndex(); err != nil {
+				t.Fatal(err)
+			} else if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			err = sh.IngestTSMFiles([]string{path}, 0, 10000000000)
+			if err == nil || !strings.Contains(err.Error(), tsdb.ErrFieldTypeConflict.Error()) {
+				t.Fatalf("expected field type conflict error, got %v", err)
+			}
+			if n := sh.SeriesN(); n != 1 {
+				t.Fatalf("unexpected series count: %d", n)
+			}
+		})
+	}
+}
+
 // Ensures that when a shard is closed, it removes any series meta-data
 // from the index.
 func TestShard_Close_RemoveIndex(t *testing.T) {
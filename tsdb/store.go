@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -55,12 +56,29 @@ type Store struct {
 	// shared per-database indexes, only if using "inmem".
 	indexes map[string]interface{}
 
+	// shared per-database series limiters, only if series are limited.
+	seriesLimiters map[string]*SeriesLimiter
+
 	// Maintains a set of shards that are in the process of deletion.
 	// This prevents new shards from being created while old ones are being deleted.
 	pendingShardDeletes map[uint64]struct{}
 
+	// Maintains the shards being moved to the cold directory.  The channel
+	// is closed once the move is done.
+	pendingShardMoves map[uint64]chan struct{}
+
 	EngineOptions EngineOptions
 
+	// OnShardChange is called with the time range of the values of a shard
+	// that were changed by the store other than through WriteToShard, such as
+	// by ingested, bulk loaded, restored or imported files, dropped idle
+	// series or a deleted shard, so that cached query results can be
+	// invalidated.
+	OnShardChange func(shardID uint64, min, max int64)
+
+	// compactionThrottle limits the disk throughput of compactions.
+	compactionThrottle *CompactionThrottle
+
 	baseLogger *zap.Logger
 	Logger     *zap.Logger
 
@@ -78,7 +96,9 @@ func NewStore(path string) *Store {
 		path:                path,
 		sfiles:              make(map[string]*SeriesFile),
 		indexes:             make(map[string]interface{}),
+		seriesLimiters:      make(map[string]*SeriesLimiter),
 		pendingShardDeletes: make(map[uint64]struct{}),
+		pendingShardMoves:   make(map[uint64]chan struct{}),
 		EngineOptions:       NewEngineOptions(),
 		Logger:              logger,
 		baseLogger:          logger,
@@ -98,6 +118,10 @@ func (s *Store) WithLogger(log *zap.Logger) {
 func (s *Store) Statistics(tags map[string]string) []models.Statistic {
 	s.mu.RLock()
 	shards := s.shardsSlice()
+	limiters := make([]*SeriesLimiter, 0, len(s.seriesLimiters))
+	for _, l := range s.seriesLimiters {
+		limiters = append(limiters, l)
+	}
 	s.mu.RUnlock()
 
 	// Add all the series and measurements cardinality estimations.
@@ -130,6 +154,11 @@ func (s *Store) Statistics(tags map[string]string) []models.Statistic {
 	for _, shard := range shards {
 		statistics = append(statistics, shard.Statistics(tags)...)
 	}
+
+	// Gather the limits and estimated series of the limited databases.
+	for _, l := range limiters {
+		statistics = append(statistics, l.Statistics(tags)...)
+	}
 	return statistics
 }
 
@@ -165,6 +194,11 @@ func (s *Store) Open() error {
 	s.wg.Add(1)
 	go s.monitorShards()
 
+	if s.compactionThrottle.Adaptive() {
+		s.wg.Add(1)
+		go s.monitorCompactionThroughput()
+	}
+
 	return nil
 }
 
@@ -197,13 +231,24 @@ func (s *Store) loadShards() error {
 
 	s.EngineOptions.CompactionLimiter = limiter.NewFixed(lim)
 
-	// Env var to disable throughput limiter.  This will be moved to a config option in 1.5.
-	if os.Getenv("INFLUXDB_DATA_COMPACTION_THROUGHPUT") == "" {
-		s.EngineOptions.CompactionThroughputLimiter = limiter.NewRate(48*1024*1024, 48*1024*1024)
-	} else {
-		s.Logger.Info("Compaction throughput limit disabled")
+	// The environment variable of earlier versions disables the limits.
+	if os.Getenv("INFLUXDB_DATA_COMPACTION_THROUGHPUT") != "" {
+		s.EngineOptions.Config.CompactThroughput = 0
+		s.EngineOptions.Config.CompactReadThroughput = 0
 	}
 
+	s.compactionThrottle = NewCompactionThrottle(s.EngineOptions.Config)
+	s.EngineOptions.CompactionThroughputLimiter = s.compactionThrottle.Write
+	s.EngineOptions.CompactionReadThroughputLimiter = s.compactionThrottle.Read
+	if s.compactionThrottle.Adaptive() {
+		s.EngineOptions.WALSyncObserver = s.compactionThrottle.ObserveFsyncLatency
+	}
+	s.EngineOptions.BulkLoadObserver = s.shardChanged
+	s.Logger.Info("Compaction throughput limits",
+		zap.Int("read_bytes_per_second", int(s.EngineOptions.Config.CompactReadThroughput)),
+		zap.Int("write_bytes_per_second", int(s.EngineOptions.Config.CompactThroughput)),
+		zap.Bool("adaptive", s.compactionThrottle.Adaptive()))
+
 	log, logEnd := logger.NewOperation(s.Logger, "Open store", "tsdb_open")
 	defer logEnd()
 
@@ -235,6 +280,7 @@ func (s *Store) loadShards() error {
 		if err != nil {
 			return err
 		}
+		limiter := s.seriesLimiter(db.Name())
 
 		// Load each retention policy within the database directory.
 		rpDirs, err := ioutil.ReadDir(dbPath)
@@ -254,6 +300,10 @@ func (s *Store) loadShards() error {
 				continue
 			}
 
+			if err := s.recoverShardMoves(db.Name(), rp.Name()); err != nil {
+				return err
+			}
+
 			shardDirs, err := ioutil.ReadDir(rpPath)
 			if err != nil {
 				return err
@@ -280,6 +330,7 @@ func (s *Store) loadShards() error {
 					// Copy options and assign shared index.
 					opt := s.EngineOptions
 					opt.InmemIndex = idx
+					opt.SeriesLimiter = limiter
 
 					// Provide an implementation of the ShardIDSets
 					opt.SeriesIDSets = shardSet{store: s, db: db}
@@ -414,6 +465,68 @@ func (s *Store) createIndexIfNotExists(name string) (interface{}, error) {
 	return idx, nil
 }
 
+// seriesLimiter returns the series limiter shared by the shards of a
+// database, or nil if its series are not limited.  It must be called under a
+// full lock.
+func (s *Store) seriesLimiter(name string) *SeriesLimiter {
+	if l := s.seriesLimiters[name]; l != nil {
+		return l
+	} else if !s.EngineOptions.Config.SeriesLimited(name) {
+		return nil
+	}
+
+	l := NewSeriesLimiter(name, s.EngineOptions.Config)
+	s.seriesLimiters[name] = l
+	return l
+}
+
+// SeriesLimits returns the series limits of a database and the estimated
+// number of series of the database and of its limited measurements.  Returns
+// nil if the series of the database are not limited.
+func (s *Store) SeriesLimits(database string) []SeriesLimitInfo {
+	s.mu.RLock()
+	l := s.seriesLimiters[database]
+	s.mu.RUnlock()
+
+	if l == nil {
+		return nil
+	}
+	return l.Limits()
+}
+
+// rebuildSeriesLimiter recounts the series of the indexes of the shards of a
+// database in its series limiter, if any, so that dropped series no longer
+// count against its limits.  The estimates are kept if a shard cannot be
+// read, such as while it is being opened.
+func (s *Store) rebuildSeriesLimiter(database string) {
+	s.mu.RLock()
+	l := s.seriesLimiters[database]
+	sfile := s.sfiles[database]
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	if l == nil || sfile == nil {
+		return
+	}
+
+	if err := l.Rebuild(func(add func(key []byte)) error {
+		for _, sh := range shards {
+			ss, err := shardSeriesIDSet(sh)
+			if err != nil {
+				return err
+			}
+			ss.ForEach(func(id uint64) {
+				if key := sfile.SeriesKey(id); key != nil {
+					add(key)
+				}
+			})
+		}
+		return nil
+	}); err != nil {
+		s.Logger.Info("Cannot rebuild series limits", logger.Database(database), zap.Error(err))
+	}
+}
+
 // Shard returns a shard by id.
 func (s *Store) Shard(id uint64) *Shard {
 	s.mu.RLock()
@@ -510,6 +623,7 @@ func (s *Store) CreateShard(database, retentionPolicy string, shardID uint64, en
 	// Copy index options and pass in shared index.
 	opt := s.EngineOptions
 	opt.InmemIndex = idx
+	opt.SeriesLimiter = s.seriesLimiter(database)
 	opt.SeriesIDSets = shardSet{store: s, db: database}
 
 	path := filepath.Join(s.path, database, retentionPolicy, strconv.FormatUint(shardID, 10))
@@ -548,6 +662,118 @@ func (s *Store) SetShardEnabled(shardID uint64, enabled bool) error {
 	return nil
 }
 
+// recoverShardMoves completes or reverts the moves of the shards of a
+// retention policy to the cold directory that were interrupted by a crash, and
+// removes partial copies from the cold directory.
+func (s *Store) recoverShardMoves(database, retentionPolicy string) error {
+	var coldPath string
+	if dir := s.EngineOptions.Config.ColdDir; dir != "" {
+		coldPath = filepath.Join(dir, database, retentionPolicy)
+		tmps, err := filepath.Glob(filepath.Join(coldPath, "*.tmp"))
+		if err != nil {
+			return err
+		}
+		for _, tmp := range tmps {
+			if err := os.RemoveAll(tmp); err != nil {
+				return err
+			}
+		}
+	}
+
+	olds, err := filepath.Glob(filepath.Join(s.path, database, retentionPolicy, "*.hot"))
+	if err != nil {
+		return err
+	}
+	for _, old := range olds {
+		path := strings.TrimSuffix(old, ".hot")
+		var dst string
+		if coldPath != "" {
+			dst = filepath.Join(coldPath, filepath.Base(path))
+		}
+		if err := recoverShardMove(path, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TierShardGroup moves the files of the shards of a shard group to the cold
+// directory if the group ended more than the cold age of its retention policy
+// ago and all of its shards are fully compacted.  Shards that are not stored
+// locally are ignored.  The move of a shard is abandoned if cancel is closed
+// while its files are copied.  Returns the IDs of the moved shards.
+func (s *Store) TierShardGroup(shardIDs []uint64, endTime time.Time, cancel <-chan struct{}) ([]uint64, error) {
+	var shards []*Shard
+	for _, id := range shardIDs {
+		sh := s.Shard(id)
+		if sh == nil {
+			continue
+		}
+
+		age := s.EngineOptions.Config.ColdShardAge(sh.database, sh.retentionPolicy)
+		if age <= 0 || time.Since(endTime) < age {
+			return nil, nil
+		} else if sh.IsCold() {
+			continue
+		} else if !sh.IsFullyCompacted() {
+			// The group is moved once all of its shards are compacted.
+			return nil, nil
+		}
+		shards = append(shards, sh)
+	}
+
+	var moved []uint64
+	for _, sh := range shards {
+		if ok, err := s.tierShard(sh, cancel); err != nil {
+			return moved, err
+		} else if ok {
+			moved = append(moved, sh.id)
+		}
+	}
+	return moved, nil
+}
+
+// tierShard moves the files of sh to the cold directory.  Returns true if the
+// shard was moved.
+func (s *Store) tierShard(sh *Shard, cancel <-chan struct{}) (bool, error) {
+	shardID := sh.id
+
+	// Deleting the shard waits until it has been moved.
+	s.mu.Lock()
+	if _, ok := s.pendingShardDeletes[shardID]; ok {
+		s.mu.Unlock()
+		return false, nil
+	} else if _, ok := s.pendingShardMoves[shardID]; ok {
+		s.mu.Unlock()
+		return false, nil
+	}
+	done := make(chan struct{})
+	s.pendingShardMoves[shardID] = done
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.pendingShardMoves, shardID)
+		close(done)
+	}()
+
+	dir := filepath.Join(s.EngineOptions.Config.ColdDir, sh.database, sh.retentionPolicy, strconv.FormatUint(shardID, 10))
+	if err := sh.MoveTo(dir, cancel); err == errShardMoveCanceled {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	// Release the memory used by the shard after it has been reopened.
+	if sh.IsIdle() {
+		if err := sh.Free(); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
 // DeleteShard removes a shard from disk.
 func (s *Store) DeleteShard(shardID uint64) error {
 	sh := s.Shard(shardID)
@@ -570,8 +796,12 @@ func (s *Store) DeleteShard(shardID uint64) error {
 	}
 	delete(s.shards, shardID)
 	s.pendingShardDeletes[shardID] = struct{}{}
+	moved := s.pendingShardMoves[shardID]
 	s.mu.Unlock()
 
+	// Results of queries cached before the shard was removed no longer apply.
+	s.shardChanged(shardID, math.MinInt64, math.MaxInt64)
+
 	// Ensure the pending deletion flag is cleared on exit.
 	defer func() {
 		s.mu.Lock()
@@ -579,6 +809,11 @@ func (s *Store) DeleteShard(shardID uint64) error {
 		delete(s.pendingShardDeletes, shardID)
 	}()
 
+	// Wait until the shard is no longer being moved to the cold directory.
+	if moved != nil {
+		<-moved
+	}
+
 	// Get the shard's local bitset of series IDs.
 	index, err := sh.Index()
 	if err != nil {
@@ -628,12 +863,20 @@ func (s *Store) DeleteShard(shardID uint64) error {
 		}
 	}
 
-	// Remove the on-disk shard data.
+	// Remove the on-disk shard data, including its files in the cold directory.
+	if target, err := os.Readlink(sh.path); err == nil {
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+	}
 	if err := os.RemoveAll(sh.path); err != nil {
 		return err
+	} else if err := os.RemoveAll(sh.walPath); err != nil {
+		return err
 	}
 
-	return os.RemoveAll(sh.walPath)
+	s.rebuildSeriesLimiter(db)
+	return nil
 }
 
 // DeleteDatabase will close all shards associated with a database and remove the directory and files from disk.
@@ -686,6 +929,11 @@ func (s *Store) DeleteDatabase(name string) error {
 	if err := os.RemoveAll(filepath.Join(s.EngineOptions.Config.WALDir, name)); err != nil {
 		return err
 	}
+	if dir := s.EngineOptions.Config.ColdDir; dir != "" {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
 
 	for _, sh := range shards {
 		delete(s.shards, sh.id)
@@ -696,6 +944,7 @@ func (s *Store) DeleteDatabase(name string) error {
 
 	// Remove shared index for database if using inmem index.
 	delete(s.indexes, name)
+	delete(s.seriesLimiters, name)
 
 	return nil
 }
@@ -745,11 +994,19 @@ func (s *Store) DeleteRetentionPolicy(database, name string) error {
 		return err
 	}
 
+	// Remove the retention policy folder from the cold directory.
+	if dir := s.EngineOptions.Config.ColdDir; dir != "" {
+		if err := os.RemoveAll(filepath.Join(dir, database, name)); err != nil {
+			return err
+		}
+	}
+
 	s.mu.Lock()
 	for _, sh := range shards {
 		delete(s.shards, sh.id)
 	}
 	s.mu.Unlock()
+	s.rebuildSeriesLimiter(database)
 	return nil
 }
 
@@ -762,12 +1019,16 @@ func (s *Store) DeleteMeasurement(database, name string) error {
 	// Limit to 1 delete for each shard since expanding the measurement into the list
 	// of series keys can be very memory intensive if run concurrently.
 	limit := limiter.NewFixed(1)
-	return s.walkShards(shards, func(sh *Shard) error {
+	if err := s.walkShards(shards, func(sh *Shard) error {
 		limit.Take()
 		defer limit.Release()
 
 		return sh.DeleteMeasurement([]byte(name))
-	})
+	}); err != nil {
+		return err
+	}
+	s.rebuildSeriesLimiter(database)
+	return nil
 }
 
 // filterShards returns a slice of shards where fn returns true
@@ -1053,7 +1314,11 @@ func (s *Store) RestoreShard(id uint64, r io.Reader) error {
 		return err
 	}
 
-	return shard.Restore(r, path)
+	if err := shard.Restore(r, path); err != nil {
+		return err
+	}
+	s.shardChanged(id, math.MinInt64, math.MaxInt64)
+	return nil
 }
 
 // ImportShard imports the contents of r to a given shard.
@@ -1071,7 +1336,11 @@ func (s *Store) ImportShard(id uint64, r io.Reader) error {
 		return err
 	}
 
-	return shard.Import(r, path)
+	if err := shard.Import(r, path); err != nil {
+		return err
+	}
+	s.shardChanged(id, math.MinInt64, math.MaxInt64)
+	return nil
 }
 
 // ShardRelativePath will return the relative path to the shard, i.e.,
@@ -1128,7 +1397,7 @@ func (s *Store) DeleteSeries(database string, sources []influxql.Source, conditi
 	// of series keys can be very memory intensive if run concurrently.
 	limit := limiter.NewFixed(1)
 
-	return s.walkShards(shards, func(sh *Shard) error {
+	if err := s.walkShards(shards, func(sh *Shard) error {
 		// Determine list of measurements from sources.
 		// Use all measurements if no FROM clause was provided.
 		var names []string
@@ -1171,7 +1440,150 @@ func (s *Store) DeleteSeries(database string, sources []influxql.Source, conditi
 		}
 
 		return nil
+	}); err != nil {
+		return err
+	}
+	s.rebuildSeriesLimiter(database)
+	return nil
+}
+
+// CompactSeriesFiles rewrites the partitions of each database's series file
+// in which at least threshold of the series entries belong to deleted series.
+func (s *Store) CompactSeriesFiles(threshold float64) error {
+	s.mu.RLock()
+	sfiles := make([]*SeriesFile, 0, len(s.sfiles))
+	for _, sfile := range s.sfiles {
+		sfiles = append(sfiles, sfile)
+	}
+	s.mu.RUnlock()
+
+	for _, sfile := range sfiles {
+		select {
+		case <-s.closing:
+			return ErrStoreClosed
+		default:
+		}
+
+		if err := sfile.CompactSegments(threshold); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteIdleSeries drops the series of retention policies with a
+// series-idle-ttl that have no values newer than the TTL in any shard of their
+// retention policy, together with their remaining values.  Only shards whose
+// newest value is older than the TTL can hold idle series, so other shards are
+// not scanned.  Series no longer in any shard are removed from the series file.
+func (s *Store) DeleteIdleSeries() error {
+	s.mu.RLock()
+	shards := s.filterShards(func(sh *Shard) bool {
+		return s.EngineOptions.Config.SeriesIdleTTL(sh.database, sh.retentionPolicy) > 0
+	})
+	s.mu.RUnlock()
+
+	// Group the shards by retention policy.
+	type rpKey struct{ db, rp string }
+	rps := make(map[rpKey][]*Shard)
+	for _, sh := range shards {
+		k := rpKey{db: sh.database, rp: sh.retentionPolicy}
+		rps[k] = append(rps[k], sh)
+	}
+
+	now := time.Now().UTC()
+	for k, shards := range rps {
+		select {
+		case <-s.closing:
+			return ErrStoreClosed
+		default:
+		}
+
+		cutoff := now.Add(-s.EngineOptions.Config.SeriesIdleTTL(k.db, k.rp)).UnixNano()
+		if err := s.deleteIdleSeries(shards, cutoff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteIdleSeries drops the series of the shards of a retention policy that
+// have no values at or after cutoff in any of the shards.
+func (s *Store) deleteIdleSeries(shards []*Shard, cutoff int64) error {
+	// Split the shards into those that only have values older than cutoff,
+	// which hold the candidates, and the others, whose series are all in use.
+	var idle []*Shard
+	active := NewSeriesIDSet()
+	for _, sh := range shards {
+		max, err := sh.MaxTime()
+		if err == ErrEngineClosed || err == ErrShardDisabled {
+			// The series of the shard are unknown, so check again later.
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if max < cutoff {
+			idle = append(idle, sh)
+			continue
+		}
+
+		ss, err := shardSeriesIDSet(sh)
+		if err != nil {
+			return err
+		}
+		active.Merge(ss)
+	}
+
+	var dropped bool
+	for _, sh := range idle {
+		ss, err := shardSeriesIDSet(sh)
+		if err != nil {
+			return err
+		}
+
+		candidates := ss.AndNot(active)
+		ids := make([]uint64, 0, candidates.Cardinality())
+		candidates.ForEach(func(id uint64) {
+			ids = append(ids, id)
+		})
+		if len(ids) == 0 {
+			continue
+		}
+
+		s.Logger.Info("Dropping idle series",
+			logger.Database(sh.database),
+			logger.RetentionPolicy(sh.retentionPolicy),
+			logger.Shard(sh.id),
+			zap.Int("series", len(ids)))
+		itr := NewSeriesIteratorAdapter(sh.sfile, NewSeriesIDSliceIterator(ids))
+		if err := sh.DeleteSeriesRange(itr, math.MinInt64, math.MaxInt64); err != nil {
+			return err
+		}
+		s.shardChanged(sh.id, math.MinInt64, math.MaxInt64)
+		dropped = true
+	}
+
+	if dropped {
+		s.rebuildSeriesLimiter(idle[0].database)
+	}
+	return nil
+}
+
+// shardSeriesIDSet returns the set of series ids of the index of sh.
+func shardSeriesIDSet(sh *Shard) (*SeriesIDSet, error) {
+	index, err := sh.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := index.(interface {
+		SeriesIDSet() *SeriesIDSet
 	})
+	if !ok {
+		return nil, fmt.Errorf("unable to get series id set for index in shard at %s", sh.Path())
+	}
+	return i.SeriesIDSet(), nil
 }
 
 // ExpandSources expands sources against all local shards.
@@ -1211,6 +1623,88 @@ func (s *Store) WriteToShard(shardID uint64, points []models.Point) error {
 	return sh.WritePoints(points)
 }
 
+// BulkLoadShard writes points to the shard directly to new TSM files,
+// bypassing the WAL and the cache of the shard.  The points are buffered and
+// become visible at once when the files are written.  It is meant for
+// backfilling large amounts of historical or unsorted data.
+func (s *Store) BulkLoadShard(shardID uint64, points []models.Point) error {
+	s.mu.RLock()
+
+	select {
+	case <-s.closing:
+		s.mu.RUnlock()
+		return ErrStoreClosed
+	default:
+	}
+
+	sh := s.shards[shardID]
+	if sh == nil {
+		s.mu.RUnlock()
+		return ErrShardNotFound
+	}
+	s.mu.RUnlock()
+
+	return sh.BulkLoadPoints(points)
+}
+
+// IngestTSMFiles validates the externally produced TSM files at paths and
+// adds them to the shard with shardID without replaying them through the
+// write path.  The values of the files must lie within minTime and maxTime,
+// usually the time range of the shard group.
+func (s *Store) IngestTSMFiles(shardID uint64, paths []string, minTime, maxTime int64) error {
+	s.mu.RLock()
+
+	select {
+	case <-s.closing:
+		s.mu.RUnlock()
+		return ErrStoreClosed
+	default:
+	}
+
+	sh := s.shards[shardID]
+	if sh == nil {
+		s.mu.RUnlock()
+		return ErrShardNotFound
+	}
+	s.mu.RUnlock()
+
+	if err := sh.IngestTSMFiles(paths, minTime, maxTime); err != nil {
+		return err
+	}
+	s.shardChanged(shardID, minTime, maxTime)
+	return nil
+}
+
+// shardChanged calls OnShardChange, if set, for the values of the shard with
+// shardID between min and max.
+func (s *Store) shardChanged(shardID uint64, min, max int64) {
+	if s.OnShardChange != nil {
+		s.OnShardChange(shardID, min, max)
+	}
+}
+
+// RebuildIndex rebuilds the index of a shard from its series keys while the
+// shard keeps serving with its current index.  See Shard.RebuildIndex.
+func (s *Store) RebuildIndex(shardID uint64, progress func(seriesN int) error) error {
+	s.mu.RLock()
+
+	select {
+	case <-s.closing:
+		s.mu.RUnlock()
+		return ErrStoreClosed
+	default:
+	}
+
+	sh := s.shards[shardID]
+	if sh == nil {
+		s.mu.RUnlock()
+		return ErrShardNotFound
+	}
+	s.mu.RUnlock()
+
+	return sh.RebuildIndex(progress)
+}
+
 // MeasurementNames returns a slice of all measurements. Measurements accepts an
 // optional condition expression. If cond is nil, then all measurements for the
 // database will be returned.
@@ -1680,16 +2174,292 @@ func mergeTagValues(valueIdxs [][2]int, tvs ...tagValues) TagValues {
 	return result
 }
 
+// TagKeyIterator returns an iterator over the sorted, distinct tag keys of the
+// series in the provided shards. If cond is not nil, only the tag keys of series
+// matching cond are returned. Unless min and max span all timestamps, only the
+// tag keys of series with values between min and max are returned.
+func (s *Store) TagKeyIterator(shardIDs []uint64, cond influxql.Expr, min, max int64) (TagKeyIterator, error) {
+	is := s.indexSet(shardIDs)
+	if len(is.Indexes) == 0 {
+		return nil, nil
+	}
+
+	release := is.SeriesFile.Retain()
+	defer release()
+
+	names, err := is.MeasurementNamesByExpr(nil, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := seriesValueFilter(s.Shards(shardIDs), is.SeriesFile, "", min, max)
+	itrs := make([]TagKeyIterator, 0, len(names))
+	for _, name := range names {
+		ids, err := is.measurementSeriesIDSetByExpr(name, cond)
+		if err != nil {
+			return nil, err
+		}
+
+		keys, err := is.measurementTagKeysBySeriesIDSet(name, ids, filter)
+		if err != nil {
+			return nil, err
+		} else if len(keys) > 0 {
+			itrs = append(itrs, NewTagKeySliceIterator(keys))
+		}
+	}
+	return MergeTagKeyIterators(itrs...), nil
+}
+
+// TagValueIterator returns an iterator over the sorted, distinct values of the
+// tag key for the series in the provided shards. If cond is not nil, only the
+// tag values of series matching cond are returned. The key "_name" returns the
+// measurement names of the matching series. Unless min and max span all
+// timestamps, only the tag values of series with values between min and max
+// are returned.
+func (s *Store) TagValueIterator(shardIDs []uint64, key string, cond influxql.Expr, min, max int64) (TagValueIterator, error) {
+	is := s.indexSet(shardIDs)
+	if len(is.Indexes) == 0 {
+		return nil, nil
+	}
+
+	release := is.SeriesFile.Retain()
+	defer release()
+
+	names, err := is.MeasurementNamesByExpr(nil, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := seriesValueFilter(s.Shards(shardIDs), is.SeriesFile, "", min, max)
+	if key == "_name" {
+		values := make([][]byte, 0, len(names))
+		for _, name := range names {
+			ids, err := is.measurementSeriesIDSetByExpr(name, cond)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok, err := is.measurementHasSeries(name, ids, filter); err != nil {
+				return nil, err
+			} else if ok {
+				values = append(values, name)
+			}
+		}
+		return NewTagValueSliceIterator(values), nil
+	}
+
+	itrs := make([]TagValueIterator, 0, len(names))
+	for _, name := range names {
+		ids, err := is.measurementSeriesIDSetByExpr(name, cond)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := is.tagValuesBySeriesIDSet(name, []byte(key), ids, filter)
+		if err != nil {
+			return nil, err
+		} else if len(values) > 0 {
+			itrs = append(itrs, NewTagValueSliceIterator(values))
+		}
+	}
+	return MergeTagValueIterators(itrs...), nil
+}
+
+// FieldKeyIterator returns an iterator over the sorted, distinct field keys of
+// the measurements with series in the provided shards. If cond is not nil, only
+// the measurements of series matching cond are included. Unless min and max
+// span all timestamps, only the field keys with values between min and max in
+// a matching series are returned.
+func (s *Store) FieldKeyIterator(shardIDs []uint64, cond influxql.Expr, min, max int64) (TagValueIterator, error) {
+	is := s.indexSet(shardIDs)
+	if len(is.Indexes) == 0 {
+		return nil, nil
+	}
+
+	release := is.SeriesFile.Retain()
+	defer release()
+
+	names, err := is.MeasurementNamesByExpr(nil, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := s.Shards(shardIDs)
+	set := make(map[string]struct{})
+	for _, name := range names {
+		ids, err := is.measurementSeriesIDSetByExpr(name, cond)
+		if err != nil {
+			return nil, err
+		} else if ok, err := is.measurementHasSeries(name, ids, nil); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		for _, sh := range shards {
+			mf := sh.MeasurementFields(name)
+			if mf == nil {
+				continue
+			}
+
+			for _, f := range mf.FieldKeys() {
+				if _, ok := set[f]; ok {
+					continue
+				}
+
+				filter := seriesValueFilter(shards, is.SeriesFile, f, min, max)
+				if ok, err := is.measurementHasSeries(name, ids, filter); err != nil {
+					return nil, err
+				} else if ok {
+					set[f] = struct{}{}
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([][]byte, len(keys))
+	for i, k := range keys {
+		values[i] = []byte(k)
+	}
+	return NewTagValueSliceIterator(values), nil
+}
+
+// seriesValueFilter returns a filter accepting the series with values for
+// field between min and max in any of the shards, or for any of their fields
+// if field is empty. Only the indexes of the shard files are read. A nil filter
+// is returned if min and max span all timestamps.
+func seriesValueFilter(shards []*Shard, sfile *SeriesFile, field string, min, max int64) seriesIDFilter {
+	if min <= models.MinNanoTime && max >= models.MaxNanoTime {
+		return nil
+	}
+
+	// Series are checked again for each tag key or value they belong to.
+	checked := make(map[uint64]bool)
+	return func(id uint64) (bool, error) {
+		if ok, found := checked[id]; found {
+			return ok, nil
+		}
+
+		name, tags := sfile.Series(id)
+		if name == nil {
+			return false, nil
+		}
+
+		key := models.MakeKey(name, tags)
+		var ok bool
+		for _, sh := range shards {
+			var err error
+			if ok, err = sh.HasFieldValues(key, field, min, max); err != nil {
+				return false, err
+			} else if ok {
+				break
+			}
+		}
+		checked[id] = ok
+		return ok, nil
+	}
+}
+
+// indexSet returns an IndexSet for the indexes of the provided shards.
+func (s *Store) indexSet(shardIDs []uint64) IndexSet {
+	is := IndexSet{Indexes: make([]Index, 0, len(shardIDs))}
+	s.mu.RLock()
+	for _, sid := range shardIDs {
+		shard, ok := s.shards[sid]
+		if !ok {
+			continue
+		}
+
+		if is.SeriesFile == nil {
+			is.SeriesFile = shard.sfile
+		}
+		is.Indexes = append(is.Indexes, shard.index)
+	}
+	s.mu.RUnlock()
+	return is.DedupeInmemIndexes()
+}
+
+// SetCompactionThroughput changes the read and write throughput limits in
+// bytes per second of level and full compactions.  A limit of 0 disables it.
+// The new limits apply to running compactions.
+func (s *Store) SetCompactionThroughput(read, write int) {
+	s.mu.RLock()
+	t := s.compactionThrottle
+	s.mu.RUnlock()
+	if t != nil {
+		t.SetThroughput(read, write)
+	}
+}
+
+// ObserveQueryLatency records the duration of a finished query for adaptive
+// compaction throttling.
+func (s *Store) ObserveQueryLatency(d time.Duration) {
+	s.mu.RLock()
+	t := s.compactionThrottle
+	s.mu.RUnlock()
+	if t != nil && t.Adaptive() {
+		t.ObserveQueryLatency(d)
+	}
+}
+
+// monitorCompactionThroughput periodically adjusts the compaction throughput
+// limits to the query and WAL fsync latencies.
+func (s *Store) monitorCompactionThroughput() {
+	defer s.wg.Done()
+	t := time.NewTicker(compactionThrottleInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-t.C:
+			s.compactionThrottle.adjust()
+		}
+	}
+}
+
 func (s *Store) monitorShards() {
 	defer s.wg.Done()
 	t := time.NewTicker(10 * time.Second)
 	defer t.Stop()
 	t2 := time.NewTicker(time.Minute)
 	defer t2.Stop()
+
+	// Idle series are only checked if a retention policy has a TTL.
+	var idleC <-chan time.Time
+	if interval := time.Duration(s.EngineOptions.Config.SeriesIdleCheckInterval); interval > 0 && len(s.EngineOptions.Config.SeriesIdleTTLs) > 0 {
+		t3 := time.NewTicker(interval)
+		defer t3.Stop()
+		idleC = t3.C
+	}
+
+	// Series files are only compacted if a threshold is set.
+	var compactC <-chan time.Time
+	threshold := s.EngineOptions.Config.SeriesFileCompactThreshold
+	if interval := time.Duration(s.EngineOptions.Config.SeriesFileCompactCheckInterval); interval > 0 && threshold > 0 {
+		t4 := time.NewTicker(interval)
+		defer t4.Stop()
+		compactC = t4.C
+	}
+
 	for {
 		select {
 		case <-s.closing:
 			return
+		case <-idleC:
+			if err := s.DeleteIdleSeries(); err != nil {
+				s.Logger.Warn("Error while removing idle series", zap.Error(err))
+			}
+		case <-compactC:
+			if err := s.CompactSeriesFiles(threshold); err != nil && err != ErrStoreClosed {
+				s.Logger.Warn("Error while compacting series files", zap.Error(err))
+			}
 		case <-t.C:
 			s.mu.RLock()
 			for _, sh := range s.shards {
@@ -24,7 +24,9 @@ import (
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/deep"
 	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
 	"github.com/influxdata/influxql"
 )
 
@@ -160,6 +162,348 @@ func TestStore_DeleteSeries_NonExistentDB(t *testing.T) {
 	}
 }
 
+// Ensure the store compacts series files once most of their series are deleted.
+func TestStore_CompactSeriesFiles(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		// Write series with large keys so that each series file partition
+		// spans more than one segment.
+		value := strings.Repeat("x", 4000)
+		points := make([]models.Point, 0, 12000)
+		for i := 0; i < cap(points); i++ {
+			name := "cpu"
+			if i%12 == 0 {
+				name = "mem"
+			}
+			points = append(points, models.MustNewPoint(name, models.NewTags(map[string]string{"host": fmt.Sprintf("%s%d", value, i)}), map[string]interface{}{"value": 1.0}, time.Unix(0, 0)))
+		}
+
+		// Keep the points in the cache.  Series whose values are being
+		// snapshotted are not dropped from the index by the delete.
+		s.EngineOptions.Config.CacheSnapshotMemorySize = 1 << 30
+
+		if err := s.CreateShard("db", "rp", 0, true); err != nil {
+			t.Fatal(err)
+		} else if err := s.BatchWrite(0, points); err != nil {
+			t.Fatal(err)
+		}
+
+		// Delete most series.
+		if err := s.DeleteSeries("db", []influxql.Source{&influxql.Measurement{Name: "cpu"}}, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		before := dirSize(t, filepath.Join(s.Path(), "db", tsdb.SeriesFileDirectory))
+		if err := s.CompactSeriesFiles(0.5); err != nil {
+			t.Fatal(err)
+		}
+		after := dirSize(t, filepath.Join(s.Path(), "db", tsdb.SeriesFileDirectory))
+		if after >= before {
+			t.Fatalf("series file did not shrink: before=%d after=%d", before, after)
+		}
+
+		// Verify remaining series before and after reopening the store.
+		for i := 0; i < 2; i++ {
+			if n, err := s.SeriesCardinality("db"); err != nil {
+				t.Fatal(err)
+			} else if n != 1000 {
+				t.Fatalf("unexpected series cardinality: %d", n)
+			}
+
+			if err := s.Reopen(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Verify deleted series can be written again.
+		s.MustWriteToShardString(0, "cpu,host=a value=1 0")
+		if n, err := s.SeriesCardinality("db"); err != nil {
+			t.Fatal(err)
+		} else if n != 1001 {
+			t.Fatalf("unexpected series cardinality: %d", n)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+// Ensure the store drops series without recent values in their retention policy.
+func TestStore_DeleteIdleSeries(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		old := time.Now().Add(-3 * time.Hour).Unix()
+		now := time.Now().Unix()
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			fmt.Sprintf("cpu,host=a value=1 %d", old),
+			fmt.Sprintf("cpu,host=b value=1 %d", old),
+		)
+		s.MustCreateShardWithData("db0", "rp0", 2,
+			fmt.Sprintf("cpu,host=b value=1 %d", now),
+			fmt.Sprintf("cpu,host=c value=1 %d", now),
+		)
+		s.MustCreateShardWithData("db0", "rp1", 3,
+			fmt.Sprintf("cpu,host=a value=1 %d", old),
+		)
+		for _, id := range []uint64{1, 2, 3} {
+			if err := s.Shard(id).ScheduleFullCompaction(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Series are not idle before the TTL has passed.
+		s.EngineOptions.Config.SeriesIdleTTLs = map[string]map[string]toml.Duration{"db0": {"rp0": toml.Duration(4 * time.Hour)}}
+		if err := s.DeleteIdleSeries(); err != nil {
+			t.Fatal(err)
+		} else if got, exp := s.Shard(1).SeriesN(), int64(2); got != exp {
+			t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+		}
+
+		// Only the series without recent values in rp0 is dropped from rp0.
+		s.EngineOptions.Config.SeriesIdleTTLs["db0"]["rp0"] = toml.Duration(time.Hour)
+		if err := s.DeleteIdleSeries(); err != nil {
+			t.Fatal(err)
+		}
+		for id, exp := range map[uint64]int64{1: 1, 2: 2, 3: 1} {
+			if got := s.Shard(id).SeriesN(); got != exp {
+				t.Fatalf("unexpected series count for shard %d: got %d, exp %d", id, got, exp)
+			}
+		}
+		if n, err := s.SeriesCardinality("db0"); err != nil {
+			t.Fatal(err)
+		} else if n != 3 {
+			t.Fatalf("unexpected series cardinality: %d", n)
+		}
+
+		// Series idle in every retention policy are no longer in the database.
+		s.EngineOptions.Config.SeriesIdleTTLs["db0"]["rp1"] = toml.Duration(time.Hour)
+		if err := s.DeleteIdleSeries(); err != nil {
+			t.Fatal(err)
+		} else if got, exp := s.Shard(3).SeriesN(), int64(0); got != exp {
+			t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+		}
+		if n, err := s.SeriesCardinality("db0"); err != nil {
+			t.Fatal(err)
+		} else if n != 2 {
+			t.Fatalf("unexpected series cardinality: %d", n)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+// Ensure ingested TSM files are reported as changes to their shard.
+func TestStore_IngestTSMFiles(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		var changes [][3]int64
+		s.OnShardChange = func(shardID uint64, min, max int64) {
+			changes = append(changes, [3]int64{int64(shardID), min, max})
+		}
+
+		if err := s.CreateShard("db0", "rp0", 1, true); err != nil {
+			t.Fatal(err)
+		}
+
+		path := filepath.Join(s.Path(), "upload.tsm")
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := tsm1.NewTSMWriter(f)
+		if err != nil {
+			t.Fatal(err)
+		} else if err := w.Write(tsm1.SeriesFieldKeyBytes("cpu,host=a", "value"), []tsm1.Value{tsm1.NewValue(10, 1.0)}); err != nil {
+			t.Fatal(err)
+		} else if err := w.WriteIndex(); err != nil {
+			t.Fatal(err)
+		} else if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.IngestTSMFiles(1, []string{path}, 0, 100); err != nil {
+			t.Fatal(err)
+		} else if got, exp := changes, [][3]int64{{1, 0, 100}}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected shard changes: got %v, exp %v", got, exp)
+		}
+		if n, err := s.SeriesCardinality("db0"); err != nil {
+			t.Fatal(err)
+		} else if n != 1 {
+			t.Fatalf("unexpected series cardinality: %d", n)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+// Ensure dropped idle series and deleted shards are reported as changes to
+// their shard.
+func TestStore_OnShardChange(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		var changes [][3]int64
+		s.OnShardChange = func(shardID uint64, min, max int64) {
+			changes = append(changes, [3]int64{int64(shardID), min, max})
+		}
+		s.EngineOptions.Config.BulkLoadBufferSize = 0
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			fmt.Sprintf("cpu,host=a value=1 %d", time.Now().Add(-3*time.Hour).Unix()),
+		)
+		s.MustCreateShardWithData("db0", "rp0", 2,
+			fmt.Sprintf("cpu,host=b value=1 %d", time.Now().Unix()),
+		)
+		for _, id := range []uint64{1, 2} {
+			if err := s.Shard(id).ScheduleFullCompaction(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		s.EngineOptions.Config.SeriesIdleTTLs = map[string]map[string]toml.Duration{"db0": {"rp0": toml.Duration(time.Hour)}}
+		if err := s.DeleteIdleSeries(); err != nil {
+			t.Fatal(err)
+		} else if got, exp := changes, [][3]int64{{1, math.MinInt64, math.MaxInt64}}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected shard changes: got %v, exp %v", got, exp)
+		}
+
+		changes = nil
+		if err := s.DeleteShard(2); err != nil {
+			t.Fatal(err)
+		} else if got, exp := changes, [][3]int64{{2, math.MinInt64, math.MaxInt64}}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected shard changes: got %v, exp %v", got, exp)
+		}
+
+		// Bulk loads are reported once they are written, and are written
+		// while the compactions of an idle shard are disabled.  The shard
+		// writes every bulk load at once.
+		changes = nil
+		s.Shard(1).SetCompactionsEnabled(false)
+		points := []models.Point{
+			models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "c"}), map[string]interface{}{"value": 1.0}, time.Unix(3, 0)),
+			models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "c"}), map[string]interface{}{"value": 2.0}, time.Unix(1, 0)),
+		}
+		if err := s.BulkLoadShard(1, points); err != nil {
+			t.Fatal(err)
+		} else if got, exp := changes, [][3]int64{{1, 1000000000, 3000000000}}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected shard changes: got %v, exp %v", got, exp)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+// Ensure the estimated series of the series limits are reported and exclude
+// dropped series.
+func TestStore_SeriesLimits_Statistics(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore("tsi1")
+	defer s.Close()
+	s.EngineOptions.Config.SeriesLimits = map[string]map[string]int{"db0": {"*": 10, "cpu": 5}}
+
+	s.MustCreateShardWithData("db0", "rp0", 1,
+		"cpu,host=a value=1 0",
+		"cpu,host=b value=1 0",
+		"mem,host=a value=1 0",
+	)
+	s.MustCreateShardWithData("db0", "rp0", 2, "cpu,host=c value=1 0")
+
+	// numSeries returns the estimated series of the database and of cpu.
+	numSeries := func() (db, cpu int64) {
+		for _, stat := range s.Statistics(nil) {
+			if stat.Name != "seriesLimits" || stat.Tags["database"] != "db0" {
+				continue
+			}
+			if stat.Tags["measurement"] == "cpu" {
+				cpu = stat.Values["numSeries"].(int64)
+			} else {
+				db = stat.Values["numSeries"].(int64)
+			}
+		}
+		return db, cpu
+	}
+
+	if db, cpu := numSeries(); db != 4 || cpu != 3 {
+		t.Fatalf("unexpected series: db=%d cpu=%d", db, cpu)
+	}
+
+	if err := s.DeleteShard(2); err != nil {
+		t.Fatal(err)
+	} else if db, cpu := numSeries(); db != 3 || cpu != 2 {
+		t.Fatalf("unexpected series after deleting shard: db=%d cpu=%d", db, cpu)
+	}
+
+	cond, err := influxql.ParseExpr(`host = 'a'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteSeries("db0", []influxql.Source{&influxql.Measurement{Name: "cpu"}}, cond); err != nil {
+		t.Fatal(err)
+	} else if db, cpu := numSeries(); db != 2 || cpu != 1 {
+		t.Fatalf("unexpected series after dropping series: db=%d cpu=%d", db, cpu)
+	}
+
+	// Series dropped from the estimates can be created again.
+	s.MustWriteToShardString(1, "cpu,host=a value=2 0")
+	if db, cpu := numSeries(); db != 3 || cpu != 2 {
+		t.Fatalf("unexpected series after recreating series: db=%d cpu=%d", db, cpu)
+	}
+}
+
+func TestStore_SeriesLimits(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore("tsi1")
+	defer s.Close()
+	s.EngineOptions.Config.SeriesLimits = map[string]map[string]int{"db0": {"*": 10, "cpu": 5, "disk": 2}}
+
+	if limits := s.SeriesLimits("db0"); limits != nil {
+		t.Fatalf("unexpected limits before writing: %v", limits)
+	}
+
+	s.MustCreateShardWithData("db0", "rp0", 1,
+		"cpu,host=a value=1 0",
+		"cpu,host=b value=1 0",
+		"mem,host=a value=1 0",
+	)
+	s.MustCreateShardWithData("db1", "rp0", 2, "cpu,host=a value=1 0")
+
+	exp := []tsdb.SeriesLimitInfo{
+		{Measurement: "*", Limit: 10, SeriesN: 3},
+		{Measurement: "cpu", Limit: 5, SeriesN: 2},
+		{Measurement: "disk", Limit: 2},
+	}
+	if got := s.SeriesLimits("db0"); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected limits:\n\tgot=%+v\n\texp=%+v", got, exp)
+	}
+	if limits := s.SeriesLimits("db1"); limits != nil {
+		t.Fatalf("unexpected limits of unlimited database: %v", limits)
+	}
+}
+
 // Ensure the store can delete an existing shard.
 func TestStore_DeleteShard(t *testing.T) {
 	t.Parallel()
@@ -249,6 +593,144 @@ func TestStore_DeleteShard(t *testing.T) {
 	}
 }
 
+// Ensure the store moves old, fully compacted shard groups to the cold
+// directory and that their shards remain usable.
+func TestStore_TierShardGroup(t *testing.T) {
+	t.Parallel()
+
+	test := func(index string) error {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		coldDir, err := ioutil.TempDir("", "influxdb-tsdb-cold-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(coldDir)
+
+		s.EngineOptions.Config.ColdDir = coldDir
+		s.EngineOptions.Config.ColdShardAges = map[string]map[string]toml.Duration{
+			"db0": {"rp0": toml.Duration(time.Hour)},
+		}
+
+		if err := s.CreateShard("db0", "rp0", 1, true); err != nil {
+			return err
+		} else if err := s.CreateShard("db0", "rp1", 2, true); err != nil {
+			return err
+		}
+
+		// Shards are moved once their shard group is older than the age of
+		// their retention policy.
+		if moved, err := s.TierShardGroup([]uint64{1}, time.Now().Add(-time.Minute), nil); err != nil {
+			return err
+		} else if len(moved) != 0 {
+			return fmt.Errorf("shard moved before its age was reached")
+		}
+		if moved, err := s.TierShardGroup([]uint64{2}, time.Now().Add(-2*time.Hour), nil); err != nil {
+			return err
+		} else if len(moved) != 0 {
+			return fmt.Errorf("shard moved without an age for its retention policy")
+		}
+
+		// No shard of a group is moved while any of them has data in its
+		// cache.
+		s.MustCreateShardWithData("db0", "rp0", 4, "cpu,host=c v=1")
+		if moved, err := s.TierShardGroup([]uint64{1, 4}, time.Now().Add(-2*time.Hour), nil); err != nil {
+			return err
+		} else if len(moved) != 0 {
+			return fmt.Errorf("shard group moved before it was fully compacted: %v", moved)
+		} else if s.Shard(1).IsCold() || s.Shard(4).IsCold() {
+			return fmt.Errorf("expected shards to be hot")
+		}
+
+		if moved, err := s.TierShardGroup([]uint64{1, 5}, time.Now().Add(-2*time.Hour), nil); err != nil {
+			return err
+		} else if !reflect.DeepEqual(moved, []uint64{1}) {
+			return fmt.Errorf("unexpected moved shards: %v", moved)
+		}
+
+		shardColdDir := filepath.Join(coldDir, "db0", "rp0", "1")
+		if !s.Shard(1).IsCold() {
+			return fmt.Errorf("expected shard to be cold")
+		} else if _, err := os.Stat(shardColdDir); err != nil {
+			return err
+		}
+
+		// The shard can be written to and read from its new location, also
+		// after the store is reopened.
+		s.MustWriteToShardString(1, "cpu,host=a v=1")
+		if err := s.Reopen(); err != nil {
+			return err
+		}
+		if sh := s.Shard(1); sh == nil {
+			return fmt.Errorf("shard missing")
+		} else if !sh.IsCold() {
+			return fmt.Errorf("expected shard to be cold after reopen")
+		}
+
+		keys, err := s.TagKeys(nil, []uint64{1}, nil)
+		if err != nil {
+			return err
+		}
+		expKeys := []tsdb.TagKeys{{Measurement: "cpu", Keys: []string{"host"}}}
+		if got, exp := keys, expKeys; !reflect.DeepEqual(got, exp) {
+			return fmt.Errorf("got keys %v, expected %v", got, exp)
+		}
+
+		// A move interrupted before the shard directory was replaced with a
+		// symlink is reverted when the store is opened.
+		s.MustCreateShardWithData("db0", "rp0", 3, "cpu,host=b v=1")
+		if err := s.Store.Close(); err != nil {
+			return err
+		}
+		path := filepath.Join(s.Path(), "db0", "rp0", "3")
+		if err := os.Rename(path, path+".hot"); err != nil {
+			return err
+		}
+		for _, dir := range []string{"3", "3.tmp"} {
+			if err := os.MkdirAll(filepath.Join(coldDir, "db0", "rp0", dir), 0700); err != nil {
+				return err
+			}
+		}
+
+		s.Store = tsdb.NewStore(s.Path())
+		s.EngineOptions.IndexVersion = index
+		s.EngineOptions.Config.WALDir = filepath.Join(s.Path(), "wal")
+		s.EngineOptions.Config.ColdDir = coldDir
+		if err := s.Open(); err != nil {
+			return err
+		}
+		if sh := s.Shard(3); sh == nil {
+			return fmt.Errorf("shard missing after recovery")
+		} else if sh.IsCold() {
+			return fmt.Errorf("expected shard to be hot after recovery")
+		} else if n := sh.SeriesN(); n != 1 {
+			return fmt.Errorf("unexpected series count after recovery: %d", n)
+		}
+		for _, p := range []string{path + ".hot", filepath.Join(coldDir, "db0", "rp0", "3"), filepath.Join(coldDir, "db0", "rp0", "3.tmp")} {
+			if _, err := os.Stat(p); !os.IsNotExist(err) {
+				return fmt.Errorf("expected %s to be removed: %v", p, err)
+			}
+		}
+
+		// Deleting the shard removes its files from the cold directory.
+		if err := s.DeleteShard(1); err != nil {
+			return err
+		} else if _, err := os.Stat(shardColdDir); !os.IsNotExist(err) {
+			return fmt.Errorf("expected cold shard files to be removed: %v", err)
+		}
+		return nil
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			if err := test(index); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 // Ensure the store can create a snapshot to a shard.
 func TestStore_CreateShardSnapShot(t *testing.T) {
 	t.Parallel()
@@ -1505,6 +1987,163 @@ func TestStore_TagValues_Auth(t *testing.T) {
 	}
 }
 
+func TestStore_TagKeyIterator(t *testing.T) {
+	t.Parallel()
+
+	test := func(index string) error {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 0,
+			`cpu,host=serverA,region=east value=1 0`,
+			`cpu,host=serverB,secret=foo value=2 10`,
+			`mem,host=serverA,rack=1 value=3 20`,
+		)
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`disk,path=/ value=4 30`,
+		)
+
+		tests := []struct {
+			cond     string
+			min, max int64
+			exp      []string
+		}{
+			{cond: "", exp: []string{"host", "path", "rack", "region", "secret"}},
+			{cond: "_name = 'cpu'", exp: []string{"host", "region", "secret"}},
+			{cond: "host = 'serverA'", exp: []string{"host", "rack", "region"}},
+			{cond: "_name = 'cpu' AND host = 'serverA'", exp: []string{"host", "region"}},
+			{cond: "host = 'serverC'", exp: nil},
+			{cond: "", min: 5e9, max: 20e9, exp: []string{"host", "rack", "secret"}},
+			{cond: "_name = 'cpu'", min: 15e9, max: 40e9, exp: nil},
+		}
+
+		for _, tt := range tests {
+			var cond influxql.Expr
+			if tt.cond != "" {
+				cond = influxql.MustParseExpr(tt.cond)
+			}
+
+			min, max := tt.min, tt.max
+			if min == 0 && max == 0 {
+				min, max = models.MinNanoTime, models.MaxNanoTime
+			}
+
+			itr, err := s.TagKeyIterator([]uint64{0, 1}, cond, min, max)
+			if err != nil {
+				return err
+			}
+
+			var got []string
+			if itr != nil {
+				for {
+					key, err := itr.Next()
+					if err != nil {
+						return err
+					} else if key == nil {
+						break
+					}
+					got = append(got, string(key))
+				}
+				itr.Close()
+			}
+
+			if !reflect.DeepEqual(got, tt.exp) {
+				return fmt.Errorf("cond %q, time range %d-%d: got %v, expected %v", tt.cond, tt.min, tt.max, got, tt.exp)
+			}
+		}
+		return nil
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			if err := test(index); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestStore_TagValueIterator(t *testing.T) {
+	t.Parallel()
+
+	test := func(index string) error {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 0,
+			`cpu,host=serverA,region=east value=1 0`,
+			`cpu,host=serverB,region=west value=2 10`,
+			`mem,host=serverC value=3 20`,
+		)
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=serverA,region=east value=1 30`,
+			`cpu,host=serverD,region=east value=4 30`,
+		)
+
+		tests := []struct {
+			key      string
+			cond     string
+			min, max int64
+			exp      []string
+		}{
+			{key: "host", exp: []string{"serverA", "serverB", "serverC", "serverD"}},
+			{key: "host", cond: "_name = 'cpu'", exp: []string{"serverA", "serverB", "serverD"}},
+			{key: "host", cond: "region = 'east'", exp: []string{"serverA", "serverD"}},
+			{key: "region", cond: "host =~ /server[BC]/", exp: []string{"west"}},
+			{key: "_name", exp: []string{"cpu", "mem"}},
+			{key: "_name", cond: "host = 'serverC'", exp: []string{"mem"}},
+			{key: "rack", exp: nil},
+			{key: "host", min: 25e9, max: 30e9, exp: []string{"serverA", "serverD"}},
+			{key: "region", cond: "host = 'serverA'", min: 5e9, max: 25e9, exp: nil},
+			{key: "_name", min: 15e9, max: 20e9, exp: []string{"mem"}},
+		}
+
+		for _, tt := range tests {
+			var cond influxql.Expr
+			if tt.cond != "" {
+				cond = influxql.MustParseExpr(tt.cond)
+			}
+
+			min, max := tt.min, tt.max
+			if min == 0 && max == 0 {
+				min, max = models.MinNanoTime, models.MaxNanoTime
+			}
+
+			itr, err := s.TagValueIterator([]uint64{0, 1}, tt.key, cond, min, max)
+			if err != nil {
+				return err
+			}
+
+			var got []string
+			if itr != nil {
+				for {
+					value, err := itr.Next()
+					if err != nil {
+						return err
+					} else if value == nil {
+						break
+					}
+					got = append(got, string(value))
+				}
+				itr.Close()
+			}
+
+			if !reflect.DeepEqual(got, tt.exp) {
+				return fmt.Errorf("key %q, cond %q, time range %d-%d: got %v, expected %v", tt.key, tt.cond, tt.min, tt.max, got, tt.exp)
+			}
+		}
+		return nil
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			if err := test(index); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 // Helper to create some tag values
 func createTagValues(mname string, kvs map[string][]string) tsdb.TagValues {
 	var sz int
@@ -1841,6 +2480,22 @@ func (s *Store) BatchWrite(shardID int, points []models.Point) error {
 	return nil
 }
 
+// dirSize returns the total size of the files under path.
+func dirSize(tb testing.TB, path string) int64 {
+	var n int64
+	if err := filepath.Walk(path, func(_ string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if !fi.IsDir() {
+			n += fi.Size()
+		}
+		return nil
+	}); err != nil {
+		tb.Fatal(err)
+	}
+	return n
+}
+
 // ParseTags returns an instance of Tags for a comma-delimited list of key/values.
 func ParseTags(s string) query.Tags {
 	m := make(map[string]string)